@@ -1,9 +1,11 @@
 package autograd_test
 
 import (
+	"bytes"
 	"fmt"
 
 	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/layer"
 	"github.com/itsubaki/autograd/model"
 	"github.com/itsubaki/autograd/numerical"
 	"github.com/itsubaki/autograd/optimizer"
@@ -25,11 +27,11 @@ func Example() {
 
 func Example_numericalDiff() {
 	// p23
-	v := []*variable.Variable{variable.New(0.5)}
-	f := func(x ...*variable.Variable) *variable.Variable {
-		A := F.Square
-		B := F.Exp
-		C := F.Square
+	v := []*variable.Variable[float64]{variable.New(0.5)}
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		A := F.Square[float64]
+		B := F.Exp[float64]
+		C := F.Square[float64]
 		return C(B(A(x...)))
 	}
 
@@ -66,11 +68,11 @@ func Example_creator() {
 	// variable(1.648721270700128)
 	// variable(3.297442541400256)
 	//
-	// *variable.SquareT[variable(1.2840254166877414)]
+	// *variable.SquareT[float64][variable(1.2840254166877414)]
 	// true
-	// *variable.ExpT[variable(0.25)]
+	// *variable.ExpT[float64][variable(0.25)]
 	// true
-	// *variable.SquareT[variable(0.5)]
+	// *variable.SquareT[float64][variable(0.5)]
 	// true
 }
 
@@ -165,7 +167,7 @@ func Example_generation() {
 
 func Example_sphere() {
 	// p167
-	sphere := func(x, y *variable.Variable) *variable.Variable {
+	sphere := func(x, y *variable.Variable[float64]) *variable.Variable[float64] {
 		// x^2 + y^2
 		return F.Add(F.Pow(2.0)(x), F.Pow(2.0)(y))
 	}
@@ -185,7 +187,7 @@ func Example_sphere() {
 
 func Example_matyas() {
 	// p167
-	matyas := func(x, y *variable.Variable) *variable.Variable {
+	matyas := func(x, y *variable.Variable[float64]) *variable.Variable[float64] {
 		// 0.26(x^2 + y^2) - 0.48xy
 		z0 := F.MulC(0.26, F.Add(F.Pow(2.0)(x), F.Pow(2.0)(y)))
 		z1 := F.MulC(0.48, F.Mul(x, y))
@@ -207,7 +209,7 @@ func Example_matyas() {
 
 func Example_rosenbrock() {
 	// p205
-	rosenbrock := func(x0, x1 *variable.Variable) *variable.Variable {
+	rosenbrock := func(x0, x1 *variable.Variable[float64]) *variable.Variable[float64] {
 		// 100 * (x1 - x0^2)^2 + (x0 - 1)^2
 		y0 := F.MulC(100, F.Pow(2.0)(F.Sub(x1, F.Pow(2.0)(x0))))
 		y1 := F.Pow(2.0)(F.AddC(-1.0, x0))
@@ -229,14 +231,14 @@ func Example_rosenbrock() {
 
 func Example_gradientDescent() {
 	// p206
-	rosenbrock := func(x0, x1 *variable.Variable) *variable.Variable {
+	rosenbrock := func(x0, x1 *variable.Variable[float64]) *variable.Variable[float64] {
 		// 100 * (x1 - x0^2)^2 + (x0 - 1)^2
 		y0 := F.Pow(2.0)(F.Sub(x1, F.Pow(2.0)(x0)))
 		y1 := F.Pow(2.0)(F.AddC(-1.0, x0))
 		return F.Add(F.MulC(100, y0), y1)
 	}
 
-	update := func(lr float64, x ...*variable.Variable) {
+	update := func(lr float64, x ...*variable.Variable[float64]) {
 		for _, v := range x {
 			v.Data = tensor.F2(v.Data, v.Grad.Data, func(a, b float64) float64 {
 				return a - lr*b
@@ -279,7 +281,7 @@ func Example_gradientDescent() {
 
 func Example_newton() {
 	// p214
-	f := func(x *variable.Variable) *variable.Variable {
+	f := func(x *variable.Variable[float64]) *variable.Variable[float64] {
 		// y = x^4 - 2x^2
 		y0 := F.Pow(4.0)(x)  // x^4
 		y1 := F.Pow(2.0)(x)  // x^2
@@ -287,7 +289,7 @@ func Example_newton() {
 		return F.Sub(y0, y2) // x^4 - 2x^2
 	}
 
-	gx2 := func(x *variable.Variable) *variable.Variable {
+	gx2 := func(x *variable.Variable[float64]) *variable.Variable[float64] {
 		// y = 12x^2 - 4
 		return F.AddC(-4.0, F.MulC(12, F.Pow(2.0)(x)))
 	}
@@ -320,7 +322,7 @@ func Example_newton() {
 
 func Example_newton_double() {
 	// p239
-	f := func(x *variable.Variable) *variable.Variable {
+	f := func(x *variable.Variable[float64]) *variable.Variable[float64] {
 		// y = x^4 - 2x^2
 		y0 := F.Pow(4.0)(x)  // x^4
 		y1 := F.Pow(2.0)(x)  // x^2
@@ -392,11 +394,11 @@ func Example_linearRegression() {
 	w := variable.New(0.0).Reshape(1, 1)
 	b := variable.New(0.0).Reshape(1, 1)
 
-	predict := func(x *variable.Variable) *variable.Variable {
+	predict := func(x *variable.Variable[float64]) *variable.Variable[float64] {
 		return F.Add(F.MatMul(x, w), b) // y = x.w + b
 	}
 
-	update := func(lr float64, x ...*variable.Variable) {
+	update := func(lr float64, x ...*variable.Variable[float64]) {
 		for _, v := range x {
 			v.Data = tensor.F2(v.Data, v.Grad.Data, func(a, b float64) float64 {
 				return a - lr*b
@@ -407,7 +409,7 @@ func Example_linearRegression() {
 	lr := 0.1
 	iters := 100
 
-	var loss *variable.Variable
+	var loss *variable.Variable[float64]
 	for range iters {
 		y := predict(x)
 		loss = F.MeanSquaredError(y, t)
@@ -436,11 +438,11 @@ func Example_linearRegression() {
 func Example_mlp() {
 	s := rand.Const()
 	m := model.NewMLP([]int{10, 1},
-		model.WithMLPSource(s),
-		model.WithMLPActivation(F.ReLU),
+		model.WithMLPSource[float64](s),
+		model.WithMLPActivation[float64](F.ReLU),
 	)
 
-	o := optimizer.SGD{
+	o := optimizer.SGD[float64]{
 		LearningRate: 0.2,
 	}
 
@@ -472,3 +474,46 @@ func Example_mlp() {
 	// 0.06942337
 	// 0.06940859
 }
+
+func Example_mlpFloat32() {
+	s := rand.Const()
+	m := model.NewMLP([]int{10, 1},
+		model.WithMLPSource[float32](s),
+		model.WithMLPActivation(F.ReLU[float32]),
+	)
+
+	o := optimizer.SGD[float32]{
+		LearningRate: 0.2,
+	}
+
+	x := variable.Convert[float32](variable.Rand([]int{100, 1}, s))
+	t := variable.Convert[float32](variable.Rand([]int{100, 1}, s))
+
+	for range 100 {
+		y := m.Forward(x)
+		loss := F.MeanSquaredError(y, t)
+
+		m.Cleargrads()
+		loss.Backward()
+		o.Update(m)
+	}
+
+	var buf bytes.Buffer
+	if err := layer.Save(&buf, m.Params()); err != nil {
+		panic(err)
+	}
+
+	restored := model.NewMLP([]int{10, 1},
+		model.WithMLPInSize[float32](1),
+		model.WithMLPActivation(F.ReLU[float32]),
+	)
+	if err := layer.Load(&buf, restored.Params()); err != nil {
+		panic(err)
+	}
+
+	loss := F.MeanSquaredError(restored.Forward(x), t)
+	fmt.Printf("%T %.4f\n", loss.At(), loss.At())
+
+	// Output:
+	// float32 0.0694
+}
@@ -9,17 +9,17 @@ import (
 	"github.com/itsubaki/autograd/variable"
 )
 
-type Func func(x ...*variable.Variable) *variable.Variable
+type Func func(x ...*variable.Variable[float64]) *variable.Variable[float64]
 
 var fmap = map[string]Func{
-	"sin":    F.Sin,
-	"cos":    F.Cos,
-	"tanh":   F.Tanh,
-	"exp":    F.Exp,
-	"log":    F.Log,
+	"sin":    F.Sin[float64],
+	"cos":    F.Cos[float64],
+	"tanh":   F.Tanh[float64],
+	"exp":    F.Exp[float64],
+	"log":    F.Log[float64],
 	"pow":    F.Pow(3.0),
-	"square": F.Square,
-	"neg":    F.Neg,
+	"square": F.Square[float64],
+	"neg":    F.Neg[float64],
 }
 
 func main() {
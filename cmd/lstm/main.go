@@ -66,7 +66,7 @@ func (l *DataLoader) Next() bool {
 }
 
 // Batch returns a batch of data and label as variable.Variable, and increments the iterator.
-func (l *DataLoader) Batch() (*variable.Variable, *variable.Variable) {
+func (l *DataLoader) Batch() (*variable.Variable[float64], *variable.Variable[float64]) {
 	begin, end := l.iter*l.BatchSize, (l.iter+1)*l.BatchSize
 	x, y := l.Data[begin:end], l.Label[begin:end]
 	l.iter++
@@ -74,9 +74,9 @@ func (l *DataLoader) Batch() (*variable.Variable, *variable.Variable) {
 }
 
 // Seq2 returns an iterator that yields batches of data and label as variable.Variable.
-func (l *DataLoader) Seq2() iter.Seq2[*variable.Variable, *variable.Variable] {
+func (l *DataLoader) Seq2() iter.Seq2[*variable.Variable[float64], *variable.Variable[float64]] {
 	l.iter = 0
-	return func(yield func(*variable.Variable, *variable.Variable) bool) {
+	return func(yield func(*variable.Variable[float64], *variable.Variable[float64]) bool) {
 		for l.Next() {
 			x, t := l.Batch()
 			if !yield(x, t) {
@@ -106,8 +106,8 @@ func main() {
 		Label:     dataset.Label,
 	}
 
-	m := model.NewLSTM(hiddenSize, 1)
-	o := optimizer.SGD{
+	m := model.NewLSTM[float64](hiddenSize, 1)
+	o := optimizer.SGD[float64]{
 		LearningRate: lr,
 	}
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/itsubaki/autograd/tensor"
 	"github.com/itsubaki/autograd/variable"
 )
 
@@ -19,7 +20,7 @@ type Opts struct {
 }
 
 // Var returns the DOT representation of a variable node.
-func Var(v *variable.Variable, opts ...Opts) string {
+func Var[T tensor.Float](v *variable.Variable[T], opts ...Opts) string {
 	if len(opts) > 0 && opts[0].Verbose {
 		return fmt.Sprintf(varfmt, v, v)
 	}
@@ -28,7 +29,7 @@ func Var(v *variable.Variable, opts ...Opts) string {
 }
 
 // Func returns the DOT representation of a function node and its edges.
-func Func(f *variable.Function) []string {
+func Func[T tensor.Float](f *variable.Function[T]) []string {
 	s := f.String()
 	begin, end := strings.Index(s, "."), strings.Index(s, "T[")
 	out := []string{fmt.Sprintf(fncfmt, f, s[begin+1:end])}
@@ -45,9 +46,9 @@ func Func(f *variable.Function) []string {
 }
 
 // Graph returns a DOT graph for the computation graph rooted at v.
-func Graph(v *variable.Variable, opts ...Opts) []string {
-	seen := make(map[*variable.Function]bool)
-	fs := addFunc(make([]*variable.Function, 0), v.Creator, seen)
+func Graph[T tensor.Float](v *variable.Variable[T], opts ...Opts) []string {
+	seen := make(map[*variable.Function[T]]bool)
+	fs := addFunc(make([]*variable.Function[T], 0), v.Creator, seen)
 
 	out := append([]string{"digraph g {"}, Var(v, opts...))
 	for len(fs) > 0 {
@@ -71,7 +72,7 @@ func Graph(v *variable.Variable, opts ...Opts) []string {
 }
 
 // addFunc adds a function to the list if it hasn't been seen before.
-func addFunc(fs []*variable.Function, f *variable.Function, seen map[*variable.Function]bool) []*variable.Function {
+func addFunc[T tensor.Float](fs []*variable.Function[T], f *variable.Function[T], seen map[*variable.Function[T]]bool) []*variable.Function[T] {
 	if _, ok := seen[f]; ok {
 		return fs
 	}
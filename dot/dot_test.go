@@ -36,12 +36,12 @@ func ExampleVar() {
 }
 
 func ExampleFunc() {
-	f0 := &variable.Function{Forwarder: &variable.SinT{}}
+	f0 := &variable.Function[float64]{Forwarder: &variable.SinT[float64]{}}
 	for _, txt := range dot.Func(f0) {
 		fmt.Println(re.ReplaceAllString(txt, dummyAddr))
 	}
 
-	f1 := &variable.Function{Forwarder: &variable.SinT{}}
+	f1 := &variable.Function[float64]{Forwarder: &variable.SinT[float64]{}}
 	fmt.Println(dot.Func(f0)[0] == dot.Func(f1)[0])
 
 	// Output:
@@ -50,10 +50,10 @@ func ExampleFunc() {
 }
 
 func Example_func() {
-	f := &variable.Function{
-		Input:     []*variable.Variable{variable.New(1)},
-		Output:    []*variable.Variable{variable.New(1)},
-		Forwarder: &variable.SinT{},
+	f := &variable.Function[float64]{
+		Input:     []*variable.Variable[float64]{variable.New(1)},
+		Output:    []*variable.Variable[float64]{variable.New(1)},
+		Forwarder: &variable.SinT[float64]{},
 	}
 
 	for _, txt := range dot.Func(f) {
@@ -114,11 +114,11 @@ func ExampleGraph_composite() {
 }
 
 func ExampleAddFunc() {
-	fs := make([]*variable.Function, 0)
-	seen := make(map[*variable.Function]bool)
+	fs := make([]*variable.Function[float64], 0)
+	seen := make(map[*variable.Function[float64]]bool)
 
-	sin := &variable.Function{Forwarder: &variable.SinT{}}
-	cos := &variable.Function{Forwarder: &variable.CosT{}}
+	sin := &variable.Function[float64]{Forwarder: &variable.SinT[float64]{}}
+	cos := &variable.Function[float64]{Forwarder: &variable.CosT[float64]{}}
 	fs = dot.AddFunc(fs, sin, seen)
 	fs = dot.AddFunc(fs, cos, seen)
 	fmt.Println(fs)
@@ -128,6 +128,6 @@ func ExampleAddFunc() {
 	fmt.Println(fs)
 
 	// Output:
-	// [*variable.SinT[] *variable.CosT[]]
-	// [*variable.SinT[] *variable.CosT[]]
+	// [*variable.SinT[float64][] *variable.CosT[float64][]]
+	// [*variable.SinT[float64][] *variable.CosT[float64][]]
 }
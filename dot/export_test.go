@@ -1,3 +1,3 @@
 package dot
 
-var AddFunc = addFunc
+var AddFunc = addFunc[float64]
@@ -7,10 +7,10 @@ import (
 
 // Accuracy returns the accuracy of the prediction.
 // The return values cannot be backpropagated.
-func Accuracy(y, t *variable.Variable) *variable.Variable {
+func Accuracy[T tensor.Float](y, t *variable.Variable[T]) *variable.Variable[T] {
 	argmax := tensor.Argmax(y.Data, 1)
 	pred := tensor.Reshape(argmax, t.Shape()...)
 	result := tensor.Equal(pred, tensor.Int(t.Data))
-	acc := tensor.Mean(result)
+	acc := tensor.Mean(tensor.F(result, func(v int) T { return T(v) }))
 	return variable.From(acc)
 }
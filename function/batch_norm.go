@@ -0,0 +1,156 @@
+package function
+
+import (
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+// BatchNorm returns a function that normalizes x[0] with shape (N, C, *spatial) for every channel,
+// and scales and shifts the result by x[1] and x[2] with shape (C,).
+// In training mode, it normalizes with the mean and the variance of the batch,
+// and moves the running statistics mean and variance with shape (C,) toward them by the momentum.
+// The running variance is updated with the unbiased variance of the batch.
+// Otherwise, it normalizes with the running statistics and does not update them.
+// They are not updated either when the session is a recomputation, such as by Checkpoint or jit.Trace.
+func BatchNorm[T tensor.Float](mean, variance *tensor.Tensor[T], momentum, eps T) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return (&variable.Function[T]{
+		Forwarder: &BatchNormT[T]{
+			Mean:     mean,
+			Var:      variance,
+			Momentum: momentum,
+			Eps:      eps,
+		},
+	}).First
+}
+
+// BatchNormT is the differentiable batch normalization operation.
+// Forward saves the mean and the inverse standard deviation of every channel,
+// which are the statistics of the batch in training mode and the running statistics otherwise.
+// Backward computes the gradients from them without creating the graph.
+// When creating the graph in training mode, it recomputes the batch statistics from x,
+// because the gradient depends on x through them.
+type BatchNormT[T tensor.Float] struct {
+	Mean, Var     *tensor.Tensor[T]
+	Momentum, Eps T
+	x, gamma      *variable.Variable[T]
+	mu, invstd    *tensor.Tensor[T]
+	axes          []int
+	train         bool
+}
+
+func (f *BatchNormT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
+	s := variable.SessionOf(x...)
+	f.x, f.gamma = x[0], x[1]
+	f.train = s.Train
+
+	// every axis but the channels
+	shape := x[0].Shape()
+	f.axes = []int{0}
+	for i := 2; i < len(shape); i++ {
+		f.axes = append(f.axes, i)
+	}
+
+	mean, variance := tensor.Clone(f.Mean), f.Var
+	if f.train {
+		mean, variance = tensor.Mean(x[0].Data, f.axes...), tensor.Variance(x[0].Data, f.axes...)
+	}
+
+	if f.train && !s.Recompute {
+		// the batch is counted once, not again when it is recomputed
+		f.update(mean, variance, x[0].Size()/shape[1])
+	}
+
+	kd := tensor.KeepDims(shape, f.axes)
+	f.mu = tensor.Reshape(mean, kd...)                                               // mean(x)
+	f.invstd = tensor.Reshape(tensor.Pow(-0.5, tensor.AddC(f.Eps, variance)), kd...) // 1 / sqrt(var(x) + eps)
+
+	xhat := tensor.Mul(tensor.Sub(x[0].Data, f.mu), f.invstd)                                             // xhat = (x - mean(x)) / sqrt(var(x) + eps)
+	y := tensor.Add(tensor.Mul(tensor.Reshape(x[1].Data, kd...), xhat), tensor.Reshape(x[2].Data, kd...)) // y = gamma * xhat + beta
+	return []*variable.Variable[T]{
+		variable.From(y),
+	}
+}
+
+func (f *BatchNormT[T]) Backward(gy ...*variable.Variable[T]) []*variable.Variable[T] {
+	c, kd := f.x.Size(1), tensor.KeepDims(f.x.Shape(), f.axes)
+	if !variable.SessionOf(gy[0]).EnableBackprop {
+		xhat := tensor.Mul(tensor.Sub(f.x.Data, f.mu), f.invstd)           // (x - mean(x)) / sqrt(var(x) + eps)
+		gbeta := tensor.SumTo(gy[0].Data, kd...)                           // sum(gy)
+		ggamma := tensor.SumTo(tensor.Mul(gy[0].Data, xhat), kd...)        // sum(gy * xhat)
+		scale := tensor.Mul(tensor.Reshape(f.gamma.Data, kd...), f.invstd) // gamma / sqrt(var(x) + eps)
+
+		gx := tensor.Mul(scale, gy[0].Data) // gamma / sqrt(var + eps) * gy
+		if f.train {
+			// gamma / sqrt(var(x) + eps) * (gy - mean(gy) - xhat * mean(gy * xhat))
+			m := 1 / T(f.x.Size()/c)
+			gx = tensor.Mul(scale, tensor.Sub(tensor.Sub(gy[0].Data, tensor.MulC(m, gbeta)), tensor.Mul(xhat, tensor.MulC(m, ggamma))))
+		}
+
+		return []*variable.Variable[T]{
+			variable.From(gx),
+			variable.From(tensor.Reshape(ggamma, c)),
+			variable.From(tensor.Reshape(gbeta, c)),
+		}
+	}
+
+	// the statistics are recomputed with differentiable operations for higher-order derivatives
+	xhat, invstd := f.normalize(f.x)
+
+	gbeta := SumTo[T](kd...)(gy[0])                  // sum(gy)
+	ggamma := SumTo[T](kd...)(Mul(gy[0], xhat))      // sum(gy * xhat)
+	scale := Mul(Reshape[T](kd...)(f.gamma), invstd) // gamma / sqrt(var(x) + eps)
+
+	gx := Mul(scale, gy[0]) // gamma / sqrt(var + eps) * gy
+	if f.train {
+		// gamma / sqrt(var(x) + eps) * (gy - mean(gy) - xhat * mean(gy * xhat))
+		m := 1 / T(f.x.Size()/c)
+		gx = Mul(scale, Sub(Sub(gy[0], MulC(m, gbeta)), Mul(xhat, MulC(m, ggamma))))
+	}
+
+	return []*variable.Variable[T]{
+		gx,
+		Reshape[T](c)(ggamma),
+		Reshape[T](c)(gbeta),
+	}
+}
+
+func (f *BatchNormT[T]) JVP(x, y, tx []*variable.Variable[T]) []*variable.Variable[T] {
+	kd := tensor.KeepDims(x[0].Shape(), f.axes)
+	xhat, invstd := f.normalize(x[0])
+
+	tn := tx[0] // the tangent of xhat times sqrt(var(x) + eps)
+	if f.train {
+		// tx - mean(tx) - xhat * mean(tx * xhat)
+		tn = Sub(Sub(tx[0], Reshape[T](kd...)(Mean[T](f.axes...)(tx[0]))), Mul(xhat, Reshape[T](kd...)(Mean[T](f.axes...)(Mul(tx[0], xhat)))))
+	}
+
+	gamma := Reshape[T](kd...)(x[1])
+	tgamma, tbeta := Reshape[T](kd...)(tx[1]), Reshape[T](kd...)(tx[2])
+	return []*variable.Variable[T]{
+		Add(Add(Mul(Mul(gamma, invstd), tn), Mul(tgamma, xhat)), tbeta), // gamma / sqrt(var + eps) * tn + tgamma * xhat + tbeta
+	}
+}
+
+// normalize returns the normalized x and the inverse of the standard deviation with the channel axis kept.
+// In training mode, they are differentiable functions of the statistics of x. Otherwise, the running statistics are constants.
+func (f *BatchNormT[T]) normalize(x *variable.Variable[T]) (*variable.Variable[T], *variable.Variable[T]) {
+	if !f.train {
+		mu := variable.From(f.mu).SetRequiresGrad(false)
+		invstd := variable.From(f.invstd).SetRequiresGrad(false)
+		return Mul(Sub(x, mu), invstd), invstd
+	}
+
+	kd := tensor.KeepDims(x.Shape(), f.axes)
+	xc := Sub(x, Reshape[T](kd...)(Mean[T](f.axes...)(x)))                                 // x - mean(x)
+	invstd := Pow[T](-0.5)(AddC(f.Eps, Reshape[T](kd...)(Mean[T](f.axes...)(Square(xc))))) // 1 / sqrt(var(x) + eps)
+	return Mul(xc, invstd), invstd
+}
+
+// update moves the running statistics toward the mean and the variance of a batch of m elements for every channel.
+func (f *BatchNormT[T]) update(mean, variance *tensor.Tensor[T], m int) {
+	unbiased := T(m) / T(max(m-1, 1))
+	for i := range f.Mean.Data {
+		f.Mean.Data[i] = (1-f.Momentum)*f.Mean.Data[i] + f.Momentum*mean.Data[i]
+		f.Var.Data[i] = (1-f.Momentum)*f.Var.Data[i] + f.Momentum*variance.Data[i]*unbiased
+	}
+}
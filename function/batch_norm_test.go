@@ -0,0 +1,167 @@
+package function_test
+
+import (
+	"fmt"
+	"testing"
+
+	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/grad"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleBatchNorm() {
+	mean, variance := tensor.Zeros[float64](2), tensor.Ones[float64](2)
+	bn := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.BatchNorm(mean, variance, 0.1, 0)(x...)
+	}
+
+	x := variable.New(
+		1, 10,
+		3, 30,
+	).Reshape(2, 2)
+	gamma := variable.New(1, 2)
+	beta := variable.New(0, 5)
+
+	y := bn(x, gamma, beta)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(gamma.Grad, beta.Grad)
+	fmt.Println(mean.Data, variance.Data)
+
+	// Output:
+	// variable[2 2]([-1 3 1 7])
+	// variable[2]([0 0]) variable[2]([2 2])
+	// [0.2 2] [1.1 20.9]
+}
+
+func ExampleBatchNorm_testMode() {
+	mean, variance := tensor.New([]int{1}, []float64{2}), tensor.New([]int{1}, []float64{4})
+	x := variable.New(0, 2, 4).Reshape(3, 1)
+
+	func() {
+		defer variable.TestMode().End()
+
+		y := F.BatchNorm(mean, variance, 0.1, 0)(x, variable.New(1), variable.New(0))
+		y.Backward()
+
+		fmt.Println(y)
+		fmt.Println(x.Grad)
+	}()
+
+	fmt.Println(mean.Data, variance.Data)
+
+	// Output:
+	// variable[3 1]([-1 0 1])
+	// variable[3 1]([0.5 0.5 0.5])
+	// [2] [4]
+}
+
+func TestBatchNorm(t *testing.T) {
+	cases := []struct {
+		shape []int
+	}{
+		{[]int{5, 3}},
+		{[]int{4, 2, 6}},
+		{[]int{3, 2, 3, 4}},
+	}
+
+	for _, c := range cases {
+		x := variable.Randn(c.shape, rand.Const(1))
+		gamma := variable.Randn(c.shape[1:2], rand.Const(2))
+		beta := variable.Randn(c.shape[1:2], rand.Const(3))
+
+		axes := []int{0}
+		for i := 2; i < len(c.shape); i++ {
+			axes = append(axes, i)
+		}
+
+		kd := tensor.KeepDims(c.shape, axes)
+		for _, train := range []bool{true, false} {
+			func() {
+				mean := tensor.Randn(c.shape[1:2], rand.Const(4))
+				variance := tensor.AddC(1, tensor.Rand(c.shape[1:2], rand.Const(5)))
+				running := []*tensor.Tensor[float64]{tensor.Clone(mean), tensor.Clone(variance)}
+
+				if !train {
+					span := variable.TestMode()
+					defer span.End()
+				}
+
+				// the normalization with the primitive operations
+				got := F.BatchNorm(mean, variance, 0.1, 1e-5)(x, gamma, beta)
+				mu, sigma2 := variable.From(running[0]), variable.From(running[1])
+				if train {
+					mu, sigma2 = F.Mean[float64](axes...)(x), F.Variance[float64](axes...)(x)
+				}
+
+				xhat := F.Div(F.Sub(x, F.Reshape[float64](kd...)(mu)), F.Pow(0.5)(F.AddC(1e-5, F.Reshape[float64](kd...)(sigma2))))
+				want := F.Add(F.Mul(F.Reshape[float64](kd...)(gamma), xhat), F.Reshape[float64](kd...)(beta))
+				if !tensor.IsCloseAll(got.Data, want.Data, 1e-10, 1e-10) {
+					t.Errorf("train=%v, got=%v, want=%v", train, got.Data.Data, want.Data.Data)
+				}
+
+				// running statistics
+				if train {
+					m := float64(x.Size() / c.shape[1])
+					running[0] = tensor.Add(tensor.MulC(0.9, running[0]), tensor.MulC(0.1, mu.Data))
+					running[1] = tensor.Add(tensor.MulC(0.9, running[1]), tensor.MulC(0.1*m/(m-1), sigma2.Data))
+				}
+
+				if !tensor.IsCloseAll(mean, running[0], 1e-12, 1e-12) || !tensor.IsCloseAll(variance, running[1], 1e-12, 1e-12) {
+					t.Errorf("train=%v, running=%v %v, want=%v %v", train, mean.Data, variance.Data, running[0].Data, running[1].Data)
+				}
+
+				// gradients
+				f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+					return F.BatchNorm(tensor.Clone(mean), tensor.Clone(variance), 0.1, 1e-5)(x...)
+				}
+
+				gradcheck(t, f, x, gamma, beta)
+			}()
+		}
+	}
+}
+
+func TestBatchNorm_double(t *testing.T) {
+	x := variable.Randn([]int{4, 2, 3}, rand.Const(1))
+	gamma := variable.Randn([]int{2}, rand.Const(2))
+	beta := variable.Randn([]int{2}, rand.Const(3))
+	w := variable.Randn([]int{4, 2, 3}, rand.Const(4))
+
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		y := F.BatchNorm(tensor.Zeros[float64](2), tensor.Ones[float64](2), 0.1, 1e-5)(x...)
+		return F.Sum[float64]()(F.Mul(w, F.Tanh(y)))
+	}
+
+	// the gradient of the squared norm of the gradient with respect to x
+	g := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		gx := grad.Grad(f)(x...)[0]
+		return F.Sum[float64]()(F.Square(gx))
+	}
+
+	gradcheck(t, g, x, gamma, beta)
+}
+
+func ExampleBatchNorm_checkpoint() {
+	mean, variance := tensor.Zeros[float64](2), tensor.Ones[float64](2)
+	bn := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.BatchNorm(mean, variance, 0.1, 0)(x...)
+	}
+
+	x := variable.New(
+		1, 10,
+		3, 30,
+	).Reshape(2, 2)
+
+	// the running statistics are not updated again when the segment is recomputed
+	y := variable.Checkpoint(bn, x, variable.New(1, 2), variable.New(0, 5))
+	y.Backward()
+
+	fmt.Println(mean.Data, variance.Data)
+
+	// Output:
+	// [0.2 2] [1.1 20.9]
+}
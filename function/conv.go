@@ -0,0 +1,200 @@
+package function
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+// ConvOpts configures a convolution.
+// Stride, Padding, Dilation and OutputPadding have one value for every spatial axis, or a single value for all of them.
+// OutputPadding is the size added to one side of the output of a transposed convolution.
+// Causal pads only the start of every spatial axis instead of Padding, so that each output depends on the inputs at or before it.
+// It is not supported by transposed convolutions.
+// The defaults are stride 1, padding 0, dilation 1, output padding 0 and 1 group.
+type ConvOpts struct {
+	Stride        []int
+	Padding       []int
+	Dilation      []int
+	OutputPadding []int
+	Groups        int
+	Causal        bool
+}
+
+// Conv1d returns a function that applies a 1-D convolution to x[0] with shape (N, C, L) and the weight x[1] with shape (OC, C/groups, K),
+// and adds the bias x[2] with shape (OC,) if it exists. The output has shape (N, OC, OL).
+func Conv1d[T tensor.Float](opts ...ConvOpts) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return func(x ...*variable.Variable[T]) *variable.Variable[T] {
+		return conv(1, x, opts...)
+	}
+}
+
+// Conv2d returns a function that applies a 2-D convolution to x[0] with shape (N, C, H, W) and the weight x[1] with shape (OC, C/groups, KH, KW),
+// and adds the bias x[2] with shape (OC,) if it exists. The output has shape (N, OC, OH, OW).
+func Conv2d[T tensor.Float](opts ...ConvOpts) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return func(x ...*variable.Variable[T]) *variable.Variable[T] {
+		return conv(2, x, opts...)
+	}
+}
+
+// Conv3d returns a function that applies a 3-D convolution to x[0] with shape (N, C, D, H, W) and the weight x[1] with shape (OC, C/groups, KD, KH, KW),
+// and adds the bias x[2] with shape (OC,) if it exists. The output has shape (N, OC, OD, OH, OW).
+func Conv3d[T tensor.Float](opts ...ConvOpts) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return func(x ...*variable.Variable[T]) *variable.Variable[T] {
+		return conv(3, x, opts...)
+	}
+}
+
+// ConvTranspose2d returns a function that applies a 2-D transposed convolution to x[0] with shape (N, C, H, W) and the weight x[1] with shape (C, OC/groups, KH, KW),
+// and adds the bias x[2] with shape (OC,) if it exists. It is the adjoint of Conv2d with respect to x[0], and the output has shape (N, OC, OH, OW).
+func ConvTranspose2d[T tensor.Float](opts ...ConvOpts) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return func(x ...*variable.Variable[T]) *variable.Variable[T] {
+		return convTranspose(2, x, opts...)
+	}
+}
+
+// conv applies the convolution over ndim spatial axes as a matrix product of the weight and the sliding windows of x[0].
+func conv[T tensor.Float](ndim int, x []*variable.Variable[T], opts ...ConvOpts) *variable.Variable[T] {
+	o := convOpts(ndim, opts...)
+	shape, wshape := x[0].Shape(), x[1].Shape()
+	check(ndim, shape, wshape, wshape[1], o.Groups)
+
+	n, c, oc, g := shape[0], shape[1], wshape[0], o.Groups
+	if oc%g != 0 {
+		panic(fmt.Sprintf("out channels %d is not divisible by %d groups", oc, g))
+	}
+
+	kernel := wshape[2:]
+	if o.Causal {
+		// pad both sides by the receptive field and drop the outputs that see the end padding
+		o.Padding = make([]int, ndim)
+		for i := range ndim {
+			o.Padding[i] = o.Dilation[i] * (kernel[i] - 1)
+		}
+	}
+
+	k := prod(kernel)
+	out := tensor.ConvSize(shape[2:], kernel, o.Stride, o.Padding, o.Dilation)
+
+	col := Im2col[T](kernel, o.Stride, o.Padding, o.Dilation)(x[0]) // (N, C*K, L)
+	col = Reshape[T](n, g, c/g*k, col.Size(2))(col)                 // (N, G, C/G*K, L)
+	w := Reshape[T](g, oc/g, c/g*k)(x[1])                           // (G, OC/G, C/G*K)
+	y := MatMul(w, col)                                             // (N, G, OC/G, L)
+	y = Reshape[T](append([]int{n, oc}, out...)...)(y)
+	if o.Causal {
+		ranges := []tensor.Range{tensor.All(), tensor.All()}
+		for i := range ndim {
+			ranges = append(ranges, tensor.R(0, (shape[2+i]-1)/o.Stride[i]+1))
+		}
+
+		y = Slice[T](ranges...)(y)
+	}
+
+	return bias(y, x)
+}
+
+// convTranspose applies the transposed convolution over ndim spatial axes as the adjoint of conv.
+func convTranspose[T tensor.Float](ndim int, x []*variable.Variable[T], opts ...ConvOpts) *variable.Variable[T] {
+	o := convOpts(ndim, opts...)
+	if o.Causal {
+		panic("causal padding is not supported by transposed convolutions")
+	}
+
+	shape, wshape := x[0].Shape(), x[1].Shape()
+	check(ndim, shape, wshape, wshape[0]/o.Groups, o.Groups)
+
+	n, c, g := shape[0], shape[1], o.Groups
+	oc, kernel := wshape[1]*g, wshape[2:]
+	k := prod(kernel)
+	l := prod(shape[2:])
+
+	out := make([]int, ndim)
+	for i := range out {
+		out[i] = (shape[2+i]-1)*o.Stride[i] - 2*o.Padding[i] + o.Dilation[i]*(kernel[i]-1) + o.OutputPadding[i] + 1
+		if o.OutputPadding[i] >= max(o.Stride[i], o.Dilation[i]) {
+			panic(fmt.Sprintf("output padding %v must be smaller than stride %v or dilation %v", o.OutputPadding, o.Stride, o.Dilation))
+		}
+	}
+
+	xs := Reshape[T](n, g, c/g, l)(x[0])                         // (N, G, C/G, L)
+	w := Transpose[T](0, 2, 1)(Reshape[T](g, c/g, oc/g*k)(x[1])) // (G, OC/G*K, C/G)
+	col := Reshape[T](n, oc*k, l)(MatMul(w, xs))                 // (N, OC*K, L)
+	y := Col2im[T](append([]int{n, oc}, out...), kernel, o.Stride, o.Padding, o.Dilation)(col)
+	return bias(y, x)
+}
+
+// bias adds x[2] to the channels of y if it exists.
+func bias[T tensor.Float](y *variable.Variable[T], x []*variable.Variable[T]) *variable.Variable[T] {
+	if len(x) < 3 {
+		return y
+	}
+
+	shape := make([]int, y.NumDims())
+	for i := range shape {
+		shape[i] = 1
+	}
+
+	shape[1] = x[2].Size()
+	return Add(y, Reshape[T](shape...)(x[2]))
+}
+
+// check panics if x with the shape and the weight with wshape do not match the convolution over ndim spatial axes,
+// where wc is the number of input channels of each group in the weight.
+func check(ndim int, shape, wshape []int, wc, groups int) {
+	if len(shape) != ndim+2 || len(wshape) != ndim+2 {
+		panic(fmt.Sprintf("shapes %v and %v do not have %d spatial axes", shape, wshape, ndim))
+	}
+
+	if shape[1] != wc*groups {
+		panic(fmt.Sprintf("in channels %d does not match %d of the weight with %d groups", shape[1], wc, groups))
+	}
+}
+
+// convOpts returns the options with the defaults and a value for every one of the ndim spatial axes.
+func convOpts(ndim int, opts ...ConvOpts) ConvOpts {
+	var o ConvOpts
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	if o.Groups == 0 {
+		o.Groups = 1
+	}
+
+	o.Stride = expand(o.Stride, ndim, 1)
+	o.Padding = expand(o.Padding, ndim, 0)
+	o.Dilation = expand(o.Dilation, ndim, 1)
+	o.OutputPadding = expand(o.OutputPadding, ndim, 0)
+	return o
+}
+
+// expand returns v with n values, which is v itself, n copies of its single value, or n copies of def if v is empty.
+func expand(v []int, n, def int) []int {
+	switch len(v) {
+	case n:
+		return v
+	case 0:
+		v = []int{def}
+	case 1:
+	default:
+		panic(fmt.Sprintf("len(%v) does not match %d spatial axes", v, n))
+	}
+
+	out := make([]int, n)
+	for i := range out {
+		out[i] = v[0]
+	}
+
+	return out
+}
+
+// prod returns the number of elements of a tensor with the shape.
+func prod(shape []int) int {
+	p := 1
+	for _, s := range shape {
+		p *= s
+	}
+
+	return p
+}
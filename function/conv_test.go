@@ -0,0 +1,488 @@
+package function_test
+
+import (
+	"fmt"
+	"testing"
+
+	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/grad"
+	"github.com/itsubaki/autograd/numerical"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleConv1d() {
+	x := variable.New(1, 2, 3, 4, 5).Reshape(1, 1, 5)
+	w := variable.New(1, -1).Reshape(1, 1, 2)
+
+	y := F.Conv1d[float64]()(x, w)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+	fmt.Println(w.Grad)
+
+	// Output:
+	// variable[1 1 4]([-1 -1 -1 -1])
+	// variable[1 1 5]([1 0 0 0 -1])
+	// variable[1 1 2]([10 14])
+}
+
+func ExampleConv1d_causal() {
+	x := variable.New(1, 2, 3, 4, 5).Reshape(1, 1, 5)
+	w := variable.New(1, 1, 1).Reshape(1, 1, 3)
+
+	// y[t] = x[t-2] + x[t-1] + x[t]
+	y := F.Conv1d[float64](F.ConvOpts{Causal: true})(x, w)
+	fmt.Println(y)
+
+	// Output:
+	// variable[1 1 5]([1 3 6 9 12])
+}
+
+func ExampleConv3d() {
+	x := variable.Ones[float64](1, 1, 3, 3, 3)
+	w := variable.Ones[float64](1, 1, 2, 2, 2)
+
+	y := F.Conv3d[float64](F.ConvOpts{Padding: []int{1, 0, 0}, Stride: []int{2, 1, 1}})(x, w)
+	fmt.Println(y)
+
+	// Output:
+	// variable[1 1 2 2 2]([4 4 4 4 8 8 8 8])
+}
+
+func ExampleConv2d() {
+	x := variable.New(
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 9,
+	).Reshape(1, 1, 3, 3)
+
+	w := variable.New(
+		1, 0,
+		0, -1,
+	).Reshape(1, 1, 2, 2)
+
+	b := variable.New(10)
+
+	y := F.Conv2d[float64]()(x, w, b)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+	fmt.Println(w.Grad)
+	fmt.Println(b.Grad)
+
+	// Output:
+	// variable[1 1 2 2]([6 6 6 6])
+	// variable[1 1 3 3]([1 1 0 1 0 -1 0 -1 -1])
+	// variable[1 1 2 2]([12 16 24 28])
+	// variable(4)
+}
+
+func ExampleConv2d_padding() {
+	x := variable.Ones[float64](1, 1, 3, 3)
+	w := variable.Ones[float64](1, 1, 3, 3)
+
+	y := F.Conv2d[float64](F.ConvOpts{Padding: []int{1}, Stride: []int{2}})(x, w)
+	fmt.Println(y)
+
+	// Output:
+	// variable[1 1 2 2]([4 4 4 4])
+}
+
+func ExampleConvTranspose2d() {
+	x := variable.New(
+		1, 2,
+		3, 4,
+	).Reshape(1, 1, 2, 2)
+
+	w := variable.Ones[float64](1, 1, 2, 2)
+
+	y := F.ConvTranspose2d[float64](F.ConvOpts{Stride: []int{2}})(x, w)
+	fmt.Println(y.Shape())
+	for _, row := range tensor.Reshape(y.Data, 4, 4).Seq2() {
+		fmt.Println(row)
+	}
+
+	// Output:
+	// [1 1 4 4]
+	// [1 1 2 2]
+	// [1 1 2 2]
+	// [3 3 4 4]
+	// [3 3 4 4]
+}
+
+func TestConv2d(t *testing.T) {
+	cases := []struct {
+		x, w []int
+		opts F.ConvOpts
+	}{
+		{[]int{2, 3, 5, 5}, []int{4, 3, 3, 3}, F.ConvOpts{}},
+		{[]int{1, 2, 6, 5}, []int{3, 2, 2, 3}, F.ConvOpts{Stride: []int{2, 1}, Padding: []int{1, 2}}},
+		{[]int{2, 2, 7, 7}, []int{2, 2, 3, 3}, F.ConvOpts{Dilation: []int{2}, Padding: []int{2}}},
+		{[]int{1, 4, 5, 6}, []int{6, 2, 3, 2}, F.ConvOpts{Groups: 2, Stride: []int{2}}},
+		{[]int{2, 3, 4, 4}, []int{3, 1, 3, 3}, F.ConvOpts{Groups: 3, Padding: []int{1}, Dilation: []int{1, 2}}},
+	}
+
+	for _, c := range cases {
+		x := variable.Randn(c.x, rand.Const(1))
+		w := variable.Randn(c.w, rand.Const(2))
+		b := variable.Randn(c.w[:1], rand.Const(3))
+
+		got := F.Conv2d[float64](c.opts)(x, w, b)
+		want := conv2d(x.Data, w.Data, b.Data, c.opts)
+		if !tensor.SliceEqual(got.Shape(), want.Shape) {
+			t.Fatalf("shape=%v, want=%v", got.Shape(), want.Shape)
+		}
+
+		if !tensor.IsCloseAll(got.Data, want, 1e-10, 1e-10) {
+			t.Errorf("got=%v, want=%v", got.Data.Data, want.Data)
+		}
+
+		// gradients
+		f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Conv2d[float64](c.opts)(x...)
+		}
+
+		gradcheck(t, f, x, w, b)
+	}
+}
+
+func TestConv1d(t *testing.T) {
+	cases := []struct {
+		x, w []int
+		opts F.ConvOpts
+	}{
+		{[]int{2, 3, 7}, []int{4, 3, 3}, F.ConvOpts{}},
+		{[]int{1, 2, 8}, []int{3, 2, 2}, F.ConvOpts{Stride: []int{2}, Padding: []int{1}}},
+		{[]int{2, 4, 9}, []int{2, 2, 3}, F.ConvOpts{Groups: 2, Dilation: []int{2}}},
+	}
+
+	for _, c := range cases {
+		x := variable.Randn(c.x, rand.Const(1))
+		w := variable.Randn(c.w, rand.Const(2))
+		b := variable.Randn(c.w[:1], rand.Const(3))
+
+		// Conv1d is Conv2d with the height of 1
+		got := F.Conv1d[float64](c.opts)(x, w, b)
+		x2 := tensor.Reshape(x.Data, c.x[0], c.x[1], 1, c.x[2])
+		w2 := tensor.Reshape(w.Data, c.w[0], c.w[1], 1, c.w[2])
+		want := conv2d(x2, w2, b.Data, F.ConvOpts{
+			Stride:   []int{1, pair(c.opts.Stride, 1)[0]},
+			Padding:  []int{0, pair(c.opts.Padding, 0)[0]},
+			Dilation: []int{1, pair(c.opts.Dilation, 1)[0]},
+			Groups:   c.opts.Groups,
+		})
+
+		if !tensor.IsCloseAll(got.Data, tensor.Reshape(want, got.Shape()...), 1e-10, 1e-10) {
+			t.Errorf("got=%v, want=%v", got.Data.Data, want.Data)
+		}
+
+		// gradients
+		f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Conv1d[float64](c.opts)(x...)
+		}
+
+		gradcheck(t, f, x, w, b)
+	}
+}
+
+func TestConv1d_causal(t *testing.T) {
+	cases := []struct {
+		kernel, stride, dilation int
+	}{
+		{1, 1, 1},
+		{3, 1, 1},
+		{2, 1, 3},
+		{3, 2, 1},
+		{3, 3, 2},
+	}
+
+	for _, c := range cases {
+		opts := F.ConvOpts{
+			Stride:   []int{c.stride},
+			Dilation: []int{c.dilation},
+			Causal:   true,
+		}
+
+		x := variable.Randn([]int{2, 2, 9}, rand.Const(1))
+		w := variable.Randn([]int{3, 2, c.kernel}, rand.Const(2))
+		b := variable.Randn([]int{3}, rand.Const(3))
+
+		y := F.Conv1d[float64](opts)(x, w, b)
+		if y.Size(2) != (9-1)/c.stride+1 {
+			t.Fatalf("shape=%v", y.Shape())
+		}
+
+		// equals the convolution of x padded only at the start
+		p := c.dilation * (c.kernel - 1)
+		xp := F.Concat[float64](2)(variable.Zeros[float64](2, 2, p), x)
+		want := F.Conv1d[float64](F.ConvOpts{Stride: opts.Stride, Dilation: opts.Dilation})(xp, w, b)
+		if !tensor.IsCloseAll(y.Data, want.Data, 1e-10, 1e-10) {
+			t.Errorf("got=%v, want=%v", y.Data.Data, want.Data.Data)
+		}
+
+		// the outputs do not depend on the future inputs
+		for s := range y.Size(2) {
+			ys := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+				return F.Slice[float64](tensor.All(), tensor.All(), tensor.Index(s))(F.Conv1d[float64](opts)(x[0], w, b))
+			}
+
+			gx := grad.Grad(ys)(x)[0]
+			for i := range 2 {
+				for j := range 2 {
+					for k := s*c.stride + 1; k < 9; k++ {
+						if gx.Data.At(i, j, k) != 0 {
+							t.Errorf("y[%d] depends on x[%d]: %v", s, k, gx.Data.Data)
+						}
+					}
+				}
+			}
+		}
+
+		// gradients
+		f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Conv1d[float64](opts)(x...)
+		}
+
+		gradcheck(t, f, x, w, b)
+	}
+}
+
+func TestConv3d(t *testing.T) {
+	cases := []struct {
+		x, w                      []int
+		stride, padding, dilation []int
+		groups                    int
+	}{
+		{[]int{2, 2, 3, 4, 4}, []int{3, 2, 2, 3, 3}, []int{1, 1, 1}, []int{0, 0, 0}, []int{1, 1, 1}, 1},
+		{[]int{1, 2, 4, 5, 4}, []int{2, 2, 3, 2, 2}, []int{1, 2, 1}, []int{0, 1, 1}, []int{1, 1, 1}, 1},
+		{[]int{1, 4, 5, 5, 5}, []int{4, 2, 2, 2, 2}, []int{1, 1, 1}, []int{0, 0, 0}, []int{2, 2, 1}, 2},
+	}
+
+	for _, c := range cases {
+		opts := F.ConvOpts{
+			Stride:   c.stride,
+			Padding:  c.padding,
+			Dilation: c.dilation,
+			Groups:   c.groups,
+		}
+
+		x := variable.Randn(c.x, rand.Const(1))
+		w := variable.Randn(c.w, rand.Const(2))
+		b := variable.Randn(c.w[:1], rand.Const(3))
+
+		// each depth of the output is the sum of the 2-D convolutions over the depth of the kernel
+		got := F.Conv3d[float64](opts)(x, w, b)
+		opts2d := F.ConvOpts{
+			Stride:   c.stride[1:],
+			Padding:  c.padding[1:],
+			Dilation: c.dilation[1:],
+			Groups:   c.groups,
+		}
+
+		for d := range got.Size(2) {
+			want := tensor.Zeros[float64](got.Size(0), got.Size(1), got.Size(3), got.Size(4))
+			for u := range c.w[2] {
+				xd := tensor.Slice(x.Data, tensor.All(), tensor.All(), tensor.Index(d+u*c.dilation[0]))
+				wd := tensor.Slice(w.Data, tensor.All(), tensor.All(), tensor.Index(u))
+				want = tensor.Add(want, conv2d(xd, wd, tensor.ZeroLike(b.Data), opts2d))
+			}
+
+			want = tensor.Add(want, tensor.Reshape(b.Data, 1, c.w[0], 1, 1))
+			if !tensor.IsCloseAll(tensor.Slice(got.Data, tensor.All(), tensor.All(), tensor.Index(d)), want, 1e-10, 1e-10) {
+				t.Errorf("got[:, :, %d] does not match %v", d, want.Data)
+			}
+		}
+
+		// gradients
+		f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Conv3d[float64](opts)(x...)
+		}
+
+		gradcheck(t, f, x, w, b)
+	}
+}
+
+func TestConv_double(t *testing.T) {
+	cases := []struct {
+		conv func(opts ...F.ConvOpts) func(x ...*variable.Variable[float64]) *variable.Variable[float64]
+		opts F.ConvOpts
+		x, w []int
+	}{
+		{F.Conv1d[float64], F.ConvOpts{Causal: true, Dilation: []int{2}}, []int{1, 2, 6}, []int{2, 2, 3}},
+		{F.Conv2d[float64], F.ConvOpts{Stride: []int{2}, Padding: []int{1}}, []int{1, 2, 4, 4}, []int{2, 2, 3, 3}},
+		{F.Conv3d[float64], F.ConvOpts{Groups: 2}, []int{1, 2, 3, 3, 3}, []int{2, 1, 2, 2, 2}},
+		{F.ConvTranspose2d[float64], F.ConvOpts{Stride: []int{2}}, []int{1, 2, 2, 2}, []int{2, 1, 2, 2}},
+	}
+
+	for _, c := range cases {
+		x := variable.Randn(c.x, rand.Const(1))
+		w := variable.Randn(c.w, rand.Const(2))
+
+		// the gradient of the squared norm of the gradient with respect to x
+		f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Sum[float64]()(F.Tanh(c.conv(c.opts)(x...)))
+		}
+
+		g := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			gx := grad.Grad(f)(x...)[0]
+			return F.Sum[float64]()(F.Square(gx))
+		}
+
+		gradcheck(t, g, x, w)
+	}
+}
+
+func TestConvTranspose2d(t *testing.T) {
+	cases := []struct {
+		x, w []int
+		opts F.ConvOpts
+		want []int
+	}{
+		{[]int{2, 3, 4, 4}, []int{3, 2, 3, 3}, F.ConvOpts{}, []int{2, 2, 6, 6}},
+		{[]int{1, 2, 3, 4}, []int{2, 3, 2, 3}, F.ConvOpts{Stride: []int{2, 1}, Padding: []int{1, 1}}, []int{1, 3, 4, 4}},
+		{[]int{1, 2, 3, 3}, []int{2, 2, 3, 3}, F.ConvOpts{Stride: []int{2}, Padding: []int{1}, OutputPadding: []int{1}}, []int{1, 2, 6, 6}},
+		{[]int{2, 4, 3, 3}, []int{4, 1, 2, 2}, F.ConvOpts{Groups: 2, Dilation: []int{2}}, []int{2, 2, 5, 5}},
+	}
+
+	for _, c := range cases {
+		x := variable.Randn(c.x, rand.Const(1))
+		w := variable.Randn(c.w, rand.Const(2))
+
+		y := F.ConvTranspose2d[float64](c.opts)(x, w)
+		if !tensor.SliceEqual(y.Shape(), c.want) {
+			t.Fatalf("shape=%v, want=%v", y.Shape(), c.want)
+		}
+
+		// adjoint: <conv(z, w), x> = <z, convT(x, w)>
+		z := variable.Randn(c.want, rand.Const(3))
+		zw := F.Conv2d[float64](c.opts)(z, w)
+		if !tensor.SliceEqual(zw.Shape(), c.x) {
+			t.Fatalf("shape=%v, want=%v", zw.Shape(), c.x)
+		}
+
+		lhs := tensor.Sum(tensor.Mul(zw.Data, x.Data)).At()
+		rhs := tensor.Sum(tensor.Mul(z.Data, y.Data)).At()
+		if !tensor.IsCloseAll(tensor.Scalar(lhs), tensor.Scalar(rhs), 1e-10, 1e-10) {
+			t.Errorf("<conv(z, w), x>=%v, <z, convT(x, w)>=%v", lhs, rhs)
+		}
+
+		// gradients
+		b := variable.Randn(c.want[1:2], rand.Const(4))
+		f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.ConvTranspose2d[float64](c.opts)(x...)
+		}
+
+		gradcheck(t, f, x, w, b)
+	}
+}
+
+func TestConv2d_panic(t *testing.T) {
+	cases := []struct {
+		name string
+		f    func()
+		want string
+	}{
+		{"ndim", func() {
+			F.Conv2d[float64]()(variable.Zeros[float64](1, 1, 3), variable.Zeros[float64](1, 1, 3))
+		}, "shapes [1 1 3] and [1 1 3] do not have 2 spatial axes"},
+		{"channels", func() {
+			F.Conv2d[float64]()(variable.Zeros[float64](1, 3, 3, 3), variable.Zeros[float64](1, 2, 1, 1))
+		}, "in channels 3 does not match 2 of the weight with 1 groups"},
+		{"groups", func() {
+			F.Conv2d[float64](F.ConvOpts{Groups: 2})(variable.Zeros[float64](1, 4, 3, 3), variable.Zeros[float64](3, 2, 1, 1))
+		}, "out channels 3 is not divisible by 2 groups"},
+		{"opts", func() {
+			F.Conv2d[float64](F.ConvOpts{Stride: []int{1, 1, 1}})(variable.Zeros[float64](1, 1, 3, 3), variable.Zeros[float64](1, 1, 1, 1))
+		}, "len([1 1 1]) does not match 2 spatial axes"},
+		{"causal", func() {
+			F.ConvTranspose2d[float64](F.ConvOpts{Causal: true})(variable.Zeros[float64](1, 1, 3, 3), variable.Zeros[float64](1, 1, 1, 1))
+		}, "causal padding is not supported by transposed convolutions"},
+		{"output padding", func() {
+			F.ConvTranspose2d[float64](F.ConvOpts{OutputPadding: []int{1}})(variable.Zeros[float64](1, 1, 3, 3), variable.Zeros[float64](1, 1, 1, 1))
+		}, "output padding [1 1] must be smaller than stride [1 1] or dilation [1 1]"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != c.want {
+					t.Errorf("got=%v, want=%v", r, c.want)
+				}
+			}()
+
+			c.f()
+			t.Fail()
+		})
+	}
+}
+
+// gradcheck compares the gradients of the sum of f with numerical.Grad.
+func gradcheck(t *testing.T, f func(x ...*variable.Variable[float64]) *variable.Variable[float64], x ...*variable.Variable[float64]) {
+	t.Helper()
+
+	for _, v := range x {
+		v.Cleargrad()
+	}
+
+	F.Sum[float64]()(f(x...)).Backward()
+	want := numerical.Grad(numerical.Func[float64](f), x)
+	for i := range x {
+		if !tensor.IsCloseAll(x[i].Grad.Data, want[i].Data, 1e-6, 1e-6) {
+			t.Errorf("x[%d].Grad=%v, want=%v", i, x[i].Grad.Data.Data, want[i].Data.Data)
+		}
+	}
+}
+
+// conv2d is a reference implementation of Conv2d with loops.
+func conv2d(x, w, b *tensor.Tensor[float64], opts F.ConvOpts) *tensor.Tensor[float64] {
+	g := max(opts.Groups, 1)
+	s, p, d := pair(opts.Stride, 1), pair(opts.Padding, 0), pair(opts.Dilation, 1)
+
+	n, c, h, wd := x.Shape[0], x.Shape[1], x.Shape[2], x.Shape[3]
+	oc, kh, kw := w.Shape[0], w.Shape[2], w.Shape[3]
+	oh := (h+2*p[0]-d[0]*(kh-1)-1)/s[0] + 1
+	ow := (wd+2*p[1]-d[1]*(kw-1)-1)/s[1] + 1
+
+	y := tensor.Zeros[float64](n, oc, oh, ow)
+	for bi := range n {
+		for o := range oc {
+			group := o / (oc / g)
+			for i := range oh {
+				for j := range ow {
+					sum := b.At(o)
+					for ci := range c / g {
+						for u := range kh {
+							for v := range kw {
+								r, q := i*s[0]-p[0]+u*d[0], j*s[1]-p[1]+v*d[1]
+								if r < 0 || r >= h || q < 0 || q >= wd {
+									continue
+								}
+
+								sum += x.At(bi, group*(c/g)+ci, r, q) * w.At(o, ci, u, v)
+							}
+						}
+					}
+
+					y.Set([]int{bi, o, i, j}, sum)
+				}
+			}
+		}
+	}
+
+	return y
+}
+
+func pair(v []int, def int) []int {
+	switch len(v) {
+	case 0:
+		return []int{def, def}
+	case 1:
+		return []int{v[0], v[0]}
+	default:
+		return v
+	}
+}
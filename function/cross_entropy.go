@@ -1,35 +1,37 @@
 package function
 
 import (
+	"math"
+
 	"github.com/itsubaki/autograd/tensor"
 	"github.com/itsubaki/autograd/variable"
 )
 
 // CrossEntropy computes the softmax cross-entropy loss.
 // It expects x[0] to have shape (N, C) and x[1] (t) to have shape (N,).
-func CrossEntropy(x ...*variable.Variable) *variable.Variable {
-	return (&variable.Function{
-		Forwarder: &CrossEntropyT{
+func CrossEntropy[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return (&variable.Function[T]{
+		Forwarder: &CrossEntropyT[T]{
 			ignoreIndex: -100,
 		},
 	}).First(x...)
 }
 
 // CrossEntropyT is the differentiable softmax cross-entropy operation.
-type CrossEntropyT struct {
+type CrossEntropyT[T tensor.Float] struct {
 	N, C        int
-	x           *variable.Variable
+	x           *variable.Variable[T]
 	ignoreIndex int
 	label       []int
 }
 
-func (f *CrossEntropyT) Forward(x ...*variable.Variable) []*variable.Variable {
+func (f *CrossEntropyT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
 	f.x, f.C = x[0], x[0].Shape()[1]     // (N, C)
 	f.label = tensor.Int(x[1].Data).Data // (N,)
 	f.N = count(f.label, f.ignoreIndex)
 	if f.N == 0 {
-		return []*variable.Variable{
-			variable.New(0.0),
+		return []*variable.Variable[T]{
+			variable.Zeros[T](1),
 		}
 	}
 
@@ -37,32 +39,102 @@ func (f *CrossEntropyT) Forward(x ...*variable.Variable) []*variable.Variable {
 	logp := logp(tensor.Sub(x[0].Data, logz), f.label, f.ignoreIndex) // (N, 1)
 	sum := tensor.Sum(logp).At()                                      // scalar
 
-	return []*variable.Variable{
-		variable.New(-1.0 / float64(f.N) * sum),
+	return []*variable.Variable[T]{
+		variable.From(tensor.Full([]int{1}, -1.0/T(f.N)*sum)),
 	}
 }
 
-func (f *CrossEntropyT) Backward(gy ...*variable.Variable) []*variable.Variable {
+func (f *CrossEntropyT[T]) Backward(gy ...*variable.Variable[T]) []*variable.Variable[T] {
 	if f.N == 0 {
-		return []*variable.Variable{
-			variable.Zeros(f.x.Shape()...),
+		return []*variable.Variable[T]{
+			variable.Zeros[T](f.x.Shape()...),
 		}
 	}
 
-	t := variable.From(oneHot(f.label, f.C, f.ignoreIndex)) // (N, C)
-	y := Softmax(1)(f.x)                                    // (N, C)
-	mask := ignoreMask(f.label, f.C, f.ignoreIndex)         // (N, C)
-	diff := Mul(Sub(y, t), mask)                            // (y-t) * mask
-	yt := MulC(1.0/float64(f.N), diff)                      // (y-t) * mask/N
-	gx := Mul(yt, gy[0])                                    // (y-t) * mask/N * gy
-	return []*variable.Variable{
-		Reshape(f.x.Shape()...)(gx),
+	t := variable.From(oneHot[T](f.label, f.C, f.ignoreIndex)) // (N, C)
+	y := Softmax[T](1)(f.x)                                    // (N, C)
+	mask := ignoreMask[T](f.label, f.C, f.ignoreIndex)         // (N, C)
+	diff := Mul(Sub(y, t), mask)                               // (y-t) * mask
+	yt := MulC(1.0/T(f.N), diff)                               // (y-t) * mask/N
+	gx := Mul(yt, gy[0])                                       // (y-t) * mask/N * gy
+	return []*variable.Variable[T]{
+		Reshape[T](f.x.Shape()...)(gx),
+	}
+}
+
+func (f *CrossEntropyT[T]) JVP(x, y, tx []*variable.Variable[T]) []*variable.Variable[T] {
+	if f.N == 0 {
+		return []*variable.Variable[T]{
+			variable.ZeroLike(y[0]),
+		}
+	}
+
+	t := variable.From(oneHot[T](f.label, f.C, f.ignoreIndex)) // (N, C)
+	p := Softmax[T](1)(x[0])                                   // (N, C)
+	mask := ignoreMask[T](f.label, f.C, f.ignoreIndex)         // (N, C)
+	diff := Mul(Sub(p, t), mask)                               // (p-t) * mask
+	ty := MulC(1.0/T(f.N), Sum[T]()(Mul(diff, tx[0])))         // sum((p-t) * mask * tx) / N
+	return []*variable.Variable[T]{
+		Reshape[T](y[0].Shape()...)(ty),
+	}
+}
+
+// ForwardInto writes the softmax cross-entropy loss of x[0] and the labels x[1] into y[0].
+// It computes the log-sum-exp of each row in one pass.
+func (f *CrossEntropyT[T]) ForwardInto(y []*tensor.Tensor[T], x ...*variable.Variable[T]) bool {
+	f.x, f.C = x[0], x[0].Shape()[1]
+	f.label = tensor.Int(x[1].Data).Data
+	f.N = count(f.label, f.ignoreIndex)
+
+	var sum T
+	data := tensor.Contiguous(x[0].Data).Data
+	for i, v := range f.label {
+		if v == f.ignoreIndex {
+			continue
+		}
+
+		row := data[i*f.C : (i+1)*f.C]
+		sum += row[v] - rowLogsumexp(row) // log(p[v])
+	}
+
+	y[0].Data[0] = 0
+	if f.N > 0 {
+		y[0].Data[0] = -1.0 / T(f.N) * sum
+	}
+
+	return true
+}
+
+// BackwardInto adds the gradients of x[0] to gx.
+// It fuses the softmax, the subtraction of the one-hot labels, the mask and the scaling of Backward into one pass.
+func (f *CrossEntropyT[T]) BackwardInto(gx []*tensor.Tensor[T], gy ...*tensor.Tensor[T]) {
+	if gx[0] == nil || f.N == 0 {
+		return
+	}
+
+	c := gy[0].Data[0] / T(f.N)
+	data := tensor.Contiguous(f.x.Data).Data
+	for i, v := range f.label {
+		if v == f.ignoreIndex {
+			continue
+		}
+
+		row := data[i*f.C : (i+1)*f.C]
+		logz := rowLogsumexp(row)
+		for j, a := range row {
+			g := T(math.Exp(float64(a - logz))) // softmax
+			if j == v {
+				g -= 1
+			}
+
+			gx[0].Data[i*f.C+j] += c * g // (y-t) * gy/N
+		}
 	}
 }
 
 // oneHot converts a slice of integer labels into a one-hot encoded tensor.
-func oneHot(label []int, CNums int, ignoreIndex int) *tensor.Tensor[float64] {
-	out := tensor.Zeros[float64](len(label), CNums)
+func oneHot[T tensor.Float](label []int, CNums int, ignoreIndex int) *tensor.Tensor[T] {
+	out := tensor.Zeros[T](len(label), CNums)
 	for i, v := range label {
 		if v == ignoreIndex {
 			continue
@@ -75,7 +147,7 @@ func oneHot(label []int, CNums int, ignoreIndex int) *tensor.Tensor[float64] {
 }
 
 // logsumexp computes log(sum(exp(x))) for the input tensor x.
-func logsumexp(x *tensor.Tensor[float64]) *tensor.Tensor[float64] {
+func logsumexp[T tensor.Float](x *tensor.Tensor[T]) *tensor.Tensor[T] {
 	// log(sum(exp(x))) = m + log(sum(exp(x - max)))
 	max1 := tensor.Unsqueeze(tensor.Max(x, 1), 1)    // max1 = max(x, axis=1)
 	expy := tensor.Exp(tensor.Sub(x, max1))          // expy = exp(x - max1)
@@ -84,9 +156,24 @@ func logsumexp(x *tensor.Tensor[float64]) *tensor.Tensor[float64] {
 	return tensor.Add(max1, logsum1)                 // logsumexp = max1 + logsum1
 }
 
+// rowLogsumexp computes log(sum(exp(x))) for a row x.
+func rowLogsumexp[T tensor.Float](x []T) T {
+	m := x[0]
+	for _, a := range x[1:] {
+		m = max(m, a)
+	}
+
+	var sum T
+	for _, a := range x {
+		sum += T(math.Exp(float64(a - m)))
+	}
+
+	return m + T(math.Log(float64(sum)))
+}
+
 // logp extracts the values from x corresponding to the true labels.
-func logp(x *tensor.Tensor[float64], label []int, ignoreIndex int) *tensor.Tensor[float64] {
-	out := tensor.Zeros[float64](len(label), 1)
+func logp[T tensor.Float](x *tensor.Tensor[T], label []int, ignoreIndex int) *tensor.Tensor[T] {
+	out := tensor.Zeros[T](len(label), 1)
 	for i, v := range label {
 		if v == ignoreIndex {
 			continue
@@ -111,8 +198,8 @@ func count(label []int, ignoreIndex int) int {
 	return n
 }
 
-func ignoreMask(label []int, C, ignoreIndex int) *variable.Variable {
-	mask := tensor.Ones[float64](len(label), C)
+func ignoreMask[T tensor.Float](label []int, C, ignoreIndex int) *variable.Variable[T] {
+	mask := tensor.Ones[T](len(label), C)
 	for i, v := range label {
 		if v != ignoreIndex {
 			continue
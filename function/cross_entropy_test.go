@@ -87,7 +87,7 @@ func ExampleCrossEntropy_double() {
 	gx := x.Grad
 	x.Cleargrad()
 	gx.Backward()
-	fmt.Println(F.Clip(0, 1)(x.Grad)) // NOTE: zeros..., why?
+	fmt.Println(F.Clip(0.0, 1.0)(x.Grad)) // NOTE: zeros..., why?
 
 	// Output:
 	// variable(2.069494302297095)
@@ -1,7 +1,7 @@
 package function
 
 var (
-	OneHot = oneHot
-	Logp   = logp
-	Relu   = relu
+	OneHot = oneHot[float64]
+	Logp   = logp[float64]
+	Relu   = relu[float64]
 )
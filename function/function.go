@@ -1,39 +1,236 @@
 package function
 
-import "github.com/itsubaki/autograd/variable"
-
-var (
-	AddC            = variable.AddC
-	Add             = variable.Add
-	SubC            = variable.SubC
-	Sub             = variable.Sub
-	MulC            = variable.MulC
-	Mul             = variable.Mul
-	DivC            = variable.DivC
-	Div             = variable.Div
-	Sin             = variable.Sin
-	Cos             = variable.Cos
-	Tanh            = variable.Tanh
-	Exp             = variable.Exp
-	Log             = variable.Log
-	Pow             = variable.Pow
-	Square          = variable.Square
-	Neg             = variable.Neg
-	Sum             = variable.Sum
-	SumTo           = variable.SumTo
-	BroadcastTo     = variable.BroadcastTo
-	Reshape         = variable.Reshape
-	Transpose       = variable.Transpose
-	TransposeMatMul = variable.TransposeMatMul
-	MatMul          = variable.MatMul
-	Max             = variable.Max
-	Min             = variable.Min
-	Clip            = variable.Clip
-	GetItem         = variable.GetItem
-	Concat          = variable.Concat
-	Split           = variable.Split
-	Squeeze         = variable.Squeeze
-	Unsqueeze       = variable.Unsqueeze
-	Mean            = variable.Mean
-	Variance        = variable.Variance
+import (
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
 )
+
+// AddC calls variable.AddC.
+func AddC[T tensor.Float](c T, x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.AddC[T](c, x...)
+}
+
+// Add calls variable.Add.
+func Add[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Add[T](x...)
+}
+
+// SubC calls variable.SubC.
+func SubC[T tensor.Float](c T, x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.SubC[T](c, x...)
+}
+
+// Sub calls variable.Sub.
+func Sub[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Sub[T](x...)
+}
+
+// MulC calls variable.MulC.
+func MulC[T tensor.Float](c T, x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.MulC[T](c, x...)
+}
+
+// Mul calls variable.Mul.
+func Mul[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Mul[T](x...)
+}
+
+// DivC calls variable.DivC.
+func DivC[T tensor.Float](c T, x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.DivC[T](c, x...)
+}
+
+// Div calls variable.Div.
+func Div[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Div[T](x...)
+}
+
+// Sin calls variable.Sin.
+func Sin[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Sin[T](x...)
+}
+
+// Cos calls variable.Cos.
+func Cos[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Cos[T](x...)
+}
+
+// Tanh calls variable.Tanh.
+func Tanh[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Tanh[T](x...)
+}
+
+// Exp calls variable.Exp.
+func Exp[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Exp[T](x...)
+}
+
+// Log calls variable.Log.
+func Log[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Log[T](x...)
+}
+
+// Pow calls variable.Pow.
+func Pow[T tensor.Float](p T) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Pow[T](p)
+}
+
+// Square calls variable.Square.
+func Square[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Square[T](x...)
+}
+
+// Neg calls variable.Neg.
+func Neg[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Neg[T](x...)
+}
+
+// Sum calls variable.Sum.
+func Sum[T tensor.Float](axes ...int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Sum[T](axes...)
+}
+
+// SumTo calls variable.SumTo.
+func SumTo[T tensor.Float](shape ...int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.SumTo[T](shape...)
+}
+
+// BroadcastTo calls variable.BroadcastTo.
+func BroadcastTo[T tensor.Float](shape ...int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.BroadcastTo[T](shape...)
+}
+
+// Reshape calls variable.Reshape.
+func Reshape[T tensor.Float](shape ...int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Reshape[T](shape...)
+}
+
+// Transpose calls variable.Transpose.
+func Transpose[T tensor.Float](axes ...int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Transpose[T](axes...)
+}
+
+// TransposeMatMul calls variable.TransposeMatMul.
+func TransposeMatMul[T tensor.Float](ndim int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.TransposeMatMul[T](ndim)
+}
+
+// MatMul calls variable.MatMul.
+func MatMul[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.MatMul[T](x...)
+}
+
+// Einsum calls variable.Einsum.
+func Einsum[T tensor.Float](subscripts string) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Einsum[T](subscripts)
+}
+
+// Max calls variable.Max.
+func Max[T tensor.Float](axes ...int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Max[T](axes...)
+}
+
+// Min calls variable.Min.
+func Min[T tensor.Float](axes ...int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Min[T](axes...)
+}
+
+// Clip calls variable.Clip.
+func Clip[T tensor.Float](min, max T) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Clip[T](min, max)
+}
+
+// GetItem calls variable.GetItem.
+func GetItem[T tensor.Float](axis int, indices []int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.GetItem[T](axis, indices)
+}
+
+// Slice calls variable.Slice.
+func Slice[T tensor.Float](ranges ...tensor.Range) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Slice[T](ranges...)
+}
+
+// Gather calls variable.Gather.
+func Gather[T tensor.Float](axis int, index *tensor.Tensor[int]) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Gather[T](axis, index)
+}
+
+// ScatterAdd calls variable.ScatterAdd.
+func ScatterAdd[T tensor.Float](axis int, index *tensor.Tensor[int]) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.ScatterAdd[T](axis, index)
+}
+
+// Concat calls variable.Concat.
+func Concat[T tensor.Float](axis int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Concat[T](axis)
+}
+
+// Split calls variable.Split.
+func Split[T tensor.Float](size []int, axis int) func(x ...*variable.Variable[T]) []*variable.Variable[T] {
+	return variable.Split[T](size, axis)
+}
+
+// Squeeze calls variable.Squeeze.
+func Squeeze[T tensor.Float](axis int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Squeeze[T](axis)
+}
+
+// Unsqueeze calls variable.Unsqueeze.
+func Unsqueeze[T tensor.Float](axis int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Unsqueeze[T](axis)
+}
+
+// Mean calls variable.Mean.
+func Mean[T tensor.Float](axes ...int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Mean[T](axes...)
+}
+
+// Variance calls variable.Variance.
+func Variance[T tensor.Float](axes ...int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Variance[T](axes...)
+}
+
+// Inv calls variable.Inv.
+func Inv[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Inv[T](x...)
+}
+
+// Det calls variable.Det.
+func Det[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Det[T](x...)
+}
+
+// LogDet calls variable.LogDet.
+func LogDet[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.LogDet[T](x...)
+}
+
+// Solve calls variable.Solve.
+func Solve[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Solve[T](x...)
+}
+
+// Cholesky calls variable.Cholesky.
+func Cholesky[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return variable.Cholesky[T](x...)
+}
+
+// QR calls variable.QR.
+func QR[T tensor.Float](x ...*variable.Variable[T]) (q, r *variable.Variable[T]) {
+	return variable.QR[T](x...)
+}
+
+// Eigh calls variable.Eigh.
+func Eigh[T tensor.Float](x ...*variable.Variable[T]) (w, v *variable.Variable[T]) {
+	return variable.Eigh[T](x...)
+}
+
+// SVD calls variable.SVD.
+func SVD[T tensor.Float](x ...*variable.Variable[T]) (u, s, vt *variable.Variable[T]) {
+	return variable.SVD[T](x...)
+}
+
+// LU calls variable.LU.
+func LU[T tensor.Float](x ...*variable.Variable[T]) (p, l, u *variable.Variable[T]) {
+	return variable.LU[T](x...)
+}
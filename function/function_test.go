@@ -1 +1,93 @@
 package function_test
+
+import (
+	"testing"
+
+	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/numerical"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func TestJVP(t *testing.T) {
+	x := variable.Randn([]int{3, 4}, rand.Const(1))
+	w := variable.Randn([]int{4, 2}, rand.Const(2))
+	b := variable.Randn([]int{1, 2}, rand.Const(3))
+	mask := tensor.New([]int{3, 4}, []float64{1, 1, 0, 1, 0, 1, 1, 1, 1, 0, 1, 1})
+	label := variable.New(0, 3, 1)
+	randn := func(shape ...int) *variable.Variable[float64] {
+		return variable.Randn(shape, rand.Const(5))
+	}
+
+	cases := []struct {
+		f func(x ...*variable.Variable[float64]) *variable.Variable[float64]
+		x []*variable.Variable[float64]
+	}{
+		{F.Sigmoid[float64], []*variable.Variable[float64]{x}},
+		{F.ReLU[float64], []*variable.Variable[float64]{x}},
+		{F.GELU[float64], []*variable.Variable[float64]{x}},
+		{F.Softmax[float64](1), []*variable.Variable[float64]{x}},
+		{F.Softmax[float64](0), []*variable.Variable[float64]{x}},
+		{F.Linear[float64], []*variable.Variable[float64]{x, w}},
+		{F.Linear[float64], []*variable.Variable[float64]{x, w, b}},
+		{F.MeanSquaredError[float64], []*variable.Variable[float64]{x, variable.Randn([]int{3, 4}, rand.Const(4))}},
+		{F.MaskFill(mask, func(m float64) bool { return m == 0 }, -1e9), []*variable.Variable[float64]{x}},
+		{func(x ...*variable.Variable[float64]) *variable.Variable[float64] { return F.CrossEntropy(x[0], label) }, []*variable.Variable[float64]{x}},
+		{F.Im2col[float64]([]int{2}, []int{1}, []int{1}, []int{2}), []*variable.Variable[float64]{randn(1, 3, 4)}},
+		{F.Col2im[float64]([]int{1, 1, 3, 4}, []int{2, 2}, []int{1, 1}, []int{0, 0}, []int{1, 1}), []*variable.Variable[float64]{randn(1, 4, 6)}},
+		{F.Conv1d[float64](F.ConvOpts{Causal: true, Dilation: []int{2}}), []*variable.Variable[float64]{randn(1, 2, 5), randn(2, 2, 2), randn(2)}},
+		{F.Conv2d[float64](F.ConvOpts{Padding: []int{1}}), []*variable.Variable[float64]{randn(1, 1, 3, 4), randn(2, 1, 2, 2), randn(2)}},
+		{F.Conv3d[float64](F.ConvOpts{Stride: []int{1, 2, 1}}), []*variable.Variable[float64]{randn(1, 1, 3, 3, 4), randn(2, 1, 2, 2, 2)}},
+		{F.ConvTranspose2d[float64](F.ConvOpts{Stride: []int{2}}), []*variable.Variable[float64]{randn(1, 2, 3, 2), randn(2, 2, 1, 2)}},
+		{F.MaxPool2d[float64]([]int{2}, F.PoolOpts{Stride: []int{1}, Padding: []int{1}}), []*variable.Variable[float64]{randn(1, 2, 3, 3)}},
+		{F.AvgPool2d[float64]([]int{2, 3}, F.PoolOpts{Padding: []int{1}}), []*variable.Variable[float64]{randn(2, 1, 3, 4)}},
+		{F.AdaptiveAvgPool2d[float64]([]int{2, 3}), []*variable.Variable[float64]{randn(1, 2, 3, 4)}},
+		{F.GlobalAvgPool[float64], []*variable.Variable[float64]{randn(2, 3, 2, 2)}},
+		{F.BatchNorm(tensor.Zeros[float64](2), tensor.Ones[float64](2), 0.1, 1e-5), []*variable.Variable[float64]{randn(3, 2, 2), randn(2), randn(2)}},
+		{F.LayerNorm([]int{1, 2}, 1e-5), []*variable.Variable[float64]{randn(2, 2, 3), randn(2, 3), randn(3)}},
+		{F.RMSNorm(nil, 1e-5), []*variable.Variable[float64]{randn(2, 3), randn(3)}},
+	}
+
+	for _, c := range cases {
+		jvpcheck(t, c.f, c.x...)
+	}
+}
+
+// jvpcheck compares the tangent of f with numerical.Diff, which perturbs every element by the same step,
+// and with the reverse mode through <u, J * v> = <J^T * u, v> for random tangents v and weights u.
+func jvpcheck(t *testing.T, f func(x ...*variable.Variable[float64]) *variable.Variable[float64], x ...*variable.Variable[float64]) {
+	t.Helper()
+
+	// numerical.Diff
+	ones := make([]*variable.Variable[float64], len(x))
+	for i := range x {
+		ones[i] = variable.Dual(x[i], variable.OneLike(x[i]))
+	}
+
+	got, want := f(ones...).Tangent(), numerical.Diff(numerical.Func[float64](f), x)
+	if !tensor.IsCloseAll(got.Data, want.Data, 1e-6, 1e-5) {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+
+	// reverse mode
+	v, duals := make([]*variable.Variable[float64], len(x)), make([]*variable.Variable[float64], len(x))
+	for i := range x {
+		v[i] = variable.Randn(x[i].Shape(), rand.Const(uint64(10+i)))
+		duals[i] = variable.Dual(x[i], v[i])
+	}
+
+	y := f(duals...)
+	u := variable.Randn(y.Shape(), rand.Const(20))
+	variable.Sum[float64]()(variable.Mul(u, y)).Backward()
+
+	uJv := tensor.Sum(tensor.Mul(u.Data, y.Tangent().Data)).At()
+	var JTuv float64
+	for i := range x {
+		JTuv += tensor.Sum(tensor.Mul(duals[i].Grad.Data, v[i].Data)).At()
+	}
+
+	if !tensor.IsCloseAll(tensor.Scalar(uJv), tensor.Scalar(JTuv), 1e-6, 1e-6) {
+		t.Errorf("<u, J * v>=%v, <J^T * u, v>=%v", uJv, JTuv)
+	}
+}
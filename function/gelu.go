@@ -13,36 +13,65 @@ const (
 )
 
 // GELU applies the Gaussian Error Linear Unit function.
-func GELU(x ...*variable.Variable) *variable.Variable {
-	return (&variable.Function{
-		Forwarder: &GELUT{},
+func GELU[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return (&variable.Function[T]{
+		Forwarder: &GELUT[T]{},
 	}).First(x...)
 }
 
 // GELUT is the differentiable GELU operation.
-type GELUT struct {
-	x *variable.Variable
+type GELUT[T tensor.Float] struct {
+	x *variable.Variable[T]
 }
 
-func (f *GELUT) Forward(x ...*variable.Variable) []*variable.Variable {
+func (f *GELUT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
 	f.x = x[0]
 
-	y := tensor.F(x[0].Data, func(x float64) float64 {
-		return 0.5 * x * (1.0 + math.Tanh(sqrt2overPi*(x+c*x*x*x)))
-	})
-
-	return []*variable.Variable{
+	y := tensor.F(x[0].Data, gelu)
+	return []*variable.Variable[T]{
 		variable.From(y),
 	}
 }
 
-func (f *GELUT) Backward(gy ...*variable.Variable) []*variable.Variable {
-	x2, x3 := Pow(2)(f.x), Pow(3)(f.x)
-	tanh := Tanh(MulC(sqrt2overPi, Add(f.x, MulC(c, x3))))
+func (f *GELUT[T]) Backward(gy ...*variable.Variable[T]) []*variable.Variable[T] {
+	return []*variable.Variable[T]{
+		Mul(gy[0], dgelu(f.x)),
+	}
+}
+
+func (f *GELUT[T]) JVP(x, y, tx []*variable.Variable[T]) []*variable.Variable[T] {
+	return []*variable.Variable[T]{
+		Mul(tx[0], dgelu(x[0])),
+	}
+}
+
+func (f *GELUT[T]) Batch(x []*variable.Variable[T], batched []bool) []*variable.Variable[T] {
+	return []*variable.Variable[T]{
+		GELU(x[0]),
+	}
+}
+
+func (f *GELUT[T]) Scalar() (func(x T) T, func(x T) T) {
+	return gelu[T], func(x T) T {
+		tanh := T(math.Tanh(sqrt2overPi * float64(x+c*x*x*x)))
+		a := 0.5 + 0.5*tanh
+		b := 0.5 * x * (1.0 - tanh*tanh)
+		du := sqrt2overPi * (1.0 + 3.0*c*x*x)
+		return a + b*du
+	}
+}
+
+// gelu returns GELU at x.
+func gelu[T tensor.Float](x T) T {
+	return 0.5 * x * (1.0 + T(math.Tanh(sqrt2overPi*float64(x+c*x*x*x))))
+}
+
+// dgelu returns the derivative of GELU at x.
+func dgelu[T tensor.Float](x *variable.Variable[T]) *variable.Variable[T] {
+	x2, x3 := Pow[T](2)(x), Pow[T](3)(x)
+	tanh := Tanh(MulC(sqrt2overPi, Add(x, MulC(c, x3))))
 	a := AddC(0.5, MulC(0.5, tanh))
-	b := MulC(0.5, Mul(f.x, SubC(1.0, Pow(2)(tanh))))
+	b := MulC(0.5, Mul(x, SubC(1.0, Pow[T](2)(tanh))))
 	du := MulC(sqrt2overPi, AddC(1.0, MulC(3.0*c, x2)))
-	return []*variable.Variable{
-		Mul(gy[0], Add(a, Mul(b, du))),
-	}
+	return Add(a, Mul(b, du))
 }
@@ -0,0 +1,105 @@
+package function
+
+import (
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+// Im2col returns a function that extracts the sliding windows of x[0] with shape (N, C, *spatial)
+// into the columns of a variable with shape (N, C*K, L), where K is the size of the kernel and L is the number of windows.
+// kernel, stride, padding and dilation have one value for every spatial axis.
+func Im2col[T tensor.Float](kernel, stride, padding, dilation []int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return (&variable.Function[T]{
+		Forwarder: &Im2colT[T]{
+			Kernel:   kernel,
+			Stride:   stride,
+			Padding:  padding,
+			Dilation: dilation,
+		},
+	}).First
+}
+
+// Im2colT is the differentiable operation that extracts sliding windows.
+type Im2colT[T tensor.Float] struct {
+	Kernel, Stride, Padding, Dilation []int
+	xShape                            []int
+}
+
+func (f *Im2colT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
+	f.xShape = x[0].Shape()
+
+	y := tensor.Im2col(x[0].Data, f.Kernel, f.Stride, f.Padding, f.Dilation)
+	return []*variable.Variable[T]{
+		variable.From(y),
+	}
+}
+
+func (f *Im2colT[T]) Backward(gy ...*variable.Variable[T]) []*variable.Variable[T] {
+	return []*variable.Variable[T]{
+		Col2im[T](f.xShape, f.Kernel, f.Stride, f.Padding, f.Dilation)(gy[0]),
+	}
+}
+
+func (f *Im2colT[T]) JVP(x, y, tx []*variable.Variable[T]) []*variable.Variable[T] {
+	return []*variable.Variable[T]{
+		Im2col[T](f.Kernel, f.Stride, f.Padding, f.Dilation)(tx[0]),
+	}
+}
+
+func (f *Im2colT[T]) Batch(x []*variable.Variable[T], batched []bool) []*variable.Variable[T] {
+	// fold the examples into N
+	shape := x[0].Shape()
+	xs := Reshape[T](append([]int{shape[0] * shape[1]}, shape[2:]...)...)(x[0])
+	y := Im2col[T](f.Kernel, f.Stride, f.Padding, f.Dilation)(xs)
+	return []*variable.Variable[T]{
+		Reshape[T](append(shape[:2:2], y.Shape()[1:]...)...)(y),
+	}
+}
+
+// Col2im returns a function that adds up the columns of x[0] with shape (N, C*K, L) into a variable with the given shape (N, C, *spatial).
+// It is the adjoint of Im2col.
+func Col2im[T tensor.Float](shape, kernel, stride, padding, dilation []int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return (&variable.Function[T]{
+		Forwarder: &Col2imT[T]{
+			Shape:    shape,
+			Kernel:   kernel,
+			Stride:   stride,
+			Padding:  padding,
+			Dilation: dilation,
+		},
+	}).First
+}
+
+// Col2imT is the differentiable operation that adds up sliding windows.
+type Col2imT[T tensor.Float] struct {
+	Shape, Kernel, Stride, Padding, Dilation []int
+}
+
+func (f *Col2imT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
+	y := tensor.Col2im(x[0].Data, f.Shape, f.Kernel, f.Stride, f.Padding, f.Dilation)
+	return []*variable.Variable[T]{
+		variable.From(y),
+	}
+}
+
+func (f *Col2imT[T]) Backward(gy ...*variable.Variable[T]) []*variable.Variable[T] {
+	return []*variable.Variable[T]{
+		Im2col[T](f.Kernel, f.Stride, f.Padding, f.Dilation)(gy[0]),
+	}
+}
+
+func (f *Col2imT[T]) JVP(x, y, tx []*variable.Variable[T]) []*variable.Variable[T] {
+	return []*variable.Variable[T]{
+		Col2im[T](f.Shape, f.Kernel, f.Stride, f.Padding, f.Dilation)(tx[0]),
+	}
+}
+
+func (f *Col2imT[T]) Batch(x []*variable.Variable[T], batched []bool) []*variable.Variable[T] {
+	// fold the examples into N
+	b, shape := x[0].Size(0), x[0].Shape()
+	xs := Reshape[T](append([]int{b * shape[1]}, shape[2:]...)...)(x[0])
+	y := Col2im[T](append([]int{b * f.Shape[0]}, f.Shape[1:]...), f.Kernel, f.Stride, f.Padding, f.Dilation)(xs)
+	return []*variable.Variable[T]{
+		Reshape[T](append([]int{b}, f.Shape...)...)(y),
+	}
+}
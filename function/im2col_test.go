@@ -0,0 +1,67 @@
+package function_test
+
+import (
+	"fmt"
+	"testing"
+
+	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleIm2col() {
+	x := variable.New(1, 2, 3, 4).Reshape(1, 1, 4)
+
+	y := F.Im2col[float64]([]int{2}, []int{1}, []int{0}, []int{1})(x)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+
+	// Output:
+	// variable[1 2 3]([1 2 3 2 3 4])
+	// variable[1 1 4]([1 2 2 1])
+}
+
+func ExampleCol2im() {
+	x := variable.New(1, 2, 3, 2, 3, 4).Reshape(1, 2, 3)
+
+	y := F.Col2im[float64]([]int{1, 1, 4}, []int{2}, []int{1}, []int{0}, []int{1})(x)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+
+	// Output:
+	// variable[1 1 4]([1 4 6 4])
+	// variable[1 2 3]([1 1 1 1 1 1])
+}
+
+func TestIm2col_batch(t *testing.T) {
+	x := variable.Randn([]int{3, 2, 2, 5}, rand.Const(1))
+	im2col := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Im2col[float64]([]int{3}, []int{2}, []int{1}, []int{1})(x...)
+	}
+
+	col2im := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Col2im[float64]([]int{2, 2, 5}, []int{3}, []int{2}, []int{1}, []int{1})(x...)
+	}
+
+	// the batching rules and the examples one by one
+	col := variable.Unbatch(im2col(variable.Batch(x, 0)), 0)
+	im := variable.Unbatch(col2im(variable.Batch(col, 0)), 0)
+	for i := range x.Size(0) {
+		xi := F.Slice[float64](tensor.Index(i))(x)
+
+		coli := im2col(xi)
+		if !tensor.IsCloseAll(F.Slice[float64](tensor.Index(i))(col).Data, coli.Data, 1e-12, 1e-12) {
+			t.Errorf("col[%d]=%v, want=%v", i, F.Slice[float64](tensor.Index(i))(col).Data.Data, coli.Data.Data)
+		}
+
+		imi := col2im(coli)
+		if !tensor.IsCloseAll(F.Slice[float64](tensor.Index(i))(im).Data, imi.Data, 1e-12, 1e-12) {
+			t.Errorf("im[%d]=%v, want=%v", i, F.Slice[float64](tensor.Index(i))(im).Data.Data, imi.Data.Data)
+		}
+	}
+}
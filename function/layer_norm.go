@@ -0,0 +1,220 @@
+package function
+
+import (
+	"math"
+	"slices"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+// LayerNorm returns a function that normalizes x[0] to zero mean and unit variance along the given axes,
+// and scales the result by x[1] and shifts it by x[2] if they exist. x[1] and x[2] must be broadcastable to x[0].
+// If no axis is given, it normalizes along the last axis.
+func LayerNorm[T tensor.Float](axes []int, eps T) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return (&variable.Function[T]{
+		Forwarder: &LayerNormT[T]{
+			Axes: normAxes(axes),
+			Eps:  eps,
+		},
+	}).First
+}
+
+// LayerNormT is the differentiable layer normalization operation.
+// Forward saves the normalized x and the inverse standard deviation of every sample along Axes.
+// Backward computes the gradient of x from them row by row without creating the graph,
+// and otherwise recomputes the per-sample statistics from x so that the gradient is differentiable.
+type LayerNormT[T tensor.Float] struct {
+	Axes         []int
+	Eps          T
+	x            []*variable.Variable[T]
+	axes         []int
+	xhat, invstd *tensor.Tensor[T]
+}
+
+func (f *LayerNormT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
+	f.x = x
+	f.axes = nonneg(f.Axes, x[0].NumDims())
+	f.invstd = tensor.Zeros[T](tensor.KeepDims(x[0].Shape(), f.axes)...)
+
+	xr, restore := rows(x[0].Data, f.axes)
+	xhat, m := tensor.ZeroLike(xr), xr.Shape[1]
+	for r := range xr.Shape[0] {
+		row, out := xr.Data[r*m:(r+1)*m], xhat.Data[r*m:(r+1)*m]
+
+		var mu, sigma2 T
+		for _, v := range row {
+			mu += v
+		}
+		mu /= T(m)
+
+		for _, v := range row {
+			sigma2 += (v - mu) * (v - mu)
+		}
+		sigma2 /= T(m)
+
+		// xhat = (x - mean(x)) / sqrt(var(x) + eps)
+		invstd := 1 / T(math.Sqrt(float64(sigma2+f.Eps)))
+		for j, v := range row {
+			out[j] = (v - mu) * invstd
+		}
+
+		f.invstd.Data[r] = invstd
+	}
+
+	f.xhat = restore(xhat)
+	return []*variable.Variable[T]{
+		variable.From(affine(f.xhat, x)),
+	}
+}
+
+func (f *LayerNormT[T]) Backward(gy ...*variable.Variable[T]) []*variable.Variable[T] {
+	gxhat := scale(gy[0], f.x)
+	if !variable.SessionOf(gy[0]).EnableBackprop {
+		gr, restore := rows(gxhat.Data, f.axes)
+		xr, _ := rows(f.xhat, f.axes)
+		gx, m := tensor.ZeroLike(gr), gr.Shape[1]
+		for r := range gr.Shape[0] {
+			g, xh, out := gr.Data[r*m:(r+1)*m], xr.Data[r*m:(r+1)*m], gx.Data[r*m:(r+1)*m]
+
+			var mg, mgx T
+			for j := range g {
+				mg += g[j]
+				mgx += g[j] * xh[j]
+			}
+			mg, mgx = mg/T(m), mgx/T(m)
+
+			// invstd * (gxhat - mean(gxhat) - xhat * mean(gxhat * xhat))
+			for j := range g {
+				out[j] = f.invstd.Data[r] * (g[j] - mg - xh[j]*mgx)
+			}
+		}
+
+		return append([]*variable.Variable[T]{variable.From(restore(gx))}, gaffine(gy[0], variable.From(f.xhat), f.x)...)
+	}
+
+	// invstd * (gxhat - mean(gxhat) - xhat * mean(gxhat * xhat))
+	xhat, invstd := f.normalize(f.x[0])
+	gx := Mul(invstd, Sub(Sub(gxhat, keepMean(gxhat, f.axes)), Mul(xhat, keepMean(Mul(gxhat, xhat), f.axes))))
+	return append([]*variable.Variable[T]{gx}, gaffine(gy[0], xhat, f.x)...)
+}
+
+func (f *LayerNormT[T]) JVP(x, y, tx []*variable.Variable[T]) []*variable.Variable[T] {
+	xhat, invstd := variable.From(f.xhat), variable.From(f.invstd)
+
+	// invstd * (tx - mean(tx) - xhat * mean(tx * xhat))
+	txhat := Mul(invstd, Sub(Sub(tx[0], keepMean(tx[0], f.axes)), Mul(xhat, keepMean(Mul(tx[0], xhat), f.axes))))
+	return []*variable.Variable[T]{
+		taffine(txhat, xhat, x, tx),
+	}
+}
+
+// normalize returns the normalized x and the inverse of its standard deviation as differentiable functions of x.
+func (f *LayerNormT[T]) normalize(x *variable.Variable[T]) (*variable.Variable[T], *variable.Variable[T]) {
+	xc := Sub(x, keepMean(x, f.axes))                                 // x - mean(x)
+	invstd := Pow[T](-0.5)(AddC(f.Eps, keepMean(Square(xc), f.axes))) // 1 / sqrt(var(x) + eps)
+	return Mul(xc, invstd), invstd
+}
+
+// normAxes returns the axes to normalize along, which are the last axis if none is given.
+func normAxes(axes []int) []int {
+	if len(axes) == 0 {
+		return []int{-1}
+	}
+
+	return axes
+}
+
+// nonneg returns the axes of a tensor with ndim dimensions with the negative ones counted from the end.
+func nonneg(axes []int, ndim int) []int {
+	out := make([]int, len(axes))
+	for i, a := range axes {
+		if a < 0 {
+			a += ndim
+		}
+
+		out[i] = a
+	}
+
+	return out
+}
+
+// rows returns v as a contiguous matrix with a row of the elements along the axes for every index of the other axes,
+// and the function that restores a matrix with the same shape to the layout of v.
+func rows[T tensor.Float](v *tensor.Tensor[T], axes []int) (*tensor.Tensor[T], func(w *tensor.Tensor[T]) *tensor.Tensor[T]) {
+	ndim := v.NumDims()
+
+	// move the axes to the end
+	var perm []int
+	for i := range ndim {
+		if !slices.Contains(axes, i) {
+			perm = append(perm, i)
+		}
+	}
+	perm = append(perm, axes...)
+
+	inv := make([]int, ndim)
+	for i, p := range perm {
+		inv[p] = i
+	}
+
+	vt := tensor.Contiguous(tensor.Transpose(v, perm...))
+	shape, m := vt.Shape, prod(vt.Shape[ndim-len(axes):])
+	return tensor.Reshape(vt, vt.Size()/m, m), func(w *tensor.Tensor[T]) *tensor.Tensor[T] {
+		return tensor.Contiguous(tensor.Transpose(tensor.Reshape(w, shape...), inv...))
+	}
+}
+
+// keepMean returns the mean of x along the axes with the axes kept.
+func keepMean[T tensor.Float](x *variable.Variable[T], axes []int) *variable.Variable[T] {
+	return Reshape[T](tensor.KeepDims(x.Shape(), axes)...)(Mean[T](axes...)(x))
+}
+
+// affine returns the normalized xhat scaled by x[1] and shifted by x[2] if they exist.
+func affine[T tensor.Float](xhat *tensor.Tensor[T], x []*variable.Variable[T]) *tensor.Tensor[T] {
+	if len(x) > 1 {
+		xhat = tensor.Mul(xhat, x[1].Data)
+	}
+
+	if len(x) > 2 {
+		xhat = tensor.Add(xhat, x[2].Data)
+	}
+
+	return xhat
+}
+
+// scale returns gy scaled by x[1] if it exists, which is the gradient of the normalized x.
+func scale[T tensor.Float](gy *variable.Variable[T], x []*variable.Variable[T]) *variable.Variable[T] {
+	if len(x) > 1 {
+		return Mul(gy, x[1])
+	}
+
+	return gy
+}
+
+// gaffine returns the gradients of x[1] and x[2] that exist.
+func gaffine[T tensor.Float](gy, xhat *variable.Variable[T], x []*variable.Variable[T]) []*variable.Variable[T] {
+	var gxs []*variable.Variable[T]
+	if len(x) > 1 {
+		gxs = append(gxs, SumTo[T](x[1].Shape()...)(Mul(gy, xhat))) // sum(gy * xhat)
+	}
+
+	if len(x) > 2 {
+		gxs = append(gxs, SumTo[T](x[2].Shape()...)(gy)) // sum(gy)
+	}
+
+	return gxs
+}
+
+// taffine returns the tangent of the normalized x scaled by x[1] and shifted by x[2] if they exist.
+func taffine[T tensor.Float](txhat, xhat *variable.Variable[T], x, tx []*variable.Variable[T]) *variable.Variable[T] {
+	if len(x) > 1 {
+		txhat = Add(Mul(x[1], txhat), Mul(tx[1], xhat)) // x1 * txhat + tx1 * xhat
+	}
+
+	if len(x) > 2 {
+		txhat = Add(txhat, tx[2])
+	}
+
+	return txhat
+}
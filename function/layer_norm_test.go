@@ -0,0 +1,136 @@
+package function_test
+
+import (
+	"fmt"
+	"testing"
+
+	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/grad"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleLayerNorm() {
+	x := variable.New(
+		1, 2, 3,
+		2, 4, 6,
+	).Reshape(2, 3)
+
+	y := F.LayerNorm[float64](nil, 0)(x)
+	fmt.Printf("%.4f\n", y.Data.Data)
+
+	// Output:
+	// [-1.2247 0.0000 1.2247 -1.2247 0.0000 1.2247]
+}
+
+func ExampleLayerNorm_affine() {
+	x := variable.New(
+		1, 3,
+		5, 9,
+	).Reshape(2, 2)
+	gamma := variable.New(1, 2)
+	beta := variable.New(0, 10)
+
+	y := F.LayerNorm[float64]([]int{1}, 0)(x, gamma, beta)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(gamma.Grad, beta.Grad)
+
+	// Output:
+	// variable[2 2]([-1 12 -1 12])
+	// variable[2]([-2 2]) variable[2]([2 2])
+}
+
+func TestLayerNorm(t *testing.T) {
+	cases := []struct {
+		x, gamma []int
+		axes     []int
+	}{
+		{[]int{3, 4}, nil, nil},
+		{[]int{3, 4}, []int{4}, []int{-1}},
+		{[]int{2, 3, 4}, []int{3, 4}, []int{1, 2}},
+		{[]int{2, 3, 4}, []int{3, 1}, []int{1}},
+		{[]int{2, 3, 5}, []int{5}, nil},
+	}
+
+	for _, c := range cases {
+		x := variable.Randn(c.x, rand.Const(1))
+		xs := []*variable.Variable[float64]{x}
+		if c.gamma != nil {
+			xs = append(xs, variable.Randn(c.gamma, rand.Const(2)), variable.Randn(c.gamma, rand.Const(3)))
+		}
+
+		// the normalization with the primitive operations
+		axes := c.axes
+		if len(axes) == 0 {
+			axes = []int{-1}
+		}
+
+		kd := tensor.KeepDims(c.x, axes)
+		xc := F.Sub(x, F.Reshape[float64](kd...)(F.Mean[float64](axes...)(x)))
+		want := F.Div(xc, F.Pow(0.5)(F.AddC(1e-5, F.Reshape[float64](kd...)(F.Variance[float64](axes...)(x)))))
+		if c.gamma != nil {
+			want = F.Add(F.Mul(want, xs[1]), xs[2])
+		}
+
+		got := F.LayerNorm[float64](c.axes, 1e-5)(xs...)
+		if !tensor.IsCloseAll(got.Data, want.Data, 1e-10, 1e-10) {
+			t.Errorf("got=%v, want=%v", got.Data.Data, want.Data.Data)
+		}
+
+		// gradients
+		f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.LayerNorm[float64](c.axes, 1e-5)(x...)
+		}
+
+		gradcheck(t, f, xs...)
+
+		// the gradients with the graph for higher-order derivatives
+		for i, g := range grad.Grad(f)(xs...) {
+			if !tensor.IsCloseAll(g.Data, xs[i].Grad.Data, 1e-10, 1e-10) {
+				t.Errorf("x[%d]: got=%v, want=%v", i, g.Data.Data, xs[i].Grad.Data.Data)
+			}
+		}
+	}
+}
+
+func TestLayerNorm_double(t *testing.T) {
+	x := variable.Randn([]int{3, 4}, rand.Const(1))
+	gamma := variable.Randn([]int{4}, rand.Const(2))
+	beta := variable.Randn([]int{4}, rand.Const(3))
+	w := variable.Randn([]int{3, 4}, rand.Const(4))
+
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		y := F.LayerNorm[float64](nil, 1e-5)(x...)
+		return F.Sum[float64]()(F.Mul(w, F.Tanh(y)))
+	}
+
+	// the gradient of the squared norm of the gradient with respect to x
+	g := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		gx := grad.Grad(f)(x...)[0]
+		return F.Sum[float64]()(F.Square(gx))
+	}
+
+	gradcheck(t, g, x, gamma, beta)
+}
+
+func BenchmarkLayerNorm(b *testing.B) {
+	x := variable.Randn([]int{64, 512}, rand.Const(1))
+	gamma, beta := variable.Ones[float64](512), variable.Zeros[float64](512)
+
+	b.Run("composed", func(b *testing.B) {
+		for range b.N {
+			xc := F.Sub(x, F.Reshape[float64](64, 1)(F.Mean[float64](1)(x)))
+			xhat := F.Div(xc, F.Pow(0.5)(F.AddC(1e-5, F.Reshape[float64](64, 1)(F.Variance[float64](1)(x)))))
+			F.Add(F.Mul(xhat, gamma), beta).Backward()
+		}
+	})
+
+	b.Run("fused", func(b *testing.B) {
+		for range b.N {
+			F.LayerNorm[float64](nil, 1e-5)(x, gamma, beta).Backward()
+		}
+	})
+}
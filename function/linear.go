@@ -6,62 +6,140 @@ import (
 )
 
 // Linear applies a linear transformation.
-func Linear(x ...*variable.Variable) *variable.Variable {
-	return (&variable.Function{
-		Forwarder: &LinearT{},
+func Linear[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return (&variable.Function[T]{
+		Forwarder: &LinearT[T]{},
 	}).First(x...)
 }
 
 // LinearT is the differentiable linear transformation.
-type LinearT struct {
-	x, w, b *variable.Variable
+type LinearT[T tensor.Float] struct {
+	x, w, b *variable.Variable[T]
+	scratch []*tensor.Tensor[T]
 }
 
 // Forward computes the linear transformation of x and w, and adds bias b if it exists.
-func (f *LinearT) Forward(x ...*variable.Variable) []*variable.Variable {
+func (f *LinearT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
 	f.x, f.w = x[0], x[1]
 
 	y := tensor.MatMul(x[0].Data, x[1].Data)
 	if len(x) < 3 {
 		// no bias
-		return []*variable.Variable{
+		return []*variable.Variable[T]{
 			variable.From(y),
 		}
 	}
 
 	// add bias
 	f.b, y = x[2], tensor.Add(y, x[2].Data)
-	return []*variable.Variable{
+	return []*variable.Variable[T]{
 		variable.From(y),
 	}
 }
 
 // Backward computes the gradients of the input variables x, w, and b (if it exists) with respect to the output gradient gy.
-func (f *LinearT) Backward(gy ...*variable.Variable) []*variable.Variable {
-	gx := MatMul(gy[0], TransposeMatMul(f.w.NumDims())(f.w)) // gy * w.T
-	gw := MatMul(TransposeMatMul(f.x.NumDims())(f.x), gy[0]) // x.T * gy
+func (f *LinearT[T]) Backward(gy ...*variable.Variable[T]) []*variable.Variable[T] {
+	gx := MatMul(gy[0], TransposeMatMul[T](f.w.NumDims())(f.w)) // gy * w.T
+	gw := MatMul(TransposeMatMul[T](f.x.NumDims())(f.x), gy[0]) // x.T * gy
 
 	if !tensor.SliceEqual(gx.Shape(), f.x.Shape()) {
-		gx = SumTo(f.x.Shape()...)(gx)
+		gx = SumTo[T](f.x.Shape()...)(gx)
 	}
 
 	if !tensor.SliceEqual(gw.Shape(), f.w.Shape()) {
-		gw = SumTo(f.w.Shape()...)(gw)
+		gw = SumTo[T](f.w.Shape()...)(gw)
 	}
 
 	if f.b == nil {
 		// no bias
-		return []*variable.Variable{
+		return []*variable.Variable[T]{
 			gx,
 			gw,
 		}
 	}
 
 	// add bias
-	gb := SumTo(f.b.Shape()...)(gy[0])
-	return []*variable.Variable{
+	gb := SumTo[T](f.b.Shape()...)(gy[0])
+	return []*variable.Variable[T]{
 		gx,
 		gw,
 		gb,
 	}
 }
+
+// JVP computes the tangent of the output with respect to the tangents of x, w, and b (if it exists).
+func (f *LinearT[T]) JVP(x, y, tx []*variable.Variable[T]) []*variable.Variable[T] {
+	ty := Add(MatMul(tx[0], x[1]), MatMul(x[0], tx[1])) // tx * w + x * tw
+	if len(x) < 3 {
+		// no bias
+		return []*variable.Variable[T]{
+			ty,
+		}
+	}
+
+	// add bias
+	return []*variable.Variable[T]{
+		Add(ty, tx[2]),
+	}
+}
+
+// ForwardInto writes the linear transformation of x and w, and bias b if it exists, into y[0].
+// It reports false, and writes nothing, unless x and w are matrices and b has a value for every column.
+func (f *LinearT[T]) ForwardInto(y []*tensor.Tensor[T], x ...*variable.Variable[T]) bool {
+	if x[0].NumDims() != 2 || x[1].NumDims() != 2 || (len(x) > 2 && x[2].Size() != x[1].Size(1)) {
+		return false
+	}
+
+	f.x, f.w, f.b = x[0], x[1], nil
+	tensor.MatMulTo(y[0], x[0].Data, x[1].Data)
+	if len(x) < 3 {
+		return true
+	}
+
+	// add bias to every row
+	f.b = x[2]
+	b, n := tensor.Contiguous(x[2].Data).Data, y[0].Shape[1]
+	for i := range y[0].Data {
+		y[0].Data[i] += b[i%n]
+	}
+
+	return true
+}
+
+// BackwardInto adds the gradients of x, w, and b (if it exists) to gx.
+// The transposes and the products are computed in scratch buffers that are reused.
+func (f *LinearT[T]) BackwardInto(gx []*tensor.Tensor[T], gy ...*tensor.Tensor[T]) {
+	m, k, n := f.x.Size(0), f.x.Size(1), f.w.Size(1)
+	if f.scratch == nil || !tensor.SliceEqual(f.scratch[2].Shape, []int{m, k}) || !tensor.SliceEqual(f.scratch[3].Shape, []int{k, n}) {
+		f.scratch = []*tensor.Tensor[T]{
+			tensor.Zeros[T](n, k), // w.T
+			tensor.Zeros[T](k, m), // x.T
+			tensor.Zeros[T](m, k), // gy * w.T
+			tensor.Zeros[T](k, n), // x.T * gy
+		}
+	}
+
+	wt, xt, gxw, gwx := f.scratch[0], f.scratch[1], f.scratch[2], f.scratch[3]
+	if gx[0] != nil {
+		tensor.MatMulTo(gxw, gy[0], tensor.Copy(wt, tensor.Transpose(f.w.Data)))
+		for i, v := range gxw.Data {
+			gx[0].Data[i] += v
+		}
+	}
+
+	if gx[1] != nil {
+		tensor.MatMulTo(gwx, tensor.Copy(xt, tensor.Transpose(f.x.Data)), gy[0])
+		for i, v := range gwx.Data {
+			gx[1].Data[i] += v
+		}
+	}
+
+	if f.b == nil || gx[2] == nil {
+		return
+	}
+
+	// sum(gy) along the rows
+	for i, v := range gy[0].Data {
+		gx[2].Data[i%n] += v
+	}
+}
@@ -8,9 +8,9 @@ import (
 // MaskFill returns a function that fills elements of x with the given value v where the corresponding elements of mask are 0.
 // This is typically used for attention masking in Transformer models,
 // e.g. filling masked positions with a large negative value before softmax.
-func MaskFill(mask *tensor.Tensor[float64], f func(m float64) bool, v float64) func(x ...*variable.Variable) *variable.Variable {
-	return (&variable.Function{
-		Forwarder: &MaskFillT{
+func MaskFill[T tensor.Float](mask *tensor.Tensor[T], f func(m T) bool, v T) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return (&variable.Function[T]{
+		Forwarder: &MaskFillT[T]{
 			mask: mask,
 			fill: v,
 			cond: f,
@@ -18,22 +18,28 @@ func MaskFill(mask *tensor.Tensor[float64], f func(m float64) bool, v float64) f
 	}).First
 }
 
-type MaskFillT struct {
-	mask *tensor.Tensor[float64]
-	fill float64
-	cond func(m float64) bool
+type MaskFillT[T tensor.Float] struct {
+	mask *tensor.Tensor[T]
+	fill T
+	cond func(m T) bool
 }
 
-func (f *MaskFillT) Forward(x ...*variable.Variable) []*variable.Variable {
+func (f *MaskFillT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
 	filled := tensor.MaskFill(x[0].Data, f.mask, f.cond, f.fill)
 
-	return []*variable.Variable{
+	return []*variable.Variable[T]{
 		variable.From(filled),
 	}
 }
 
-func (f *MaskFillT) Backward(gy ...*variable.Variable) []*variable.Variable {
-	return []*variable.Variable{
+func (f *MaskFillT[T]) Backward(gy ...*variable.Variable[T]) []*variable.Variable[T] {
+	return []*variable.Variable[T]{
 		Mul(gy[0], variable.From(f.mask)),
 	}
 }
+
+func (f *MaskFillT[T]) JVP(x, y, tx []*variable.Variable[T]) []*variable.Variable[T] {
+	return []*variable.Variable[T]{
+		Mul(tx[0], variable.From(f.mask)),
+	}
+}
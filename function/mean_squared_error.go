@@ -6,33 +6,97 @@ import (
 )
 
 // MeanSquaredError computes the mean squared error loss.
-func MeanSquaredError(x ...*variable.Variable) *variable.Variable {
-	return (&variable.Function{
-		Forwarder: &MeanSquaredErrorT{},
+func MeanSquaredError[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return (&variable.Function[T]{
+		Forwarder: &MeanSquaredErrorT[T]{},
 	}).First(x...)
 }
 
 // MeanSquaredErrorT is the differentiable mean squared error operation.
-type MeanSquaredErrorT struct {
-	x0, x1 *variable.Variable
+type MeanSquaredErrorT[T tensor.Float] struct {
+	x0, x1 *variable.Variable[T]
 }
 
-func (f *MeanSquaredErrorT) Forward(x ...*variable.Variable) []*variable.Variable {
+func (f *MeanSquaredErrorT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
 	f.x0, f.x1 = x[0], x[1]
 
-	diff := tensor.Sub(x[0].Data, x[1].Data)                            // x0 - x1
-	y := tensor.Sum(tensor.Mul(diff, diff)).At() / float64(diff.Size()) // (x0 - x1)^2 / N
-	return []*variable.Variable{
-		variable.New(y),
+	diff := tensor.Sub(x[0].Data, x[1].Data)                      // x0 - x1
+	y := tensor.Sum(tensor.Mul(diff, diff)).At() / T(diff.Size()) // (x0 - x1)^2 / N
+	return []*variable.Variable[T]{
+		variable.From(tensor.Full([]int{1}, y)),
 	}
 }
 
-func (f *MeanSquaredErrorT) Backward(gy ...*variable.Variable) []*variable.Variable {
-	diff := Sub(f.x0, f.x1)                                 // x0 - x1
-	gx0 := MulC(2.0/float64(diff.Size()), Mul(gy[0], diff)) // gy * (x0 - x1) * 2/N
-	gx1 := Neg(gx0)                                         // -gx0
-	return []*variable.Variable{
+func (f *MeanSquaredErrorT[T]) Backward(gy ...*variable.Variable[T]) []*variable.Variable[T] {
+	diff := Sub(f.x0, f.x1)                           // x0 - x1
+	gx0 := MulC(2.0/T(diff.Size()), Mul(gy[0], diff)) // gy * (x0 - x1) * 2/N
+	gx1 := Neg(gx0)                                   // -gx0
+	return []*variable.Variable[T]{
 		gx0,
 		gx1,
 	}
 }
+
+func (f *MeanSquaredErrorT[T]) JVP(x, y, tx []*variable.Variable[T]) []*variable.Variable[T] {
+	diff := Sub(x[0], x[1])                                    // x0 - x1
+	tdiff := Sub(tx[0], tx[1])                                 // tx0 - tx1
+	ty := MulC(2.0/T(diff.Size()), Sum[T]()(Mul(diff, tdiff))) // sum((x0 - x1) * (tx0 - tx1)) * 2/N
+	return []*variable.Variable[T]{
+		Reshape[T](y[0].Shape()...)(ty),
+	}
+}
+
+// Batch computes the mean squared error of each example.
+// The examples of x[0] and x[1] must have the same number of dimensions.
+func (f *MeanSquaredErrorT[T]) Batch(x []*variable.Variable[T], batched []bool) []*variable.Variable[T] {
+	diff := Square(Sub(x[0], x[1])) // (x0 - x1)^2
+	if diff.NumDims() == 1 {
+		return []*variable.Variable[T]{
+			Reshape[T](diff.Size(0), 1)(diff),
+		}
+	}
+
+	axes := make([]int, diff.NumDims()-1)
+	for i := range axes {
+		axes[i] = i + 1
+	}
+
+	return []*variable.Variable[T]{
+		Reshape[T](diff.Size(0), 1)(Mean[T](axes...)(diff)),
+	}
+}
+
+// ForwardInto writes the mean squared error of x[0] and x[1] into y[0].
+// It reports false, and writes nothing, if x[0] and x[1] have different shapes.
+func (f *MeanSquaredErrorT[T]) ForwardInto(y []*tensor.Tensor[T], x ...*variable.Variable[T]) bool {
+	if !tensor.SliceEqual(x[0].Shape(), x[1].Shape()) {
+		return false
+	}
+
+	f.x0, f.x1 = x[0], x[1]
+	x0, x1 := tensor.Contiguous(x[0].Data).Data, tensor.Contiguous(x[1].Data).Data
+
+	var sum T
+	for i := range x0 {
+		sum += (x0[i] - x1[i]) * (x0[i] - x1[i])
+	}
+
+	y[0].Data[0] = sum / T(len(x0)) // (x0 - x1)^2 / N
+	return true
+}
+
+// BackwardInto adds the gradients of x[0] and x[1] to gx.
+func (f *MeanSquaredErrorT[T]) BackwardInto(gx []*tensor.Tensor[T], gy ...*tensor.Tensor[T]) {
+	x0, x1 := tensor.Contiguous(f.x0.Data).Data, tensor.Contiguous(f.x1.Data).Data
+	c := gy[0].Data[0] * 2 / T(len(x0))
+	for i := range x0 {
+		g := c * (x0[i] - x1[i]) // gy * (x0 - x1) * 2/N
+		if gx[0] != nil {
+			gx[0].Data[i] += g
+		}
+
+		if gx[1] != nil {
+			gx[1].Data[i] -= g
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package function
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+// PoolOpts configures a pooling.
+// Stride and Padding have one value for every spatial axis, or a single value for all of them.
+// The defaults are the stride of the kernel size and padding 0.
+type PoolOpts struct {
+	Stride  []int
+	Padding []int
+}
+
+// MaxPool2d returns a function that takes the maximum of every window of x[0] with shape (N, C, H, W).
+// The output has shape (N, C, OH, OW), and the gradients are routed to the positions of the maximum.
+// The padding is at most half of the kernel size, so that every window has an element of x[0].
+func MaxPool2d[T tensor.Float](kernel []int, opts ...PoolOpts) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return func(x ...*variable.Variable[T]) *variable.Variable[T] {
+		return maxPool(2, kernel, x[0], opts...)
+	}
+}
+
+// AvgPool2d returns a function that takes the mean of every window of x[0] with shape (N, C, H, W).
+// The output has shape (N, C, OH, OW), and the padded zeros are included in the mean.
+func AvgPool2d[T tensor.Float](kernel []int, opts ...PoolOpts) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return func(x ...*variable.Variable[T]) *variable.Variable[T] {
+		col, out := windows(2, kernel, x[0], opts...)
+		return Reshape[T](out...)(Mean[T](2)(col))
+	}
+}
+
+// AdaptiveAvgPool2d returns a function that takes the mean of x[0] with shape (N, C, H, W) over the windows that divide it into the output size.
+// The i-th window along an axis of the size n starts at floor(i*n/size) and ends at ceil((i+1)*n/size).
+// The output has shape (N, C, size[0], size[1]).
+func AdaptiveAvgPool2d[T tensor.Float](size []int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return func(x ...*variable.Variable[T]) *variable.Variable[T] {
+		size := expand(size, 2, 1)
+		if x[0].NumDims() != 4 {
+			panic(fmt.Sprintf("shape %v does not have %d spatial axes", x[0].Shape(), 2))
+		}
+
+		// (OH, H) @ (N, C, H, W) @ (W, OW)
+		h := adaptive[T](x[0].Size(2), size[0])
+		w := adaptive[T](x[0].Size(3), size[1])
+		return MatMul(MatMul(h, x[0]), Transpose[T](1, 0)(w))
+	}
+}
+
+// GlobalAvgPool returns the mean of x[0] with shape (N, C, *spatial) over the spatial axes.
+// The output has shape (N, C).
+func GlobalAvgPool[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	axes := make([]int, x[0].NumDims()-2)
+	for i := range axes {
+		axes[i] = i + 2
+	}
+
+	return Mean[T](axes...)(x[0])
+}
+
+// maxPool takes the maximum of every window of x over ndim spatial axes by gathering the columns at the argmax.
+func maxPool[T tensor.Float](ndim int, kernel []int, x *variable.Variable[T], opts ...PoolOpts) *variable.Variable[T] {
+	k := expand(kernel, ndim, 1)
+	o := poolOpts(ndim, k, opts...)
+	for i := range ndim {
+		if 2*o.Padding[i] > k[i] {
+			panic(fmt.Sprintf("padding %v must be at most half of kernel %v", o.Padding, k))
+		}
+	}
+
+	col, out := windows(ndim, kernel, x, opts...)
+
+	// the padded elements are never the maximum
+	ones := tensor.Ones[T](append([]int{1, 1}, x.Shape()[2:]...)...)
+	mask := tensor.Im2col(ones, k, o.Stride, o.Padding, tensor.Full([]int{ndim}, 1).Data) // (1, K, L)
+	masked := tensor.Clone(col.Data)
+	for i := range masked.Data {
+		if mask.Data[i%mask.Size()] == 0 {
+			masked.Data[i] = T(math.Inf(-1))
+		}
+	}
+
+	index := tensor.Argmax(masked, 2)                                       // (N, C, L)
+	index = tensor.Reshape(index, col.Size(0), col.Size(1), 1, col.Size(3)) // (N, C, 1, L)
+	return Reshape[T](out...)(Gather[T](2, index)(col))
+}
+
+// windows returns the windows of x with shape (N, C, K, L) and the shape of the output (N, C, *out).
+func windows[T tensor.Float](ndim int, kernel []int, x *variable.Variable[T], opts ...PoolOpts) (*variable.Variable[T], []int) {
+	shape := x.Shape()
+	if len(shape) != ndim+2 {
+		panic(fmt.Sprintf("shape %v does not have %d spatial axes", shape, ndim))
+	}
+
+	k := expand(kernel, ndim, 1)
+	o := poolOpts(ndim, k, opts...)
+	d := tensor.Full([]int{ndim}, 1).Data
+	out := tensor.ConvSize(shape[2:], k, o.Stride, o.Padding, d)
+
+	n, c := shape[0], shape[1]
+	col := Im2col[T](k, o.Stride, o.Padding, d)(x)    // (N, C*K, L)
+	col = Reshape[T](n, c, prod(k), col.Size(2))(col) // (N, C, K, L)
+	return col, append([]int{n, c}, out...)
+}
+
+// poolOpts returns the options with the defaults and a value for every one of the ndim spatial axes.
+func poolOpts(ndim int, kernel []int, opts ...PoolOpts) PoolOpts {
+	var o PoolOpts
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	if len(o.Stride) == 0 {
+		o.Stride = kernel
+	}
+
+	o.Stride = expand(o.Stride, ndim, 1)
+	o.Padding = expand(o.Padding, ndim, 0)
+	return o
+}
+
+// adaptive returns the matrix with shape (size, n) that averages the adaptive windows of an axis of the size n.
+func adaptive[T tensor.Float](n, size int) *variable.Variable[T] {
+	m := tensor.Zeros[T](size, n)
+	for i := range size {
+		start, end := i*n/size, ((i+1)*n+size-1)/size
+		for j := start; j < end; j++ {
+			m.Set([]int{i, j}, 1/T(end-start))
+		}
+	}
+
+	return variable.From(m).SetRequiresGrad(false)
+}
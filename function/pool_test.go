@@ -0,0 +1,307 @@
+package function_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/grad"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleMaxPool2d() {
+	x := variable.New(
+		1, 5, 2, 0,
+		3, 4, 8, 6,
+		0, 1, 2, 3,
+		7, 2, 9, 1,
+	).Reshape(1, 1, 4, 4)
+
+	y := F.MaxPool2d[float64]([]int{2})(x)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+
+	// Output:
+	// variable[1 1 2 2]([5 8 7 9])
+	// variable[1 1 4 4]([0 1 0 0 0 0 1 0 0 0 0 0 1 0 1 0])
+}
+
+func ExampleMaxPool2d_padding() {
+	x := variable.New(
+		-1, -2,
+		-3, -4,
+	).Reshape(1, 1, 2, 2)
+
+	y := F.MaxPool2d[float64]([]int{2}, F.PoolOpts{Stride: []int{1}, Padding: []int{1}})(x)
+	fmt.Println(y)
+
+	// Output:
+	// variable[1 1 3 3]([-1 -1 -2 -1 -1 -2 -3 -3 -4])
+}
+
+func ExampleAvgPool2d() {
+	x := variable.New(
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+	).Reshape(1, 1, 2, 4)
+
+	y := F.AvgPool2d[float64]([]int{2})(x)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+
+	// Output:
+	// variable[1 1 1 2]([3.5 5.5])
+	// variable[1 1 2 4]([0.25 0.25 0.25 0.25 0.25 0.25 0.25 0.25])
+}
+
+func ExampleAdaptiveAvgPool2d() {
+	x := variable.New(
+		1, 2, 3,
+		4, 5, 6,
+	).Reshape(1, 1, 2, 3)
+
+	// the windows of the width are [0, 2) and [1, 3)
+	y := F.AdaptiveAvgPool2d[float64]([]int{1, 2})(x)
+	fmt.Println(y)
+
+	// Output:
+	// variable[1 1 1 2]([3 4])
+}
+
+func ExampleGlobalAvgPool() {
+	x := variable.New(
+		1, 2,
+		3, 4,
+
+		5, 6,
+		7, 8,
+	).Reshape(1, 2, 2, 2)
+
+	y := F.GlobalAvgPool(x)
+	fmt.Println(y)
+
+	// Output:
+	// variable[1 2]([2.5 6.5])
+}
+
+func TestMaxPool2d(t *testing.T) {
+	cases := []struct {
+		x      []int
+		kernel []int
+		opts   F.PoolOpts
+	}{
+		{[]int{2, 3, 4, 4}, []int{2}, F.PoolOpts{}},
+		{[]int{1, 2, 5, 7}, []int{3, 2}, F.PoolOpts{}},
+		{[]int{1, 2, 5, 5}, []int{3}, F.PoolOpts{Stride: []int{1}, Padding: []int{1}}},
+		{[]int{2, 1, 6, 5}, []int{2, 3}, F.PoolOpts{Stride: []int{2, 1}, Padding: []int{1, 0}}},
+	}
+
+	for _, c := range cases {
+		x := variable.Randn(c.x, rand.Const(1))
+
+		got := F.MaxPool2d[float64](c.kernel, c.opts)(x)
+		want := pool2d(x.Data, c.kernel, c.opts, math.Inf(-1), math.Max, 1)
+		if !tensor.SliceEqual(got.Shape(), want.Shape) {
+			t.Fatalf("shape=%v, want=%v", got.Shape(), want.Shape)
+		}
+
+		if !tensor.IsCloseAll(got.Data, want, 1e-12, 1e-12) {
+			t.Errorf("got=%v, want=%v", got.Data.Data, want.Data)
+		}
+
+		// gradients
+		f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.MaxPool2d[float64](c.kernel, c.opts)(x...)
+		}
+
+		gradcheck(t, f, x)
+	}
+}
+
+func TestAvgPool2d(t *testing.T) {
+	cases := []struct {
+		x      []int
+		kernel []int
+		opts   F.PoolOpts
+	}{
+		{[]int{2, 3, 4, 4}, []int{2}, F.PoolOpts{}},
+		{[]int{1, 2, 5, 7}, []int{3, 2}, F.PoolOpts{}},
+		{[]int{1, 2, 5, 5}, []int{3}, F.PoolOpts{Stride: []int{1}, Padding: []int{1}}},
+		{[]int{2, 1, 6, 5}, []int{2, 3}, F.PoolOpts{Stride: []int{2, 1}, Padding: []int{1, 0}}},
+	}
+
+	for _, c := range cases {
+		x := variable.Randn(c.x, rand.Const(1))
+
+		got := F.AvgPool2d[float64](c.kernel, c.opts)(x)
+		k := pair(c.kernel, 1)
+		want := pool2d(x.Data, c.kernel, c.opts, 0, func(a, b float64) float64 { return a + b }, 1/float64(k[0]*k[1]))
+		if !tensor.SliceEqual(got.Shape(), want.Shape) {
+			t.Fatalf("shape=%v, want=%v", got.Shape(), want.Shape)
+		}
+
+		if !tensor.IsCloseAll(got.Data, want, 1e-12, 1e-12) {
+			t.Errorf("got=%v, want=%v", got.Data.Data, want.Data)
+		}
+
+		// gradients
+		f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.AvgPool2d[float64](c.kernel, c.opts)(x...)
+		}
+
+		gradcheck(t, f, x)
+	}
+}
+
+func TestAdaptiveAvgPool2d(t *testing.T) {
+	cases := []struct {
+		x    []int
+		size []int
+	}{
+		{[]int{2, 3, 4, 4}, []int{2}},
+		{[]int{1, 2, 5, 7}, []int{3, 4}},
+		{[]int{1, 1, 3, 3}, []int{5}},
+		{[]int{1, 2, 6, 5}, []int{1}},
+	}
+
+	for _, c := range cases {
+		x := variable.Randn(c.x, rand.Const(1))
+
+		got := F.AdaptiveAvgPool2d[float64](c.size)(x)
+		size := pair(c.size, 1)
+		want := tensor.Zeros[float64](c.x[0], c.x[1], size[0], size[1])
+		for n := range c.x[0] {
+			for ch := range c.x[1] {
+				for i := range size[0] {
+					for j := range size[1] {
+						h0, h1 := i*c.x[2]/size[0], int(math.Ceil(float64((i+1)*c.x[2])/float64(size[0])))
+						w0, w1 := j*c.x[3]/size[1], int(math.Ceil(float64((j+1)*c.x[3])/float64(size[1])))
+
+						var sum float64
+						for r := h0; r < h1; r++ {
+							for q := w0; q < w1; q++ {
+								sum += x.Data.At(n, ch, r, q)
+							}
+						}
+
+						want.Set([]int{n, ch, i, j}, sum/float64((h1-h0)*(w1-w0)))
+					}
+				}
+			}
+		}
+
+		if !tensor.IsCloseAll(got.Data, want, 1e-12, 1e-12) {
+			t.Errorf("got=%v, want=%v", got.Data.Data, want.Data)
+		}
+
+		// gradients
+		f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.AdaptiveAvgPool2d[float64](c.size)(x...)
+		}
+
+		gradcheck(t, f, x)
+	}
+
+	// the global average pooling is the adaptive average pooling to 1x1
+	x := variable.Randn([]int{2, 3, 4, 5}, rand.Const(1))
+	got := F.GlobalAvgPool(x)
+	want := F.AdaptiveAvgPool2d[float64]([]int{1})(x)
+	if !tensor.IsCloseAll(got.Data, tensor.Reshape(want.Data, 2, 3), 1e-12, 1e-12) {
+		t.Errorf("got=%v, want=%v", got.Data.Data, want.Data.Data)
+	}
+}
+
+func TestMaxPool2d_double(t *testing.T) {
+	x := variable.Randn([]int{1, 2, 4, 4}, rand.Const(1))
+	w := variable.Randn([]int{2, 2, 3, 3}, rand.Const(2))
+
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		y := F.Conv2d[float64](F.ConvOpts{Padding: []int{1}})(x...)
+		return F.Sum[float64]()(F.MaxPool2d[float64]([]int{2}, F.PoolOpts{Stride: []int{1}})(F.Tanh(y)))
+	}
+
+	// the gradient of the squared norm of the gradient with respect to x
+	g := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		gx := grad.Grad(f)(x...)[0]
+		return F.Sum[float64]()(F.Square(gx))
+	}
+
+	gradcheck(t, g, x, w)
+}
+
+func TestPool_panic(t *testing.T) {
+	cases := []struct {
+		name string
+		f    func()
+		want string
+	}{
+		{"ndim", func() {
+			F.MaxPool2d[float64]([]int{2})(variable.Zeros[float64](1, 1, 4))
+		}, "shape [1 1 4] does not have 2 spatial axes"},
+		{"padding", func() {
+			F.MaxPool2d[float64]([]int{2}, F.PoolOpts{Padding: []int{2}})(variable.Zeros[float64](1, 1, 4, 4))
+		}, "padding [2 2] must be at most half of kernel [2 2]"},
+		{"adaptive", func() {
+			F.AdaptiveAvgPool2d[float64]([]int{2})(variable.Zeros[float64](1, 4, 4))
+		}, "shape [1 4 4] does not have 2 spatial axes"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != c.want {
+					t.Errorf("got=%v, want=%v", r, c.want)
+				}
+			}()
+
+			c.f()
+			t.Fail()
+		})
+	}
+}
+
+// pool2d is a reference implementation of the pooling with loops.
+// The padded elements are skipped, and the reduction of every window is scaled.
+func pool2d(x *tensor.Tensor[float64], kernel []int, opts F.PoolOpts, init float64, f func(a, b float64) float64, scale float64) *tensor.Tensor[float64] {
+	k := pair(kernel, 1)
+	s, p := pair(opts.Stride, 0), pair(opts.Padding, 0)
+	if len(opts.Stride) == 0 {
+		s = k
+	}
+
+	n, c, h, w := x.Shape[0], x.Shape[1], x.Shape[2], x.Shape[3]
+	oh := (h+2*p[0]-k[0])/s[0] + 1
+	ow := (w+2*p[1]-k[1])/s[1] + 1
+
+	y := tensor.Zeros[float64](n, c, oh, ow)
+	for bi := range n {
+		for ch := range c {
+			for i := range oh {
+				for j := range ow {
+					acc := init
+					for u := range k[0] {
+						for v := range k[1] {
+							r, q := i*s[0]-p[0]+u, j*s[1]-p[1]+v
+							if r < 0 || r >= h || q < 0 || q >= w {
+								continue
+							}
+
+							acc = f(acc, x.At(bi, ch, r, q))
+						}
+					}
+
+					y.Set([]int{bi, ch, i, j}, acc*scale)
+				}
+			}
+		}
+	}
+
+	return y
+}
@@ -1,40 +1,61 @@
 package function
 
 import (
-	"math"
-
 	"github.com/itsubaki/autograd/tensor"
 	"github.com/itsubaki/autograd/variable"
 )
 
 // ReLU applies the rectified linear unit function.
-func ReLU(x ...*variable.Variable) *variable.Variable {
-	return (&variable.Function{
-		Forwarder: &ReLUT{},
+func ReLU[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return (&variable.Function[T]{
+		Forwarder: &ReLUT[T]{},
 	}).First(x...)
 }
 
 // ReLUT is the differentiable ReLU operation.
-type ReLUT struct {
-	x *variable.Variable
+type ReLUT[T tensor.Float] struct {
+	x *variable.Variable[T]
 }
 
-func (f *ReLUT) Forward(x ...*variable.Variable) []*variable.Variable {
+func (f *ReLUT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
 	f.x = x[0]
 
 	y := tensor.F(x[0].Data, maximum)
-	return []*variable.Variable{
+	return []*variable.Variable[T]{
 		variable.From(y),
 	}
 }
 
-func (f *ReLUT) Backward(gy ...*variable.Variable) []*variable.Variable {
+func (f *ReLUT[T]) Backward(gy ...*variable.Variable[T]) []*variable.Variable[T] {
 	mask := tensor.Mask(f.x.Data, relu)
-	return []*variable.Variable{
+	return []*variable.Variable[T]{
 		Mul(gy[0], variable.From(mask)), // gy * mask
 	}
 }
 
-func maximum(v float64) float64 { return math.Max(v, 0.0) }
+func (f *ReLUT[T]) JVP(x, y, tx []*variable.Variable[T]) []*variable.Variable[T] {
+	mask := tensor.Mask(x[0].Data, relu)
+	return []*variable.Variable[T]{
+		Mul(tx[0], variable.From(mask)), // tx * mask
+	}
+}
+
+func (f *ReLUT[T]) Batch(x []*variable.Variable[T], batched []bool) []*variable.Variable[T] {
+	return []*variable.Variable[T]{
+		ReLU(x[0]),
+	}
+}
+
+func (f *ReLUT[T]) Scalar() (func(x T) T, func(x T) T) {
+	return maximum[T], func(x T) T {
+		if relu(x) {
+			return 1
+		}
+
+		return 0
+	}
+}
+
+func maximum[T tensor.Float](v T) T { return max(v, 0.0) }
 
-func relu(v float64) bool { return v > 0 }
+func relu[T tensor.Float](v T) bool { return v > 0 }
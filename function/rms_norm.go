@@ -0,0 +1,109 @@
+package function
+
+import (
+	"math"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+// RMSNorm returns a function that divides x[0] by its root mean square along the given axes,
+// and scales the result by x[1] if it exists. x[1] must be broadcastable to x[0].
+// If no axis is given, it normalizes along the last axis.
+func RMSNorm[T tensor.Float](axes []int, eps T) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return (&variable.Function[T]{
+		Forwarder: &RMSNormT[T]{
+			Axes: normAxes(axes),
+			Eps:  eps,
+		},
+	}).First
+}
+
+// RMSNormT is the differentiable root mean square normalization operation.
+// Forward saves the normalized x and the inverse root mean square of every sample along Axes,
+// which Backward reuses without creating the graph. No mean is saved, because x is not centered.
+type RMSNormT[T tensor.Float] struct {
+	Axes         []int
+	Eps          T
+	x            []*variable.Variable[T]
+	axes         []int
+	xhat, invrms *tensor.Tensor[T]
+}
+
+func (f *RMSNormT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
+	f.x = x
+	f.axes = nonneg(f.Axes, x[0].NumDims())
+	f.invrms = tensor.Zeros[T](tensor.KeepDims(x[0].Shape(), f.axes)...)
+
+	xr, restore := rows(x[0].Data, f.axes)
+	xhat, m := tensor.ZeroLike(xr), xr.Shape[1]
+	for r := range xr.Shape[0] {
+		row, out := xr.Data[r*m:(r+1)*m], xhat.Data[r*m:(r+1)*m]
+
+		var ms T
+		for _, v := range row {
+			ms += v * v
+		}
+		ms /= T(m)
+
+		// xhat = x / sqrt(mean(x^2) + eps)
+		invrms := 1 / T(math.Sqrt(float64(ms+f.Eps)))
+		for j, v := range row {
+			out[j] = v * invrms
+		}
+
+		f.invrms.Data[r] = invrms
+	}
+
+	f.xhat = restore(xhat)
+	return []*variable.Variable[T]{
+		variable.From(affine(f.xhat, x[:min(len(x), 2)])),
+	}
+}
+
+func (f *RMSNormT[T]) Backward(gy ...*variable.Variable[T]) []*variable.Variable[T] {
+	x := f.x[:min(len(f.x), 2)]
+	gxhat := scale(gy[0], x)
+	if !variable.SessionOf(gy[0]).EnableBackprop {
+		gr, restore := rows(gxhat.Data, f.axes)
+		xr, _ := rows(f.xhat, f.axes)
+		gx, m := tensor.ZeroLike(gr), gr.Shape[1]
+		for r := range gr.Shape[0] {
+			g, xh, out := gr.Data[r*m:(r+1)*m], xr.Data[r*m:(r+1)*m], gx.Data[r*m:(r+1)*m]
+
+			var mgx T
+			for j := range g {
+				mgx += g[j] * xh[j]
+			}
+			mgx /= T(m)
+
+			// invrms * (gxhat - xhat * mean(gxhat * xhat))
+			for j := range g {
+				out[j] = f.invrms.Data[r] * (g[j] - xh[j]*mgx)
+			}
+		}
+
+		return append([]*variable.Variable[T]{variable.From(restore(gx))}, gaffine(gy[0], variable.From(f.xhat), x)...)
+	}
+
+	// invrms * (gxhat - xhat * mean(gxhat * xhat))
+	xhat, invrms := f.normalize(f.x[0])
+	gx := Mul(invrms, Sub(gxhat, Mul(xhat, keepMean(Mul(gxhat, xhat), f.axes))))
+	return append([]*variable.Variable[T]{gx}, gaffine(gy[0], xhat, x)...)
+}
+
+func (f *RMSNormT[T]) JVP(x, y, tx []*variable.Variable[T]) []*variable.Variable[T] {
+	xhat, invrms := variable.From(f.xhat), variable.From(f.invrms)
+
+	// invrms * (tx - xhat * mean(tx * xhat))
+	txhat := Mul(invrms, Sub(tx[0], Mul(xhat, keepMean(Mul(tx[0], xhat), f.axes))))
+	return []*variable.Variable[T]{
+		taffine(txhat, xhat, x[:min(len(x), 2)], tx),
+	}
+}
+
+// normalize returns the normalized x and the inverse of its root mean square as differentiable functions of x.
+func (f *RMSNormT[T]) normalize(x *variable.Variable[T]) (*variable.Variable[T], *variable.Variable[T]) {
+	invrms := Pow[T](-0.5)(AddC(f.Eps, keepMean(Square(x), f.axes))) // 1 / sqrt(mean(x^2) + eps)
+	return Mul(x, invrms), invrms
+}
@@ -0,0 +1,100 @@
+package function_test
+
+import (
+	"fmt"
+	"testing"
+
+	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/grad"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleRMSNorm() {
+	x := variable.New(
+		3, 4,
+		-6, 8,
+	).Reshape(2, 2)
+	gamma := variable.New(1, 2)
+
+	y := F.RMSNorm[float64](nil, 0)(x, gamma)
+	y.Backward()
+
+	fmt.Printf("%.4f\n", y.Data.Data)
+	fmt.Printf("%.4f\n", gamma.Grad.Data.Data)
+
+	// Output:
+	// [0.8485 2.2627 -0.8485 2.2627]
+	// [0.0000 2.2627]
+}
+
+func TestRMSNorm(t *testing.T) {
+	cases := []struct {
+		x, gamma []int
+		axes     []int
+	}{
+		{[]int{3, 4}, nil, nil},
+		{[]int{3, 4}, []int{4}, []int{-1}},
+		{[]int{2, 3, 4}, []int{3, 4}, []int{1, 2}},
+		{[]int{2, 3, 4}, []int{3, 1}, []int{1}},
+	}
+
+	for _, c := range cases {
+		x := variable.Randn(c.x, rand.Const(1))
+		xs := []*variable.Variable[float64]{x}
+		if c.gamma != nil {
+			xs = append(xs, variable.Randn(c.gamma, rand.Const(2)))
+		}
+
+		// the normalization with the primitive operations
+		axes := c.axes
+		if len(axes) == 0 {
+			axes = []int{-1}
+		}
+
+		kd := tensor.KeepDims(c.x, axes)
+		want := F.Div(x, F.Pow(0.5)(F.AddC(1e-5, F.Reshape[float64](kd...)(F.Mean[float64](axes...)(F.Square(x))))))
+		if c.gamma != nil {
+			want = F.Mul(want, xs[1])
+		}
+
+		got := F.RMSNorm[float64](c.axes, 1e-5)(xs...)
+		if !tensor.IsCloseAll(got.Data, want.Data, 1e-10, 1e-10) {
+			t.Errorf("got=%v, want=%v", got.Data.Data, want.Data.Data)
+		}
+
+		// gradients
+		f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.RMSNorm[float64](c.axes, 1e-5)(x...)
+		}
+
+		gradcheck(t, f, xs...)
+
+		// the gradients with the graph for higher-order derivatives
+		for i, g := range grad.Grad(f)(xs...) {
+			if !tensor.IsCloseAll(g.Data, xs[i].Grad.Data, 1e-10, 1e-10) {
+				t.Errorf("x[%d]: got=%v, want=%v", i, g.Data.Data, xs[i].Grad.Data.Data)
+			}
+		}
+	}
+}
+
+func TestRMSNorm_double(t *testing.T) {
+	x := variable.Randn([]int{3, 4}, rand.Const(1))
+	gamma := variable.Randn([]int{4}, rand.Const(2))
+	w := variable.Randn([]int{3, 4}, rand.Const(4))
+
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		y := F.RMSNorm[float64](nil, 1e-5)(x...)
+		return F.Sum[float64]()(F.Mul(w, F.Tanh(y)))
+	}
+
+	// the gradient of the squared norm of the gradient with respect to x
+	g := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		gx := grad.Grad(f)(x...)[0]
+		return F.Sum[float64]()(F.Square(gx))
+	}
+
+	gradcheck(t, g, x, gamma)
+}
@@ -1,34 +1,56 @@
 package function
 
 import (
+	"math"
+
 	"github.com/itsubaki/autograd/tensor"
 	"github.com/itsubaki/autograd/variable"
 )
 
 // Sigmoid applies the sigmoid function.
-func Sigmoid(x ...*variable.Variable) *variable.Variable {
-	return (&variable.Function{
-		Forwarder: &SigmoidT{},
+func Sigmoid[T tensor.Float](x ...*variable.Variable[T]) *variable.Variable[T] {
+	return (&variable.Function[T]{
+		Forwarder: &SigmoidT[T]{},
 	}).First(x...)
 }
 
 // SigmoidT is the differentiable sigmoid operation.
-type SigmoidT struct {
-	y *variable.Variable
+type SigmoidT[T tensor.Float] struct {
+	y *variable.Variable[T]
 }
 
-func (f *SigmoidT) Forward(x ...*variable.Variable) []*variable.Variable {
+func (f *SigmoidT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
 	tanh := tensor.Tanh(tensor.MulC(0.5, x[0].Data)) // tanh(0.5 * x)
 	y := tensor.AddC(0.5, tensor.MulC(0.5, tanh))    // 0.5 + 0.5 * tanh(0.5 * x)
 
 	f.y = variable.From(y)
-	return []*variable.Variable{
+	return []*variable.Variable[T]{
 		f.y,
 	}
 }
 
-func (f *SigmoidT) Backward(gy ...*variable.Variable) []*variable.Variable {
-	return []*variable.Variable{
+func (f *SigmoidT[T]) Backward(gy ...*variable.Variable[T]) []*variable.Variable[T] {
+	return []*variable.Variable[T]{
 		Mul(gy[0], Mul(f.y, SubC(1.0, f.y))), // gy * y * (1 - y)
 	}
 }
+
+func (f *SigmoidT[T]) JVP(x, y, tx []*variable.Variable[T]) []*variable.Variable[T] {
+	return []*variable.Variable[T]{
+		Mul(tx[0], Mul(y[0], SubC(1.0, y[0]))), // tx * y * (1 - y)
+	}
+}
+
+func (f *SigmoidT[T]) Batch(x []*variable.Variable[T], batched []bool) []*variable.Variable[T] {
+	return []*variable.Variable[T]{
+		Sigmoid(x[0]),
+	}
+}
+
+func (f *SigmoidT[T]) Scalar() (func(x T) T, func(x T) T) {
+	y := func(x T) T { return 0.5 + 0.5*T(math.Tanh(0.5*float64(x))) }
+	return y, func(x T) T {
+		s := y(x)
+		return s * (1.0 - s)
+	}
+}
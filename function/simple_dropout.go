@@ -9,18 +9,18 @@ import (
 
 // DropoutSimple returns a function that applies dropout during training
 // by composing primitive operations such as Rand, Mask, Mul, and MulC.
-func DropoutSimple(ratio float64, s ...randv2.Source) func(x ...*variable.Variable) *variable.Variable {
-	return func(x ...*variable.Variable) *variable.Variable {
-		if !variable.Config.Train {
+func DropoutSimple[T tensor.Float](ratio T, s ...randv2.Source) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return func(x ...*variable.Variable[T]) *variable.Variable[T] {
+		if !variable.SessionOf(x[0]).Train {
 			return x[0]
 		}
 
-		rand := tensor.Rand(x[0].Shape(), s...)
+		rand := tensor.Convert[T](tensor.Rand(x[0].Shape(), s...))
 		mask := tensor.Mask(rand, mask(ratio))
 		return MulC(1.0/(1.0-ratio), Mul(x[0], variable.From(mask))) // y = x * mask / (1 - ratio)
 	}
 }
 
-func mask(ratio float64) func(v float64) bool {
-	return func(v float64) bool { return v > ratio }
+func mask[T tensor.Float](ratio T) func(v T) bool {
+	return func(v T) bool { return v > ratio }
 }
@@ -59,3 +59,14 @@ func ExampleDropoutSimple_double() {
 	// variable[5]([2 2 0 0 0])
 	// <nil>
 }
+
+func ExampleDropoutSimple_session() {
+	s := &variable.Session{EnableBackprop: false, Train: false}
+	x := variable.New(1, 1, 1, 1, 1).Bind(s)
+
+	y := F.DropoutSimple(0.5)(x)
+	fmt.Println(y)
+
+	// Output:
+	// variable[5]([1 1 1 1 1])
+}
@@ -1,10 +1,13 @@
 package function
 
-import "github.com/itsubaki/autograd/variable"
+import (
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
 
 // LinearSimple applies a linear transformation by composing
 // primitive operations such as MatMul and Add.
-func LinearSimple(x, w *variable.Variable, b ...*variable.Variable) *variable.Variable {
+func LinearSimple[T tensor.Float](x, w *variable.Variable[T], b ...*variable.Variable[T]) *variable.Variable[T] {
 	t := MatMul(x, w)
 	if len(b) == 0 {
 		return t
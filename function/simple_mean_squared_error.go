@@ -1,10 +1,13 @@
 package function
 
-import "github.com/itsubaki/autograd/variable"
+import (
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
 
 // MeanSquaredErrorSimple computes the mean squared error by composing
 // primitive operations such as Sub, Mul, and Sum.
-func MeanSquaredErrorSimple(x0, x1 *variable.Variable) *variable.Variable {
-	diff := Sub(x0, x1)                                           // x0 - x1
-	return MulC(1.0/float64(diff.Size()), Sum()(Mul(diff, diff))) // (1/N) * sum((x0 - x1)^2)
+func MeanSquaredErrorSimple[T tensor.Float](x0, x1 *variable.Variable[T]) *variable.Variable[T] {
+	diff := Sub(x0, x1)                                        // x0 - x1
+	return MulC(1.0/T(diff.Size()), Sum[T]()(Mul(diff, diff))) // (1/N) * sum((x0 - x1)^2)
 }
@@ -1,9 +1,12 @@
 package function
 
-import "github.com/itsubaki/autograd/variable"
+import (
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
 
 // SigmoidSimple applies the sigmoid function by composing
 // primitive operations such as Neg, Exp, AddC, and DivC.
-func SigmoidSimple(x *variable.Variable) *variable.Variable {
+func SigmoidSimple[T tensor.Float](x *variable.Variable[T]) *variable.Variable[T] {
 	return DivC(1.0, AddC(1.0, Exp(Neg(x)))) // y = 1 / (1 + exp(-x))
 }
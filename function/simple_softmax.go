@@ -7,10 +7,10 @@ import (
 
 // SoftmaxSimple applies the softmax function along the given axis
 // by composing primitive operations such as Exp, SumTo, and Div.
-func SoftmaxSimple(x *variable.Variable, axis int) *variable.Variable {
+func SoftmaxSimple[T tensor.Float](x *variable.Variable[T], axis int) *variable.Variable[T] {
 	shape := tensor.KeepDims(x.Shape(), []int{axis})
 
 	y := Exp(x)
-	sumy := SumTo(shape...)(y)
+	sumy := SumTo[T](shape...)(y)
 	return Div(y, sumy)
 }
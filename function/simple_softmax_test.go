@@ -42,9 +42,9 @@ func ExampleSoftmaxSimple_axis1n() {
 }
 
 func Example_softmax1d() {
-	softmax1d := func(x *variable.Variable) *variable.Variable {
+	softmax1d := func(x *variable.Variable[float64]) *variable.Variable[float64] {
 		y := F.Exp(x)
-		sumy := F.Sum()(y)
+		sumy := F.Sum[float64]()(y)
 		return F.Div(y, sumy)
 	}
 
@@ -6,39 +6,61 @@ import (
 )
 
 // Softmax returns a function that applies softmax along the given axis.
-func Softmax(axis int) func(x ...*variable.Variable) *variable.Variable {
-	return (&variable.Function{
-		Forwarder: &SoftmaxT{
+func Softmax[T tensor.Float](axis int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return (&variable.Function[T]{
+		Forwarder: &SoftmaxT[T]{
 			Axis: axis,
 		},
 	}).First
 }
 
 // SoftmaxT is the differentiable softmax operation.
-type SoftmaxT struct {
+type SoftmaxT[T tensor.Float] struct {
 	Axis int
-	y    *variable.Variable
+	y    *variable.Variable[T]
 }
 
-func (f *SoftmaxT) Forward(x ...*variable.Variable) []*variable.Variable {
+func (f *SoftmaxT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
 	max1 := tensor.Unsqueeze(tensor.Max(x[0].Data, f.Axis), f.Axis) // max1 = max(x, axis=1)
 	expy := tensor.Exp(tensor.Sub(x[0].Data, max1))                 // expy = exp(x - max1)
 	sum1 := tensor.Unsqueeze(tensor.Sum(expy, f.Axis), f.Axis)      // sum1 = sum(expy, axis=1)
 	div := tensor.Div(expy, sum1)                                   // y = expy / sum1
 
 	f.y = variable.From(div)
-	return []*variable.Variable{
+	return []*variable.Variable[T]{
 		f.y,
 	}
 }
 
-func (f *SoftmaxT) Backward(gy ...*variable.Variable) []*variable.Variable {
+func (f *SoftmaxT[T]) Backward(gy ...*variable.Variable[T]) []*variable.Variable[T] {
 	shape := tensor.KeepDims(f.y.Shape(), []int{f.Axis})
 
-	gyy := Mul(gy[0], f.y)        // gyy = gy * y
-	sum := SumTo(shape...)(gyy)   // sum = sum(gy, axis=1)
-	gx := Sub(gyy, Mul(f.y, sum)) // gyy - y * sum
-	return []*variable.Variable{
+	gyy := Mul(gy[0], f.y)         // gyy = gy * y
+	sum := SumTo[T](shape...)(gyy) // sum = sum(gy, axis=1)
+	gx := Sub(gyy, Mul(f.y, sum))  // gyy - y * sum
+	return []*variable.Variable[T]{
 		gx,
 	}
 }
+
+func (f *SoftmaxT[T]) JVP(x, y, tx []*variable.Variable[T]) []*variable.Variable[T] {
+	shape := tensor.KeepDims(y[0].Shape(), []int{f.Axis})
+
+	ytx := Mul(y[0], tx[0])        // ytx = y * tx
+	sum := SumTo[T](shape...)(ytx) // sum = sum(ytx, axis=1)
+	ty := Sub(ytx, Mul(y[0], sum)) // ytx - y * sum
+	return []*variable.Variable[T]{
+		ty,
+	}
+}
+
+func (f *SoftmaxT[T]) Batch(x []*variable.Variable[T], batched []bool) []*variable.Variable[T] {
+	axis := f.Axis
+	if axis < 0 {
+		axis += x[0].NumDims() - 1
+	}
+
+	return []*variable.Variable[T]{
+		Softmax[T](axis + 1)(x[0]),
+	}
+}
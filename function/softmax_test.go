@@ -13,7 +13,7 @@ func ExampleSoftmax() {
 		4, 4, 8,
 	).Reshape(2, 3)
 
-	y := F.Softmax(1)(x)
+	y := F.Softmax[float64](1)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -30,7 +30,7 @@ func ExampleSoftmax_axis0() {
 		4, 4, 8,
 	).Reshape(2, 3)
 
-	y := F.Softmax(0)(x)
+	y := F.Softmax[float64](0)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -47,7 +47,7 @@ func ExampleSoftmax_axis1n() {
 		4, 4, 8,
 	).Reshape(2, 3)
 
-	y := F.Softmax(-1)(x)
+	y := F.Softmax[float64](-1)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -64,7 +64,7 @@ func ExampleSoftmax_double() {
 		4, 4, 8,
 	).Reshape(2, 3)
 
-	y := F.Softmax(1)(x)
+	y := F.Softmax[float64](1)(x)
 	y.Backward(variable.Opts{CreateGraph: true})
 	fmt.Println(y)
 	fmt.Println(x.Grad)
@@ -0,0 +1,79 @@
+// Package grad provides functional transforms that return the derivatives of a function of variables.
+// Unlike Variable.Backward, the transforms do not modify the Grad fields of the variables.
+// The derivatives are computed with the graph created, so the transforms can be composed for higher-order derivatives.
+package grad
+
+import (
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+// Func is a differentiable function of variables.
+type Func[T tensor.Float] func(x ...*variable.Variable[T]) *variable.Variable[T]
+
+// Grad returns a function that computes the gradients of the sum of f(x...) with respect to each x.
+// If f does not depend on an x, the gradient is zero.
+func Grad[T tensor.Float](f Func[T]) func(x ...*variable.Variable[T]) []*variable.Variable[T] {
+	return func(x ...*variable.Variable[T]) []*variable.Variable[T] {
+		_, gxs := ValueAndGrad(f)(x...)
+		return gxs
+	}
+}
+
+// ValueAndGrad returns a function that computes f(x...) and the gradients of its sum with respect to each x.
+func ValueAndGrad[T tensor.Float](f Func[T]) func(x ...*variable.Variable[T]) (*variable.Variable[T], []*variable.Variable[T]) {
+	return func(x ...*variable.Variable[T]) (*variable.Variable[T], []*variable.Variable[T]) {
+		y := f(x...)
+		return y, variable.VJP(y, variable.OneLike(y), x...)
+	}
+}
+
+// Jacobian returns a function that computes the Jacobian of f(x...) with respect to each x.
+// The Jacobian with respect to x[i] has the shape of f(x...) followed by the shape of x[i].
+func Jacobian[T tensor.Float](f Func[T]) func(x ...*variable.Variable[T]) []*variable.Variable[T] {
+	return func(x ...*variable.Variable[T]) []*variable.Variable[T] {
+		y := f(x...)
+
+		rows := make([][]*variable.Variable[T], len(x))
+		for k := range y.Size() {
+			// the k-th row is the gradient of the k-th element of y
+			e := tensor.ZeroLike(y.Data)
+			e.Data[k] = 1
+
+			gxs := variable.VJP(y, variable.From(e), x...)
+			for i := range x {
+				rows[i] = append(rows[i], variable.Reshape[T](1, x[i].Size())(gxs[i]))
+			}
+		}
+
+		jac := make([]*variable.Variable[T], len(x))
+		for i := range x {
+			shape := append(y.Shape(), x[i].Shape()...)
+			if len(rows[i]) == 0 {
+				jac[i] = variable.Zeros[T](shape...)
+				continue
+			}
+
+			jac[i] = variable.Reshape[T](shape...)(variable.Concat[T](0)(rows[i]...))
+		}
+
+		return jac
+	}
+}
+
+// Hessian returns a function that computes the Hessian of the sum of f(x...) with respect to each pair of x.
+// The Hessian with respect to x[i] and x[j] has the shape of x[i] followed by the shape of x[j].
+func Hessian[T tensor.Float](f Func[T]) func(x ...*variable.Variable[T]) [][]*variable.Variable[T] {
+	return func(x ...*variable.Variable[T]) [][]*variable.Variable[T] {
+		hess := make([][]*variable.Variable[T], len(x))
+		for i := range x {
+			gi := func(x ...*variable.Variable[T]) *variable.Variable[T] {
+				return Grad(f)(x...)[i]
+			}
+
+			hess[i] = Jacobian(gi)(x...)
+		}
+
+		return hess
+	}
+}
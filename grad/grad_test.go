@@ -0,0 +1,267 @@
+package grad_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/grad"
+	"github.com/itsubaki/autograd/numerical"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleGrad() {
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Sum[float64]()(F.Pow(3.0)(x[0]))
+	}
+
+	x := variable.New(1, 2, 3)
+	gx := grad.Grad(f)(x)
+
+	fmt.Println(gx[0])
+	fmt.Println(x.Grad)
+
+	// Output:
+	// variable[3]([3 12 27])
+	// <nil>
+}
+
+func ExampleGrad_higher() {
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Sin(x[0])
+	}
+
+	// the n-th derivative of sin
+	df := grad.Func[float64](f)
+	for range 4 {
+		g := df
+		df = func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return grad.Grad(g)(x...)[0]
+		}
+	}
+
+	x := variable.New(1.0)
+	fmt.Println(df(x))
+	fmt.Println(F.Sin(x))
+
+	// Output:
+	// variable(0.8414709848078965)
+	// variable(0.8414709848078965)
+}
+
+func ExampleGrad_multiple() {
+	// 0.26(x^2 + y^2) - 0.48xy
+	matyas := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		z0 := F.MulC(0.26, F.Add(F.Pow(2.0)(x[0]), F.Pow(2.0)(x[1])))
+		z1 := F.MulC(0.48, F.Mul(x[0], x[1]))
+		return F.Sub(z0, z1)
+	}
+
+	gxs := grad.Grad(matyas)(variable.New(1.0), variable.New(1.0))
+	fmt.Printf("%.4f %.4f\n", gxs[0].At(), gxs[1].At())
+
+	// Output:
+	// 0.0400 0.0400
+}
+
+func ExampleValueAndGrad() {
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Mul(x[0], F.Exp(x[1]))
+	}
+
+	y, gxs := grad.ValueAndGrad(f)(variable.New(2.0), variable.New(0.0))
+	fmt.Println(y)
+	fmt.Println(gxs[0], gxs[1])
+
+	// Output:
+	// variable(2)
+	// variable(1) variable(2)
+}
+
+func ExampleJacobian() {
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.MatMul(x[0], x[1])
+	}
+
+	a := variable.New(1, 2, 3, 4).Reshape(2, 2)
+	b := variable.New(5, 6).Reshape(2, 1)
+	jac := grad.Jacobian(f)(a, b)
+
+	fmt.Println(jac[0].Shape())
+	fmt.Println(jac[1].Shape())
+	fmt.Println(tensor.Reshape(jac[1].Data, 2, 2).Data)
+
+	// Output:
+	// [2 1 2 2]
+	// [2 1 2 1]
+	// [1 2 3 4]
+}
+
+func ExampleHessian() {
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Mul(F.Square(x[0]), x[1])
+	}
+
+	hess := grad.Hessian(f)(variable.New(2.0), variable.New(3.0))
+	for _, h := range hess {
+		fmt.Println(h[0], h[1])
+	}
+
+	// Output:
+	// variable(6) variable(4)
+	// variable(4) variable(0)
+}
+
+func ExampleHessian_quadratic() {
+	// x^T A x
+	a := variable.New(1, 2, 3, 4).Reshape(2, 2)
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Sum[float64]()(F.Mul(x[0], F.MatMul(a, x[0])))
+	}
+
+	hess := grad.Hessian(f)(variable.New(1, 1).Reshape(2, 1))
+	fmt.Println(hess[0][0].Shape())
+	fmt.Println(hess[0][0].Data.Data)
+
+	// Output:
+	// [2 1 2 1]
+	// [2 5 5 8]
+}
+
+func TestGrad(t *testing.T) {
+	cases := []struct {
+		f grad.Func[float64]
+		x []*variable.Variable[float64]
+	}{
+		{
+			f: F.Tanh[float64],
+			x: []*variable.Variable[float64]{variable.New(-1, 0.5, 2)},
+		},
+		{
+			f: F.MatMul[float64],
+			x: []*variable.Variable[float64]{
+				variable.New(1, 2, 3, 4, 5, 6).Reshape(2, 3),
+				variable.New(1, -1, 2, 0.5, -2, 3).Reshape(3, 2),
+			},
+		},
+		{
+			f: func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+				return F.Div(F.Sin(x[0]), F.Add(x[1], x[0]))
+			},
+			x: []*variable.Variable[float64]{
+				variable.New(0.5, 1.5),
+				variable.New(2, 3),
+			},
+		},
+		{
+			// f does not depend on x[1]
+			f: func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+				return F.Exp(x[0])
+			},
+			x: []*variable.Variable[float64]{
+				variable.New(1, 2),
+				variable.New(3, 4, 5),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		got := grad.Grad(c.f)(c.x...)
+		want := numerical.Grad(numerical.Func[float64](c.f), c.x)
+
+		for i := range c.x {
+			if c.x[i].Grad != nil {
+				t.Errorf("x[%d].Grad=%v", i, c.x[i].Grad)
+			}
+
+			if !tensor.IsCloseAll(got[i].Data, want[i].Data, 1e-6) {
+				t.Errorf("got=%v, want=%v", got[i], want[i])
+			}
+		}
+	}
+}
+
+func TestJacobian(t *testing.T) {
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Mul(F.Sin(F.MatMul(x[0], x[1])), x[0])
+	}
+
+	x := []*variable.Variable[float64]{
+		variable.New(1, 2, 3, 4).Reshape(2, 2),
+		variable.New(0.5, -1, 2, 0.25).Reshape(2, 2),
+	}
+	jac := grad.Jacobian(f)(x...)
+
+	// the k-th row of the Jacobian is the gradient of the k-th element of f
+	y := f(x...)
+	for k := range y.Size() {
+		fk := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.GetItem[float64](0, []int{k})(F.Reshape[float64](y.Size())(f(x...)))
+		}
+
+		want := numerical.Grad(fk, x)
+		for i := range x {
+			row := tensor.Slice(tensor.Reshape(jac[i].Data, y.Size(), x[i].Size()), tensor.Index(k))
+			if !tensor.IsCloseAll(row, tensor.Reshape(want[i].Data, x[i].Size()), 1e-6) {
+				t.Errorf("k=%d, i=%d, got=%v, want=%v", k, i, row.Data, want[i].Data.Data)
+			}
+		}
+	}
+}
+
+func TestGrad_checkpoint(t *testing.T) {
+	// x[1] is captured by the checkpointed segment
+	f := func(checkpoint bool) grad.Func[float64] {
+		return func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			g := func(h ...*variable.Variable[float64]) *variable.Variable[float64] {
+				return F.Mul(F.Sin(h[0]), x[1])
+			}
+
+			if checkpoint {
+				return variable.Checkpoint(g, x[0])
+			}
+
+			return g(x[0])
+		}
+	}
+
+	x, w := variable.New(1, 2), variable.New(3, 4)
+	got := grad.Jacobian(f(true))(x, w)
+	want := grad.Jacobian(f(false))(x, w)
+	for i := range want {
+		if !tensor.IsCloseAll(got[i].Data, want[i].Data, 1e-10) {
+			t.Errorf("i=%d, got=%v, want=%v", i, got[i].Data.Data, want[i].Data.Data)
+		}
+	}
+
+	if x.Grad != nil || w.Grad != nil {
+		t.Errorf("x.Grad=%v, w.Grad=%v", x.Grad, w.Grad)
+	}
+}
+
+func TestHessian(t *testing.T) {
+	// rosenbrock
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		y0 := F.Pow(2.0)(F.Sub(x[1], F.Pow(2.0)(x[0])))
+		y1 := F.Pow(2.0)(F.AddC(-1.0, x[0]))
+		return F.Add(F.MulC(100, y0), y1)
+	}
+
+	x0, x1 := 0.5, 2.0
+	hess := grad.Hessian(f)(variable.New(x0), variable.New(x1))
+
+	want := [][]float64{
+		{1200*x0*x0 - 400*x1 + 2, -400 * x0},
+		{-400 * x0, 200},
+	}
+
+	for i := range want {
+		for j := range want[i] {
+			if math.Abs(hess[i][j].At()-want[i][j]) > 1e-9 {
+				t.Errorf("i=%d, j=%d, got=%v, want=%v", i, j, hess[i][j].At(), want[i][j])
+			}
+		}
+	}
+}
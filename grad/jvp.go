@@ -0,0 +1,31 @@
+package grad
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+// Jvp returns f(primals...) and the Jacobian-vector product of f at primals with tangents.
+// The product is computed in forward mode alongside f, so every function used by f must implement variable.JVPer.
+// The output is computed from new variables that share the data of primals,
+// so a backward pass from the output does not reach primals.
+func Jvp[T tensor.Float](f Func[T], primals, tangents []*variable.Variable[T]) (*variable.Variable[T], *variable.Variable[T]) {
+	if len(primals) != len(tangents) {
+		panic(fmt.Sprintf("len(primals)=%d does not match len(tangents)=%d", len(primals), len(tangents)))
+	}
+
+	x := make([]*variable.Variable[T], len(primals))
+	for i := range primals {
+		x[i] = variable.Dual(primals[i], tangents[i])
+	}
+
+	y := f(x...)
+	if t := y.Tangent(); t != nil {
+		return y, t
+	}
+
+	// f does not depend on x
+	return y, variable.ZeroLike(y)
+}
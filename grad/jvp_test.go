@@ -0,0 +1,90 @@
+package grad_test
+
+import (
+	"fmt"
+	"testing"
+
+	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/grad"
+	"github.com/itsubaki/autograd/numerical"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleJvp() {
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Mul(F.Sin(x[0]), x[1])
+	}
+
+	x0, x1 := variable.New(0.0, 1.0), variable.New(2.0, 3.0)
+	y, ty := grad.Jvp(f, []*variable.Variable[float64]{x0, x1}, []*variable.Variable[float64]{variable.New(1, 1), variable.New(0, 0)})
+
+	fmt.Printf("%.4f\n", y.Data.Data)
+	fmt.Printf("%.4f\n", ty.Data.Data)
+
+	// Output:
+	// [0.0000 2.5244]
+	// [2.0000 1.6209]
+}
+
+func ExampleJvp_constant() {
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return variable.New(1, 2)
+	}
+
+	_, ty := grad.Jvp(f, []*variable.Variable[float64]{variable.New(1)}, []*variable.Variable[float64]{variable.New(1)})
+	fmt.Println(ty)
+
+	// Output:
+	// variable[2]([0 0])
+}
+
+func TestJvp(t *testing.T) {
+	// an ODE-like simulation with few inputs and many outputs
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		list := make([]*variable.Variable[float64], 0)
+
+		y := x[0]
+		for range 16 {
+			y = F.Add(y, F.MulC(0.1, F.Mul(x[1], F.Tanh(y))))
+			list = append(list, y)
+		}
+
+		return F.Concat[float64](0)(list...)
+	}
+
+	x := []*variable.Variable[float64]{variable.New(0.5, -0.3), variable.New(0.8)}
+	_, ty := grad.Jvp(f, x, []*variable.Variable[float64]{variable.New(1, 1), variable.New(1)})
+
+	want := numerical.Diff(f, x)
+	if !tensor.IsCloseAll(ty.Data, want.Data, 1e-6, 1e-5) {
+		t.Errorf("got=%v, want=%v", ty, want)
+	}
+
+	// the columns of the Jacobian
+	jac := grad.Jacobian(f)(x...)
+	for i := range x {
+		for j := range x[i].Size() {
+			tangents := []*variable.Variable[float64]{variable.Zeros[float64](2), variable.Zeros[float64](1)}
+			tangents[i].Data.Data[j] = 1
+
+			_, ty := grad.Jvp(f, x, tangents)
+			col := tensor.Slice(jac[i].Data, tensor.All(), tensor.Index(j))
+			if !tensor.IsCloseAll(ty.Data, col) {
+				t.Errorf("i=%d, j=%d, got=%v, want=%v", i, j, ty.Data.Data, tensor.Clone(col).Data)
+			}
+		}
+	}
+}
+
+func TestJvp_len(t *testing.T) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			return
+		}
+
+		t.Fail()
+	}()
+
+	grad.Jvp(F.Sin, []*variable.Variable[float64]{variable.New(1)}, nil)
+}
@@ -0,0 +1,67 @@
+package grad
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+// None is the axis of an input of Vmap that is shared by every example.
+const None = math.MinInt
+
+// Vmap returns a function that applies f to each example of x and stacks the outputs along outAxis.
+// The examples of x[i] are along inAxes[i], or x[i] is shared by every example if inAxes[i] is None.
+// f is applied to every example at once using the batching rules of the functions it calls.
+// The result is differentiable, and Vmap can be composed with Grad, for example to compute per-example gradients
+// with respect to the shared inputs.
+func Vmap[T tensor.Float](f Func[T], inAxes []int, outAxis int) func(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return func(x ...*variable.Variable[T]) *variable.Variable[T] {
+		if len(inAxes) != len(x) {
+			panic(fmt.Sprintf("len(inAxes)=%d does not match len(x)=%d", len(inAxes), len(x)))
+		}
+
+		size := -1
+		for i := range x {
+			if inAxes[i] == None {
+				continue
+			}
+
+			n := x[i].Size(inAxes[i])
+			if size >= 0 && n != size {
+				panic(fmt.Sprintf("batch size %d of x[%d] does not match %d", n, i, size))
+			}
+
+			size = n
+		}
+
+		if size < 0 {
+			panic("no input is batched")
+		}
+
+		xs := make([]*variable.Variable[T], len(x))
+		for i := range x {
+			if inAxes[i] == None {
+				// broadcast to every example so that the gradients with respect to it in f are per-example
+				xs[i] = variable.Batch(stack(size, x[i]), 0)
+				continue
+			}
+
+			xs[i] = variable.Batch(x[i], inAxes[i])
+		}
+
+		y := f(xs...)
+		if !y.Batched() {
+			// y does not depend on the examples
+			y = variable.Batch(stack(size, y), 0)
+		}
+
+		return variable.Unbatch(y, outAxis)
+	}
+}
+
+// stack returns size copies of x stacked along a new axis 0.
+func stack[T tensor.Float](size int, x *variable.Variable[T]) *variable.Variable[T] {
+	return variable.BroadcastTo[T](append([]int{size}, x.Shape()...)...)(variable.Unsqueeze[T](0)(x))
+}
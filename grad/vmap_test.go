@@ -0,0 +1,314 @@
+package grad_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/grad"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleVmap() {
+	// the dot product of two vectors
+	dot := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Sum[float64]()(F.Mul(x[0], x[1]))
+	}
+
+	x := variable.New(1, 2, 3, 4, 5, 6).Reshape(2, 3)
+	w := variable.New(1, 0, -1)
+
+	y := grad.Vmap(dot, []int{0, grad.None}, 0)(x, w)
+	fmt.Println(y)
+
+	// Output:
+	// variable[2]([-2 -2])
+}
+
+func ExampleVmap_grad() {
+	// the loss of an example
+	loss := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Square(F.Sub(F.Sum[float64]()(F.Mul(x[0], x[1])), x[2]))
+	}
+
+	// per-example gradients with respect to w
+	gw := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return grad.Grad(loss)(x...)[1]
+	}
+
+	x := variable.New(1, 2, 3, 4).Reshape(2, 2)
+	w := variable.New(0.5, -1)
+	t := variable.New(1, 2)
+
+	y := grad.Vmap(gw, []int{0, grad.None, 0}, 0)(x, w, t)
+	fmt.Println(y)
+
+	// Output:
+	// variable[2 2]([-5 -10 -27 -36])
+}
+
+func ExampleVmap_outAxis() {
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.MulC(2, x[0])
+	}
+
+	x := variable.New(1, 2, 3, 4, 5, 6).Reshape(2, 3)
+	y := grad.Vmap(f, []int{1}, 1)(x)
+	fmt.Println(y)
+
+	// Output:
+	// variable[2 3]([2 4 6 8 10 12])
+}
+
+func TestVmap(t *testing.T) {
+	s := rand.NewPCG(1, 2)
+	rand := func(shape ...int) *variable.Variable[float64] {
+		return variable.Rand(shape, s)
+	}
+
+	// spd returns a symmetric positive definite matrix
+	spd := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		n := x[0].Size(-1)
+		return F.Add(F.MatMul(x[0], F.Transpose[float64](1, 0)(x[0])), variable.From(tensor.Identity[float64](n, n)))
+	}
+
+	cases := []struct {
+		name    string
+		f       grad.Func[float64]
+		x       []*variable.Variable[float64]
+		inAxes  []int
+		outAxis int
+	}{
+		{"add", F.Add[float64], []*variable.Variable[float64]{rand(4, 3), rand(3)}, []int{0, grad.None}, 0},
+		{"add batched", F.Add[float64], []*variable.Variable[float64]{rand(4, 3), rand(4, 3)}, []int{0, 0}, 0},
+		{"add scalar", F.Add[float64], []*variable.Variable[float64]{rand(4), rand(2, 3)}, []int{0, grad.None}, 0},
+		{"add rank", F.Add[float64], []*variable.Variable[float64]{rand(4, 3), rand(2, 3)}, []int{0, grad.None}, 0},
+		{"sub", F.Sub[float64], []*variable.Variable[float64]{rand(3), rand(4, 3)}, []int{grad.None, 0}, 0},
+		{"mul", F.Mul[float64], []*variable.Variable[float64]{rand(4, 2, 3), rand(3, 4)}, []int{0, 1}, 0},
+		{"div", F.Div[float64], []*variable.Variable[float64]{rand(4, 3), addc(1, rand(4, 3))}, []int{0, 0}, 0},
+		{"neg", F.Neg[float64], []*variable.Variable[float64]{rand(4, 3)}, []int{0}, 0},
+		{"exp", F.Exp[float64], []*variable.Variable[float64]{rand(4, 3)}, []int{0}, 0},
+		{"log", F.Log[float64], []*variable.Variable[float64]{addc(1, rand(4, 3))}, []int{0}, 0},
+		{"sin", F.Sin[float64], []*variable.Variable[float64]{rand(4, 3)}, []int{0}, 0},
+		{"cos", F.Cos[float64], []*variable.Variable[float64]{rand(4, 3)}, []int{0}, 0},
+		{"tanh", F.Tanh[float64], []*variable.Variable[float64]{rand(4, 3)}, []int{0}, 0},
+		{"pow", func(x ...*variable.Variable[float64]) *variable.Variable[float64] { return F.Pow(3.0)(x...) }, []*variable.Variable[float64]{rand(4, 3)}, []int{0}, 0},
+		{"clip", func(x ...*variable.Variable[float64]) *variable.Variable[float64] { return F.Clip(0.2, 0.8)(x...) }, []*variable.Variable[float64]{rand(4, 3)}, []int{0}, 0},
+		{"sum", func(x ...*variable.Variable[float64]) *variable.Variable[float64] { return F.Sum[float64]()(x...) }, []*variable.Variable[float64]{rand(4, 2, 3)}, []int{0}, 0},
+		{"sum axis", func(x ...*variable.Variable[float64]) *variable.Variable[float64] { return F.Sum[float64](-1)(x...) }, []*variable.Variable[float64]{rand(4, 2, 3)}, []int{0}, 0},
+		{"sum scalar", func(x ...*variable.Variable[float64]) *variable.Variable[float64] { return F.Sum[float64]()(x...) }, []*variable.Variable[float64]{rand(4)}, []int{0}, 0},
+		{"mean", func(x ...*variable.Variable[float64]) *variable.Variable[float64] { return F.Mean[float64](0)(x...) }, []*variable.Variable[float64]{rand(4, 2, 3)}, []int{0}, 0},
+		{"variance", func(x ...*variable.Variable[float64]) *variable.Variable[float64] { return F.Variance[float64]()(x...) }, []*variable.Variable[float64]{rand(4, 2, 3)}, []int{0}, 0},
+		{"max", func(x ...*variable.Variable[float64]) *variable.Variable[float64] { return F.Max[float64](1)(x...) }, []*variable.Variable[float64]{rand(4, 2, 3)}, []int{0}, 0},
+		{"min", func(x ...*variable.Variable[float64]) *variable.Variable[float64] { return F.Min[float64]()(x...) }, []*variable.Variable[float64]{rand(4, 2, 3)}, []int{0}, 0},
+		{"reshape", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Reshape[float64](3, 2)(x...)
+		}, []*variable.Variable[float64]{rand(4, 2, 3)}, []int{0}, 0},
+		{"transpose", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Transpose[float64]()(x...)
+		}, []*variable.Variable[float64]{rand(4, 2, 3)}, []int{0}, 0},
+		{"transpose axes", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Transpose[float64](1, 0)(x...)
+		}, []*variable.Variable[float64]{rand(4, 2, 3)}, []int{0}, 0},
+		{"broadcast_to", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.BroadcastTo[float64](2, 3)(x...)
+		}, []*variable.Variable[float64]{rand(4, 3)}, []int{0}, 0},
+		{"sum_to", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.SumTo[float64](1, 3)(x...)
+		}, []*variable.Variable[float64]{rand(4, 2, 2, 3)}, []int{0}, 0},
+		{"squeeze", func(x ...*variable.Variable[float64]) *variable.Variable[float64] { return F.Squeeze[float64](1)(x...) }, []*variable.Variable[float64]{rand(4, 3, 1)}, []int{0}, 0},
+		{"unsqueeze", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Unsqueeze[float64](-1)(x...)
+		}, []*variable.Variable[float64]{rand(4, 3)}, []int{0}, 0},
+		{"concat", func(x ...*variable.Variable[float64]) *variable.Variable[float64] { return F.Concat[float64](0)(x...) }, []*variable.Variable[float64]{rand(4, 2), rand(3)}, []int{0, grad.None}, 0},
+		{"split", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Split[float64]([]int{1, 2}, 1)(x...)[0]
+		}, []*variable.Variable[float64]{rand(4, 2, 3)}, []int{0}, 0},
+		{"get_item", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.GetItem[float64](1, []int{2, 0})(x...)
+		}, []*variable.Variable[float64]{rand(4, 2, 3)}, []int{0}, 0},
+		{"slice", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Slice[float64](tensor.Range{Start: 1, Stop: 3})(x...)
+		}, []*variable.Variable[float64]{rand(4, 3)}, []int{0}, 0},
+		{"einsum", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Einsum[float64]("ij,jk->ik")(x...)
+		}, []*variable.Variable[float64]{rand(4, 2, 3), rand(3, 2)}, []int{0, grad.None}, 0},
+		{"matmul", F.MatMul[float64], []*variable.Variable[float64]{rand(2, 3), rand(4, 3, 2)}, []int{grad.None, 0}, 0},
+		{"matmul batched", F.MatMul[float64], []*variable.Variable[float64]{rand(4, 2, 3), rand(4, 3, 2)}, []int{0, 0}, 0},
+		{"inv", compose(F.Inv, spd), []*variable.Variable[float64]{rand(4, 3, 3)}, []int{0}, 0},
+		{"det", F.Det[float64], []*variable.Variable[float64]{rand(4, 3, 3)}, []int{0}, 0},
+		{"logdet", compose(F.LogDet, spd), []*variable.Variable[float64]{rand(4, 3, 3)}, []int{0}, 0},
+		{"solve", func(x ...*variable.Variable[float64]) *variable.Variable[float64] { return F.Solve(spd(x[0]), x[1]) }, []*variable.Variable[float64]{rand(4, 3, 3), rand(3, 2)}, []int{0, grad.None}, 0},
+		{"cholesky", compose(F.Cholesky, spd), []*variable.Variable[float64]{rand(4, 3, 3)}, []int{0}, 0},
+		{"qr", func(x ...*variable.Variable[float64]) *variable.Variable[float64] { q, _ := F.QR(x...); return q }, []*variable.Variable[float64]{rand(4, 3, 2)}, []int{0}, 0},
+		{"eigh", compose(func(x ...*variable.Variable[float64]) *variable.Variable[float64] { w, _ := F.Eigh(x...); return w }, spd), []*variable.Variable[float64]{rand(4, 3, 3)}, []int{0}, 0},
+		{"svd", compose(func(x ...*variable.Variable[float64]) *variable.Variable[float64] { _, s, _ := F.SVD(x...); return s }, spd), []*variable.Variable[float64]{rand(4, 3, 3)}, []int{0}, 0},
+		{"lu", compose(func(x ...*variable.Variable[float64]) *variable.Variable[float64] { _, _, u := F.LU(x...); return u }, spd), []*variable.Variable[float64]{rand(4, 3, 3)}, []int{0}, 0},
+		{"sigmoid", F.Sigmoid[float64], []*variable.Variable[float64]{rand(4, 3)}, []int{0}, 0},
+		{"relu", F.ReLU[float64], []*variable.Variable[float64]{addc(-0.5, rand(4, 3))}, []int{0}, 0},
+		{"gelu", F.GELU[float64], []*variable.Variable[float64]{rand(4, 3)}, []int{0}, 0},
+		{"softmax", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Softmax[float64](-1)(x...)
+		}, []*variable.Variable[float64]{rand(4, 2, 3)}, []int{0}, 0},
+		{"mean_squared_error", F.MeanSquaredError[float64], []*variable.Variable[float64]{rand(4, 2, 3), rand(2, 3)}, []int{0, grad.None}, 0},
+		{"linear", F.Linear[float64], []*variable.Variable[float64]{rand(4, 2, 3), rand(3, 2), rand(4, 2)}, []int{0, grad.None, 0}, 0},
+		{"gather", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Gather[float64](0, tensor.New([]int{2}, []int{2, 0}))(x...)
+		}, []*variable.Variable[float64]{rand(4, 3)}, []int{0}, 0},
+		{"scatter_add", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.ScatterAdd[float64](1, tensor.New([]int{2, 2}, []int{0, 2, 1, 1}))(x...)
+		}, []*variable.Variable[float64]{rand(2, 3), rand(4, 2, 2)}, []int{grad.None, 0}, 0},
+		{"cross_entropy", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.CrossEntropy(x[0], variable.New(1, 0))
+		}, []*variable.Variable[float64]{rand(4, 2, 3)}, []int{0}, 0},
+		{"in_axis", F.Mul[float64], []*variable.Variable[float64]{rand(3, 4), rand(3)}, []int{1, grad.None}, 0},
+		{"out_axis", func(x ...*variable.Variable[float64]) *variable.Variable[float64] { return F.Sum[float64](0)(x...) }, []*variable.Variable[float64]{rand(4, 2, 3)}, []int{0}, 1},
+		{"constant", func(x ...*variable.Variable[float64]) *variable.Variable[float64] { return variable.New(1, 2) }, []*variable.Variable[float64]{rand(4, 3)}, []int{0}, 1},
+		{"composite", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			h := F.Tanh(F.Add(F.MatMul(x[0], x[1]), x[2]))
+			return F.Sum[float64]()(F.Mul(F.Softmax[float64](-1)(h), h))
+		}, []*variable.Variable[float64]{rand(4, 1, 3), rand(3, 2), rand(2)}, []int{0, grad.None, grad.None}, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := grad.Vmap(c.f, c.inAxes, c.outAxis)(c.x...)
+			want := loop(c.f, c.inAxes, c.outAxis)(c.x...)
+			if !tensor.SliceEqual(got.Shape(), want.Shape()) {
+				t.Fatalf("shape=%v, want=%v", got.Shape(), want.Shape())
+			}
+
+			if got.Batched() {
+				t.Errorf("output is batched")
+			}
+
+			if !tensor.IsCloseAll(got.Data, want.Data, 1e-8, 1e-8) {
+				t.Errorf("got=%v, want=%v", got.Data.Data, want.Data.Data)
+			}
+
+			// gradients
+			gxs := grad.Grad(grad.Vmap(c.f, c.inAxes, c.outAxis))(c.x...)
+			wxs := grad.Grad(loop(c.f, c.inAxes, c.outAxis))(c.x...)
+			for i := range gxs {
+				if gxs[i].Batched() {
+					t.Errorf("gradient of x[%d] is batched", i)
+				}
+
+				if !tensor.IsCloseAll(gxs[i].Data, wxs[i].Data, 1e-8, 1e-8) {
+					t.Errorf("x[%d]: grad=%v, want=%v", i, gxs[i].Data.Data, wxs[i].Data.Data)
+				}
+			}
+		})
+	}
+}
+
+func TestVmap_grad(t *testing.T) {
+	s := rand.NewPCG(1, 2)
+	x, w, b, tt := variable.Rand([]int{5, 3}, s), variable.Rand([]int{3, 2}, s), variable.Rand([]int{2}, s), variable.Rand([]int{5, 1, 2}, s)
+
+	loss := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.MeanSquaredError(F.Sigmoid(F.Add(F.MatMul(F.Unsqueeze[float64](0)(x[0]), x[1]), x[2])), x[3])
+	}
+
+	for i := 1; i < 3; i++ {
+		// per-example gradients with respect to the parameters
+		g := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return grad.Grad(loss)(x...)[i]
+		}
+
+		got := grad.Vmap(g, []int{0, grad.None, grad.None, 0}, 0)(x, w, b, tt)
+		want := loop(g, []int{0, grad.None, grad.None, 0}, 0)(x, w, b, tt)
+		if !tensor.IsCloseAll(got.Data, want.Data, 1e-8, 1e-8) {
+			t.Errorf("got=%v, want=%v", got.Data.Data, want.Data.Data)
+		}
+	}
+}
+
+func TestVmap_jvp(t *testing.T) {
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Sum[float64](-1)(F.Mul(F.Sin(x[0]), x[1]))
+	}
+
+	x, w := variable.New(1, 2, 3, 4, 5, 6).Reshape(2, 3), variable.New(1, 2, 3)
+	tx, tw := variable.New(1, 0, 0, 0, 0, 1).Reshape(2, 3), variable.New(0, 0, 0)
+	vf := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return grad.Vmap(f, []int{0, grad.None}, 0)(x...)
+	}
+
+	_, got := grad.Jvp(vf, []*variable.Variable[float64]{x, w}, []*variable.Variable[float64]{tx, tw})
+	_, want := grad.Jvp(loop(f, []int{0, grad.None}, 0), []*variable.Variable[float64]{x, w}, []*variable.Variable[float64]{tx, tw})
+	if !tensor.IsCloseAll(got.Data, want.Data, 1e-8, 1e-8) {
+		t.Errorf("got=%v, want=%v", got.Data.Data, want.Data.Data)
+	}
+}
+
+func TestVmap_panic(t *testing.T) {
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] { return x[0] }
+	x := variable.New(1, 2)
+
+	cases := []struct {
+		name   string
+		inAxes []int
+		x      []*variable.Variable[float64]
+		want   string
+	}{
+		{"len", []int{0, 0}, []*variable.Variable[float64]{x}, "len(inAxes)=2 does not match len(x)=1"},
+		{"none", []int{grad.None}, []*variable.Variable[float64]{x}, "no input is batched"},
+		{"size", []int{0, 0}, []*variable.Variable[float64]{x, variable.New(1, 2, 3)}, "batch size 3 of x[1] does not match 2"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != c.want {
+					t.Errorf("got=%v, want=%v", r, c.want)
+				}
+			}()
+
+			grad.Vmap(f, c.inAxes, 0)(c.x...)
+			t.Fail()
+		})
+	}
+}
+
+// loop returns a function that applies f to each example of x and stacks the outputs along outAxis.
+func loop(f grad.Func[float64], inAxes []int, outAxis int) grad.Func[float64] {
+	return func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		var size int
+		for i := range x {
+			if inAxes[i] != grad.None {
+				size = x[i].Size(inAxes[i])
+			}
+		}
+
+		ys := make([]*variable.Variable[float64], size)
+		for k := range size {
+			xk := make([]*variable.Variable[float64], len(x))
+			for i := range x {
+				if inAxes[i] == grad.None {
+					xk[i] = x[i]
+					continue
+				}
+
+				xk[i] = F.GetItem[float64](inAxes[i], []int{k})(x[i])
+				xk[i] = F.Squeeze[float64](inAxes[i])(xk[i])
+			}
+
+			ys[k] = F.Unsqueeze[float64](outAxis)(f(xk...))
+		}
+
+		return F.Concat[float64](outAxis)(ys...)
+	}
+}
+
+func compose(f, g grad.Func[float64]) grad.Func[float64] {
+	return func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return f(g(x...))
+	}
+}
+
+func addc(c float64, x *variable.Variable[float64]) *variable.Variable[float64] {
+	return variable.From(tensor.AddC(c, x.Data))
+}
@@ -3,19 +3,19 @@ package hook
 import (
 	"math"
 
-	"github.com/itsubaki/autograd/layer"
 	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
 )
 
 // ClipGrad returns a hook that clips the global gradient norm to max.
-func ClipGrad(max float64) func(params []layer.Parameter) {
-	return func(params []layer.Parameter) {
-		var total float64
+func ClipGrad[T tensor.Float](max T) func(params []*variable.Variable[T]) {
+	return func(params []*variable.Variable[T]) {
+		var total T
 		for _, p := range params {
 			total += tensor.Sum(tensor.Pow(2, p.Grad.Data)).At()
 		}
 
-		rate := max / (math.Sqrt(total) + 1e-6)
+		rate := max / (T(math.Sqrt(float64(total))) + 1e-6)
 		if rate >= 1 {
 			return
 		}
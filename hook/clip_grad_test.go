@@ -12,7 +12,7 @@ func ExampleClipGrad() {
 	p.Grad = variable.New(1, 2, 3, 4)
 
 	h := hook.ClipGrad(1.0)
-	h([]*variable.Variable{p})
+	h([]*variable.Variable[float64]{p})
 
 	fmt.Println(p.Grad)
 
@@ -25,7 +25,7 @@ func ExampleClipGrad_noclip() {
 	p.Grad = variable.New(0.1, 0.2, 0.3, 0.4)
 
 	h := hook.ClipGrad(1.0)
-	h([]*variable.Variable{p})
+	h([]*variable.Variable[float64]{p})
 
 	fmt.Println(p.Grad)
 
@@ -1,13 +1,13 @@
 package hook
 
 import (
-	"github.com/itsubaki/autograd/layer"
 	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
 )
 
 // WeightDecay returns a hook that adds L2 weight decay to gradients.
-func WeightDecay(lambda float64) func(params []layer.Parameter) {
-	return func(params []layer.Parameter) {
+func WeightDecay[T tensor.Float](lambda T) func(params []*variable.Variable[T]) {
+	return func(params []*variable.Variable[T]) {
 		for _, p := range params {
 			p.Grad.Data = tensor.F2(p.Grad.Data, p.Data, decay(lambda))
 		}
@@ -15,6 +15,6 @@ func WeightDecay(lambda float64) func(params []layer.Parameter) {
 }
 
 // decay returns a function that adds lambda times b to a.
-func decay(lambda float64) func(a, b float64) float64 {
-	return func(a, b float64) float64 { return a + lambda*b }
+func decay[T tensor.Float](lambda T) func(a, b T) T {
+	return func(a, b T) T { return a + lambda*b }
 }
@@ -12,7 +12,7 @@ func ExampleWeightDecay() {
 	p.Grad = variable.New(1.0)
 
 	h := hook.WeightDecay(0.1)
-	h([]*variable.Variable{p})
+	h([]*variable.Variable[float64]{p})
 
 	fmt.Println(p.Grad)
 
@@ -0,0 +1,328 @@
+package jit
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+// Pass is an optimization of the steps of a plan.
+type Pass[T tensor.Float] func(p *Plan[T])
+
+// Optimize applies the passes to p in order, and returns p.
+// If no pass is given, FoldConstants, FuseElementwise and EliminateDeadSteps are applied.
+// The optimized plan gives the same results as p up to rounding errors, and Forward must be called again before Backward.
+func (p *Plan[T]) Optimize(passes ...Pass[T]) *Plan[T] {
+	if len(passes) == 0 {
+		passes = []Pass[T]{FoldConstants[T], FuseElementwise[T], EliminateDeadSteps[T]}
+	}
+
+	for _, pass := range passes {
+		pass(p)
+	}
+
+	p.compile()
+	return p
+}
+
+// FoldConstants evaluates the steps whose inputs are all constants once, and replaces their outputs with constants.
+// It also folds the scalar constants of Add, Sub, Mul and Div into a kernel applied to each element of the other input.
+// The constants are the leaves that do not require gradients, such as c of MulC.
+// Their values are read by FoldConstants, so they must not be changed afterwards.
+func FoldConstants[T tensor.Float](p *Plan[T]) {
+	constant := make([]bool, len(p.values))
+	for _, k := range p.leaves {
+		constant[k] = !p.values[k].RequiresGrad()
+	}
+
+	var steps []step[T]
+	for _, s := range p.steps {
+		if all(constant, s.in) {
+			y := s.f.Forward(p.slots(s.in)...)
+			for j, k := range s.out {
+				store(p.values[k].Data, y[j].Data)
+				p.values[k].SetRequiresGrad(false)
+				p.leaves = append(p.leaves, k)
+				constant[k] = true
+			}
+
+			continue
+		}
+
+		if k, in, ok := p.arithmetic(s, constant); ok {
+			s = step[T]{f: k, in: []int{in}, out: s.out}
+		}
+
+		steps = append(steps, s)
+	}
+
+	p.steps = steps
+}
+
+// FuseElementwise fuses the chains of elementwise operations of a single input into one step,
+// which applies the functions of the chain to each element in one pass and does not compute the intermediate values.
+// The elementwise operations are the ones that implement variable.Elementwise, and the kernels of FoldConstants.
+// A chain is broken by an intermediate value that is used by other steps or is the output of the plan.
+// The gradients of a fused chain are computed in one pass as well, but the operations applied by the Backward of the other steps are not fused.
+func FuseElementwise[T tensor.Float](p *Plan[T]) {
+	uses := make([]int, len(p.values))
+	uses[p.output]++
+	for _, s := range p.steps {
+		for _, k := range s.in {
+			uses[k]++
+		}
+	}
+
+	// the index in steps of the kernel that computes the slot
+	producer := make(map[int]int)
+
+	var steps []step[T]
+	for _, s := range p.steps {
+		k, ok := kernel(s)
+		if !ok {
+			steps = append(steps, s)
+			continue
+		}
+
+		if i, ok := producer[s.in[0]]; ok && uses[s.in[0]] == 1 {
+			prev := steps[i].f.(*fused[T])
+			steps[i] = step[T]{f: prev.then(k), in: steps[i].in, out: s.out}
+			producer[s.out[0]] = i
+			continue
+		}
+
+		steps = append(steps, step[T]{f: k, in: s.in, out: s.out})
+		producer[s.out[0]] = len(steps) - 1
+	}
+
+	p.steps = steps
+}
+
+// EliminateDeadSteps removes the steps whose outputs are not used to compute the output of the plan,
+// and renumbers the slots without the values that are no longer used, such as the intermediate values of fused chains.
+func EliminateDeadSteps[T tensor.Float](p *Plan[T]) {
+	live := make([]bool, len(p.values))
+	live[p.output] = true
+
+	var steps []step[T]
+	for _, s := range slices.Backward(p.steps) {
+		if !slices.ContainsFunc(s.out, func(k int) bool { return live[k] }) {
+			continue
+		}
+
+		for _, k := range s.in {
+			live[k] = true
+		}
+
+		steps = append(steps, s)
+	}
+
+	slices.Reverse(steps)
+
+	// the outputs of the steps are kept even if they are not used, and so are the inputs of the plan
+	used := make([]bool, len(p.values))
+	used[p.output] = true
+	for _, k := range p.inputs {
+		used[k] = true
+	}
+
+	for _, s := range steps {
+		for _, k := range slices.Concat(s.in, s.out) {
+			used[k] = true
+		}
+	}
+
+	slot := make([]int, len(p.values))
+	var values []*variable.Variable[T]
+	for k, v := range p.values {
+		if !used[k] {
+			continue
+		}
+
+		slot[k] = len(values)
+		values = append(values, v)
+	}
+
+	renumber := func(k []int) []int {
+		out := make([]int, len(k))
+		for i := range k {
+			out[i] = slot[k[i]]
+		}
+
+		return out
+	}
+
+	var leaves []int
+	for _, k := range p.leaves {
+		if used[k] {
+			leaves = append(leaves, slot[k])
+		}
+	}
+
+	for i := range steps {
+		steps[i].in, steps[i].out = renumber(steps[i].in), renumber(steps[i].out)
+	}
+
+	p.values, p.steps, p.leaves = values, steps, leaves
+	p.inputs, p.output = renumber(p.inputs), slot[p.output]
+}
+
+// fused is the operation that applies the scalar functions of a chain of elementwise operations to each element in one pass.
+// Its gradient is computed in one pass, and is not differentiable.
+type fused[T tensor.Float] struct {
+	names   []string
+	fn, dfn []func(x T) T
+	x       *variable.Variable[T]
+}
+
+func (f *fused[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
+	f.x = x[0]
+
+	y := tensor.F(x[0].Data, func(a T) T {
+		for _, fn := range f.fn {
+			a = fn(a)
+		}
+
+		return a
+	})
+
+	return []*variable.Variable[T]{
+		variable.From(y),
+	}
+}
+
+func (f *fused[T]) Backward(gy ...*variable.Variable[T]) []*variable.Variable[T] {
+	// chain rule: gy * df_n(a_n-1) * ... * df_1(a_0)
+	gx := tensor.F2(f.x.Data, gy[0].Data, func(a, g T) T {
+		for i, fn := range f.fn {
+			g *= f.dfn[i](a)
+			a = fn(a)
+		}
+
+		return g
+	})
+
+	return []*variable.Variable[T]{
+		variable.From(gx),
+	}
+}
+
+func (f *fused[T]) ForwardInto(y []*tensor.Tensor[T], x ...*variable.Variable[T]) bool {
+	f.x = x[0]
+
+	for i, a := range tensor.Contiguous(x[0].Data).Data {
+		for _, fn := range f.fn {
+			a = fn(a)
+		}
+
+		y[0].Data[i] = a
+	}
+
+	return true
+}
+
+func (f *fused[T]) BackwardInto(gx []*tensor.Tensor[T], gy ...*tensor.Tensor[T]) {
+	if gx[0] == nil {
+		return
+	}
+
+	for i, a := range tensor.Contiguous(f.x.Data).Data {
+		g := gy[0].Data[i]
+		for j, fn := range f.fn {
+			g *= f.dfn[j](a)
+			a = fn(a)
+		}
+
+		gx[0].Data[i] += g
+	}
+}
+
+// then returns the kernel that applies f and then next.
+func (f *fused[T]) then(next *fused[T]) *fused[T] {
+	return &fused[T]{
+		names: slices.Concat(f.names, next.names),
+		fn:    slices.Concat(f.fn, next.fn),
+		dfn:   slices.Concat(f.dfn, next.dfn),
+	}
+}
+
+// kernel returns the kernel of the step if it is an elementwise operation of a single input.
+func kernel[T tensor.Float](s step[T]) (*fused[T], bool) {
+	if k, ok := s.f.(*fused[T]); ok {
+		return k, true
+	}
+
+	e, ok := s.f.(variable.Elementwise[T])
+	if !ok || len(s.in) != 1 || len(s.out) != 1 {
+		return nil, false
+	}
+
+	f, df := e.Scalar()
+	return &fused[T]{
+		names: []string{fmt.Sprintf("%T", s.f)},
+		fn:    []func(x T) T{f},
+		dfn:   []func(x T) T{df},
+	}, true
+}
+
+// arithmetic returns the kernel of the step if it is Add, Sub, Mul or Div of a scalar constant,
+// and the slot of the other input. The other input must have the shape of the output.
+func (p *Plan[T]) arithmetic(s step[T], constant []bool) (*fused[T], int, bool) {
+	if len(s.in) != 2 || constant[s.in[0]] == constant[s.in[1]] {
+		return nil, 0, false
+	}
+
+	i := 0 // the index of the constant
+	if constant[s.in[1]] {
+		i = 1
+	}
+
+	c, x := p.values[s.in[i]], p.values[s.in[1-i]]
+	if c.Size() != 1 || !tensor.SliceEqual(x.Shape(), p.values[s.out[0]].Shape()) {
+		return nil, 0, false
+	}
+
+	v := tensor.Contiguous(c.Data).Data[0]
+	var f, df func(a T) T
+	switch s.f.(type) {
+	case *variable.AddT[T]:
+		f, df = func(a T) T { return v + a }, func(a T) T { return 1 }
+	case *variable.SubT[T]:
+		if i == 0 {
+			f, df = func(a T) T { return v - a }, func(a T) T { return -1 }
+			break
+		}
+
+		f, df = func(a T) T { return a - v }, func(a T) T { return 1 }
+	case *variable.MulT[T]:
+		f, df = func(a T) T { return v * a }, func(a T) T { return v }
+	case *variable.DivT[T]:
+		if i == 0 {
+			f, df = func(a T) T { return v / a }, func(a T) T { return -v / (a * a) }
+			break
+		}
+
+		f, df = func(a T) T { return a / v }, func(a T) T { return 1 / v }
+	default:
+		return nil, 0, false
+	}
+
+	return &fused[T]{
+		names: []string{fmt.Sprintf("%T", s.f)},
+		fn:    []func(x T) T{f},
+		dfn:   []func(x T) T{df},
+	}, s.in[1-i], true
+}
+
+// all reports whether the slots are all true.
+func all(b []bool, k []int) bool {
+	for _, i := range k {
+		if !b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,209 @@
+package jit_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/grad"
+	"github.com/itsubaki/autograd/jit"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExamplePlan_Optimize() {
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Sum[float64]()(F.MulC(2, F.Exp(F.AddC(1, F.Sin(x[0])))))
+	}
+
+	p := jit.Trace(f, variable.New(0, 0))
+	fmt.Println(p)
+	fmt.Println()
+
+	p.Optimize()
+	fmt.Println(p)
+
+	x := variable.New(1, 2)
+	fmt.Println(p.Forward(x), f(variable.New(1, 2)))
+
+	// Output:
+	// v0 = x[0]
+	// v1 = *variable.SinT[float64](v0)
+	// v3 = *variable.AddT[float64](v2, v1)
+	// v4 = *variable.ExpT[float64](v3)
+	// v6 = *variable.MulT[float64](v5, v4)
+	// v7 = *variable.SumT[float64](v6)
+	// return v7
+	//
+	// v0 = x[0]
+	// v1 = fused[*variable.SinT[float64] *variable.AddT[float64] *variable.ExpT[float64] *variable.MulT[float64]](v0)
+	// v2 = *variable.SumT[float64](v1)
+	// return v2
+	// variable(26.10830622901169) variable(26.10830622901169)
+}
+
+func TestOptimize(t *testing.T) {
+	s := rand.NewPCG(1, 2)
+	w := variable.Randn([]int{3, 4}, s)
+
+	cases := []struct {
+		name string
+		f    func(x ...*variable.Variable[float64]) *variable.Variable[float64]
+		len  int
+	}{
+		{"chain", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Sum[float64]()(F.Mul(F.DivC(2, F.AddC(3, F.Square(F.Tanh(F.MulC(0.5, x[0]))))), w))
+		}, 3},
+		{"scalar right", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			c := variable.New(4).SetRequiresGrad(false)
+			return F.Sum[float64]()(F.Mul(F.Sub(F.Div(F.Cos(x[0]), c), c), w))
+		}, 3},
+		{"log exp neg", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Sum[float64]()(F.Log(F.AddC(2, F.Neg(F.Exp(F.Neg(F.Pow(2.0)(x[0])))))))
+		}, 2},
+		{"activations", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Sum[float64]()(F.Mul(F.Clip(-0.8, 0.8)(F.GELU(F.Sigmoid(F.ReLU(F.SubC(0.1, x[0]))))), w))
+		}, 3},
+		{"shared", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			y := F.Sin(x[0])
+			return F.Sum[float64]()(F.Mul(F.Exp(y), F.MulC(2, y)))
+		}, 5},
+		{"output", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Exp(F.Sin(x[0]))
+		}, 1},
+		{"broadcast", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Sum[float64]()(F.Mul(F.AddC(1, F.Sum[float64](1)(x[0])), variable.New(1, 2, 3, 4, 5).Reshape(5, 1)))
+		}, 4},
+		{"gelu grad", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.Sum[float64]()(F.Mul(grad.Grad[float64](F.GELU)(x[0])[0], w))
+		}, 13},
+		{"cross entropy", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return F.CrossEntropy(F.Linear(F.Tanh(x[0]), F.Transpose[float64]()(w)), variable.New(0, 2, 1))
+		}, 4},
+	}
+
+	passes := []struct {
+		name   string
+		passes []jit.Pass[float64]
+	}{
+		{"none", []jit.Pass[float64]{func(p *jit.Plan[float64]) {}}},
+		{"fold", []jit.Pass[float64]{jit.FoldConstants[float64]}},
+		{"fuse", []jit.Pass[float64]{jit.FuseElementwise[float64]}},
+		{"dead", []jit.Pass[float64]{jit.EliminateDeadSteps[float64]}},
+		{"fold fuse", []jit.Pass[float64]{jit.FoldConstants[float64], jit.FuseElementwise[float64]}},
+		{"all", nil},
+	}
+
+	for _, c := range cases {
+		for _, pass := range passes {
+			t.Run(c.name+"/"+pass.name, func(t *testing.T) {
+				p := jit.Trace(c.f, variable.Zeros[float64](3, 4)).Optimize(pass.passes...)
+				if pass.passes == nil && p.Len() != c.len {
+					t.Errorf("len=%d, want=%d\n%v", p.Len(), c.len, p)
+				}
+
+				for range 3 {
+					x := variable.Randn([]int{3, 4}, s)
+
+					w.Cleargrad()
+					want := c.f(x)
+					want.Backward()
+					wgx, wgw := x.Grad, w.Grad
+
+					w.Cleargrad()
+					x.Cleargrad()
+					got := p.Forward(x)
+					p.Backward()
+
+					if !tensor.IsCloseAll(got.Data, want.Data, 1e-10, 1e-10) {
+						t.Errorf("got=%v, want=%v", got.Data.Data, want.Data.Data)
+					}
+
+					if !tensor.IsCloseAll(x.Grad.Data, wgx.Data, 1e-10, 1e-10) {
+						t.Errorf("x.Grad=%v, want=%v", x.Grad.Data.Data, wgx.Data.Data)
+					}
+
+					if (w.Grad == nil) != (wgw == nil) {
+						t.Fatalf("w.Grad=%v, want=%v", w.Grad, wgw)
+					}
+
+					if wgw != nil && !tensor.IsCloseAll(w.Grad.Data, wgw.Data, 1e-10, 1e-10) {
+						t.Errorf("w.Grad=%v, want=%v", w.Grad.Data.Data, wgw.Data.Data)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestFoldConstants(t *testing.T) {
+	w := variable.New(1, 2)
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Sum[float64]()(F.Mul(x[0], F.Exp(F.Sin(w))))
+	}
+
+	p := jit.Trace(f, variable.New(0, 0))
+
+	// w is frozen after the trace
+	w.SetRequiresGrad(false)
+	p.Optimize()
+	if p.Len() != 2 {
+		t.Errorf("len=%d, want=2\n%v", p.Len(), p)
+	}
+
+	x := variable.New(3, 4)
+	got := p.Forward(x)
+	p.Backward()
+
+	want := f(variable.New(3, 4))
+	if !tensor.IsCloseAll(got.Data, want.Data, 1e-10, 1e-10) {
+		t.Errorf("got=%v, want=%v", got.Data.Data, want.Data.Data)
+	}
+
+	if !tensor.IsCloseAll(x.Grad.Data, F.Exp(F.Sin(w)).Data, 1e-10, 1e-10) {
+		t.Errorf("x.Grad=%v", x.Grad)
+	}
+
+	if w.Grad != nil {
+		t.Errorf("w.Grad=%v", w.Grad)
+	}
+}
+
+func TestOptimize_backward(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "Backward is called before Forward" {
+			t.Errorf("unexpected panic: %v", r)
+		}
+	}()
+
+	p := jit.Trace(F.Exp, variable.New(1, 2))
+	p.Forward(variable.New(1, 2))
+	p.Optimize().Backward()
+	t.Fail()
+}
+
+func BenchmarkOptimize(b *testing.B) {
+	s := rand.NewPCG(1, 2)
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Sum[float64]()(grad.Grad[float64](F.GELU)(x[0])[0])
+	}
+
+	x := variable.Randn([]int{128, 256}, s)
+
+	b.Run("plan", func(b *testing.B) {
+		p := jit.Trace(f, x)
+		for range b.N {
+			p.Forward(x)
+			p.Backward()
+		}
+	})
+
+	b.Run("optimized", func(b *testing.B) {
+		p := jit.Trace(f, x).Optimize()
+		for range b.N {
+			p.Forward(x)
+			p.Backward()
+		}
+	})
+}
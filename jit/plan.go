@@ -0,0 +1,358 @@
+// Package jit records a function of variables into a static plan that can be replayed for new inputs.
+// Replaying a plan does not create functions nor sort them, and reuses the buffers of the values and gradients.
+// The steps that implement Inplacer, and the elementwise operations, write into the buffers without allocating;
+// the results of the other steps are copied into them.
+// Optimize rewrites the steps of a plan, for example to fuse chains of elementwise operations into one pass.
+// The passes rewrite the functions recorded in the forward only. The functions applied by the Backward of a step are not recorded,
+// so its chain of operations is fused only if the step computes its gradients in one pass by BackwardInto, like CrossEntropy.
+package jit
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+// nograd is the session of the gradients while backpropagating a plan.
+var nograd = &variable.Session{EnableBackprop: false, Train: true}
+
+// Plan is a static sequence of the functions recorded by Trace.
+// The values are numbered slots, which are the inputs, the leaves and the outputs of the steps.
+type Plan[T tensor.Float] struct {
+	values  []*variable.Variable[T]
+	grads   []*tensor.Tensor[T]
+	reached []bool
+	inputs  []int
+	leaves  []int
+	steps   []step[T]
+	order   []int
+	output  int
+	x       []*variable.Variable[T]
+}
+
+// Inplacer is the interface implemented by operations that write their results into the buffers of a plan.
+type Inplacer[T tensor.Float] interface {
+	// ForwardInto writes the outputs for x into y, which are contiguous and have the shapes of the outputs,
+	// and reports whether it does. If not, the plan applies Forward and copies the outputs into y instead.
+	ForwardInto(y []*tensor.Tensor[T], x ...*variable.Variable[T]) bool
+	// BackwardInto adds the gradients for gy to gx, which are contiguous and have the shapes of the inputs.
+	// gx[i] is nil if the gradient of x[i] is not needed. It is called only after ForwardInto writes the outputs.
+	BackwardInto(gx []*tensor.Tensor[T], gy ...*tensor.Tensor[T])
+}
+
+// step is a function of the plan and the slots of its inputs and outputs.
+type step[T tensor.Float] struct {
+	f       variable.Forwarder[T]
+	in, out []int
+	into    Inplacer[T]
+	inplace bool
+
+	// the values, the buffers of the outputs and the gradients of the slots
+	x         []*variable.Variable[T]
+	y, gx, gy []*tensor.Tensor[T]
+	gys       []*variable.Variable[T]
+}
+
+// Trace applies f to x and records the functions in the graph of the output into a plan.
+// The plan can be replayed by Forward and Backward for new inputs with the same shapes as x,
+// and gives the same results as f as long as f applies the same functions for every input.
+// The leaves of the graph other than x, such as parameters, are used by reference, so their updates are reflected.
+// Variables computed in f without a graph, such as the random mask of DropoutSimple, are recorded as constants.
+// f is applied as a recomputation, so the side effects of its functions, such as the update of the running statistics
+// of BatchNorm, happen in Forward only.
+func Trace[T tensor.Float](f func(x ...*variable.Variable[T]) *variable.Variable[T], x ...*variable.Variable[T]) *Plan[T] {
+	rec := variable.SessionOf[T]()
+	if !rec.EnableBackprop {
+		panic("backprop must be enabled to trace")
+	}
+
+	rec.Recompute = true
+	xs := make([]*variable.Variable[T], len(x))
+	for i := range x {
+		xs[i] = variable.From(tensor.Clone(x[i].Data)).Bind(&rec)
+	}
+
+	y := f(xs...)
+
+	p := &Plan[T]{}
+	slot := make(map[*variable.Variable[T]]int)
+	add := func(v *variable.Variable[T]) int {
+		if k, ok := slot[v]; ok {
+			return k
+		}
+
+		slot[v] = len(p.values)
+		p.values = append(p.values, v)
+		return slot[v]
+	}
+
+	for _, v := range xs {
+		p.inputs = append(p.inputs, add(v))
+	}
+
+	for _, fn := range funcs(y) {
+		s := step[T]{f: fn.Forwarder}
+		for _, v := range fn.Input {
+			if _, ok := slot[v]; !ok {
+				// the outputs of the previous steps have slots
+				p.leaves = append(p.leaves, add(v))
+			}
+
+			s.in = append(s.in, slot[v])
+		}
+
+		for _, v := range fn.Output {
+			// the buffer of the output
+			v.Data = tensor.Clone(v.Data)
+			s.out = append(s.out, add(v))
+		}
+
+		p.steps = append(p.steps, s)
+	}
+
+	p.output = add(y)
+	if y.Creator == nil && !slices.Contains(p.inputs, p.output) {
+		p.leaves = append(p.leaves, p.output)
+	}
+
+	// the values other than the leaves are not bound to the session of the trace when replayed
+	for k, v := range p.values {
+		if !slices.Contains(p.leaves, k) {
+			p.values[k] = variable.From(v.Data)
+		}
+	}
+
+	p.compile()
+	return p
+}
+
+// Forward replays the plan for x, and returns the output.
+// x must have the same shapes as the inputs of Trace.
+// The data of the output is a buffer of the plan, which is overwritten by the next Forward.
+func (p *Plan[T]) Forward(x ...*variable.Variable[T]) *variable.Variable[T] {
+	if len(x) != len(p.inputs) {
+		panic(fmt.Sprintf("len(x)=%d does not match %d inputs", len(x), len(p.inputs)))
+	}
+
+	for i, k := range p.inputs {
+		store(p.values[k].Data, x[i].Data)
+	}
+
+	for i := range p.steps {
+		s := &p.steps[i]
+		if s.inplace = s.into != nil && s.into.ForwardInto(s.y, s.x...); s.inplace {
+			continue
+		}
+
+		y := s.f.Forward(s.x...)
+		for j := range s.y {
+			store(s.y[j], y[j].Data)
+		}
+	}
+
+	p.x = x
+	return variable.From(p.values[p.output].Data)
+}
+
+// Backward backpropagates the output of the last Forward in the precomputed order.
+// Like Variable.Backward, the gradients are accumulated in the Grad fields of the inputs of Forward and the leaves,
+// except for the variables that do not require gradients. Hooks are not called.
+func (p *Plan[T]) Backward() {
+	if p.x == nil {
+		panic("Backward is called before Forward")
+	}
+
+	for k := range p.grads {
+		clear(p.grads[k].Data)
+		p.reached[k] = false
+	}
+
+	fill(p.grads[p.output].Data, 1)
+	p.reached[p.output] = true
+
+	for _, i := range p.order {
+		s := &p.steps[i]
+		if s.inplace {
+			for j, k := range s.in {
+				s.gx[j] = nil
+				if p.values[k].RequiresGrad() {
+					s.gx[j], p.reached[k] = p.grads[k], true
+				}
+			}
+
+			s.into.BackwardInto(s.gx, s.gy...)
+			continue
+		}
+
+		gxs := s.f.Backward(s.gys...)
+		for j, k := range s.in[:min(len(s.in), len(gxs))] {
+			if gxs[j] == nil || !p.values[k].RequiresGrad() {
+				continue
+			}
+
+			accumulate(p.grads[k], gxs[j].Data)
+			p.reached[k] = true
+		}
+	}
+
+	for i, k := range p.inputs {
+		p.setGrad(p.x[i], k)
+	}
+
+	for _, k := range p.leaves {
+		p.setGrad(p.values[k], k)
+	}
+}
+
+// Len returns the number of steps of the plan.
+func (p *Plan[T]) Len() int {
+	return len(p.steps)
+}
+
+// String returns the steps of the plan, one per line.
+func (p *Plan[T]) String() string {
+	var sb strings.Builder
+	for i, k := range p.inputs {
+		fmt.Fprintf(&sb, "v%d = x[%d]\n", k, i)
+	}
+
+	for _, s := range p.steps {
+		fmt.Fprintf(&sb, "%s = %s(%s)\n", names(s.out), name(s.f), names(s.in))
+	}
+
+	fmt.Fprintf(&sb, "return v%d", p.output)
+	return sb.String()
+}
+
+// compile precomputes the backward order and allocates the buffers of the gradients.
+func (p *Plan[T]) compile() {
+	p.order = make([]int, len(p.steps))
+	for i := range p.steps {
+		p.order[i] = len(p.steps) - 1 - i
+	}
+
+	p.grads = make([]*tensor.Tensor[T], len(p.values))
+	for k, v := range p.values {
+		p.grads[k] = tensor.ZeroLike(v.Data)
+	}
+
+	p.reached = make([]bool, len(p.values))
+	p.x = nil
+
+	for i := range p.steps {
+		s := &p.steps[i]
+		s.x, s.gx = p.slots(s.in), make([]*tensor.Tensor[T], len(s.in))
+		s.y, s.gy, s.gys = nil, nil, nil
+		for _, k := range s.out {
+			s.y = append(s.y, p.values[k].Data)
+			s.gy = append(s.gy, p.grads[k])
+			s.gys = append(s.gys, variable.From(p.grads[k]).Bind(nograd))
+		}
+
+		s.into, _ = s.f.(Inplacer[T])
+		if k, ok := kernel(*s); ok && s.into == nil {
+			// the elementwise operations are replayed by their kernels
+			s.into = k
+		}
+	}
+}
+
+// slots returns the values of the slots.
+func (p *Plan[T]) slots(k []int) []*variable.Variable[T] {
+	out := make([]*variable.Variable[T], len(k))
+	for i := range k {
+		out[i] = p.values[k[i]]
+	}
+
+	return out
+}
+
+// setGrad accumulates the gradient of the slot k in the Grad field of v, if it is reached.
+func (p *Plan[T]) setGrad(v *variable.Variable[T], k int) {
+	if !p.reached[k] || !v.RequiresGrad() {
+		return
+	}
+
+	if v.Grad == nil {
+		v.Grad = variable.From(tensor.Clone(p.grads[k]))
+		return
+	}
+
+	v.Grad = variable.From(tensor.Add(v.Grad.Data, p.grads[k]))
+}
+
+// funcs returns the functions in the graph of y in ascending order of generation.
+func funcs[T tensor.Float](y *variable.Variable[T]) []*variable.Function[T] {
+	if y.Creator == nil {
+		return nil
+	}
+
+	seen := map[*variable.Function[T]]bool{y.Creator: true}
+	fs := []*variable.Function[T]{y.Creator}
+	for i := 0; i < len(fs); i++ {
+		for _, x := range fs[i].Input {
+			if x.Creator == nil || seen[x.Creator] {
+				continue
+			}
+
+			seen[x.Creator] = true
+			fs = append(fs, x.Creator)
+		}
+	}
+
+	sort.SliceStable(fs, func(i, j int) bool { return fs[i].Generation < fs[j].Generation })
+	return fs
+}
+
+// store copies the data of src to the buffer dst.
+func store[T tensor.Float](dst, src *tensor.Tensor[T]) {
+	if !tensor.SliceEqual(dst.Shape, src.Shape) {
+		panic(fmt.Sprintf("shape %v does not match %v", src.Shape, dst.Shape))
+	}
+
+	if dst == src {
+		return
+	}
+
+	copy(dst.Data, tensor.Contiguous(src).Data)
+}
+
+// accumulate adds src to the buffer dst.
+func accumulate[T tensor.Float](dst, src *tensor.Tensor[T]) {
+	if !tensor.SliceEqual(dst.Shape, src.Shape) {
+		panic(fmt.Sprintf("gradient shape %v does not match %v", src.Shape, dst.Shape))
+	}
+
+	for i, a := range tensor.Contiguous(src).Data {
+		dst.Data[i] += a
+	}
+}
+
+func fill[T tensor.Float](data []T, v T) {
+	for i := range data {
+		data[i] = v
+	}
+}
+
+// name returns the name of the operation f.
+func name[T tensor.Float](f variable.Forwarder[T]) string {
+	if k, ok := f.(*fused[T]); ok {
+		return fmt.Sprintf("fused[%s]", strings.Join(k.names, " "))
+	}
+
+	return fmt.Sprintf("%T", f)
+}
+
+// names returns the names of the slots.
+func names(k []int) string {
+	s := make([]string, len(k))
+	for i := range k {
+		s[i] = fmt.Sprintf("v%d", k[i])
+	}
+
+	return strings.Join(s, ", ")
+}
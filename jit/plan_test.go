@@ -0,0 +1,350 @@
+package jit_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/jit"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleTrace() {
+	w := variable.New(2, 3)
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Sum[float64]()(F.Mul(F.Sin(x[0]), w))
+	}
+
+	p := jit.Trace(f, variable.New(0, 0))
+	fmt.Println(p)
+
+	x := variable.New(1, 2)
+	y := p.Forward(x)
+	p.Backward()
+
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+	fmt.Println(w.Grad)
+
+	// Output:
+	// v0 = x[0]
+	// v1 = *variable.SinT[float64](v0)
+	// v3 = *variable.MulT[float64](v1, v2)
+	// v4 = *variable.SumT[float64](v3)
+	// return v4
+	// variable(4.410834250092838)
+	// variable[2]([1.0806046117362795 -1.2484405096414273])
+	// variable[2]([0.8414709848078965 0.9092974268256816])
+}
+
+func ExamplePlan_Forward() {
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Add(x[0], x[1])
+	}
+
+	p := jit.Trace(f, variable.New(0, 0), variable.New(0, 0))
+	for i := range 3 {
+		c := float64(i)
+		y := p.Forward(variable.New(c, c), variable.New(1, 2))
+		fmt.Println(y)
+	}
+
+	// Output:
+	// variable[2]([1 2])
+	// variable[2]([2 3])
+	// variable[2]([3 4])
+}
+
+func ExampleTrace_batchNorm() {
+	mean, variance := tensor.Zeros[float64](1), tensor.Ones[float64](1)
+	gamma, beta := variable.New(1), variable.New(0)
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.BatchNorm(mean, variance, 0.5, 0)(x[0], gamma, beta)
+	}
+
+	// the running statistics are updated by Forward, not by Trace
+	p := jit.Trace(f, variable.New(0, 2).Reshape(2, 1))
+	fmt.Println(mean.Data, variance.Data)
+
+	p.Forward(variable.New(0, 2).Reshape(2, 1))
+	fmt.Println(mean.Data, variance.Data)
+
+	// Output:
+	// [0] [1]
+	// [0.5] [1.5]
+}
+
+func TestPlan(t *testing.T) {
+	s := rand.NewPCG(1, 2)
+	w1, b1 := variable.Randn([]int{3, 4}, s), variable.Zeros[float64](4)
+	w2, b2 := variable.Randn([]int{4, 2}, s), variable.Zeros[float64](2)
+	params := []*variable.Variable[float64]{w1, b1, w2, b2}
+
+	// a two-layer MLP and its loss
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		h := F.Sigmoid(F.Linear(x[0], w1, b1))
+		y := F.Softmax[float64](1)(F.Linear(h, w2, b2))
+		return F.MeanSquaredError(y, x[1])
+	}
+
+	x, tt := variable.Randn([]int{5, 3}, s), variable.Rand([]int{5, 2}, s)
+	p := jit.Trace(f, x, tt)
+
+	for range 5 {
+		x, tt := variable.Randn([]int{5, 3}, s), variable.Rand([]int{5, 2}, s)
+
+		// dynamic graph
+		for _, v := range params {
+			v.Cleargrad()
+		}
+
+		want := f(x, tt)
+		want.Backward()
+
+		wgrads := make([]*tensor.Tensor[float64], len(params))
+		for i, v := range params {
+			wgrads[i] = v.Grad.Data
+		}
+
+		// plan
+		for _, v := range params {
+			v.Cleargrad()
+		}
+
+		xs := []*variable.Variable[float64]{variable.From(x.Data), variable.From(tt.Data)}
+		got := p.Forward(xs...)
+		p.Backward()
+
+		if !tensor.IsCloseAll(got.Data, want.Data, 1e-10, 1e-10) {
+			t.Errorf("got=%v, want=%v", got.Data.Data, want.Data.Data)
+		}
+
+		for i, v := range params {
+			if !tensor.IsCloseAll(v.Grad.Data, wgrads[i], 1e-10, 1e-10) {
+				t.Errorf("params[%d].Grad=%v, want=%v", i, v.Grad.Data.Data, wgrads[i].Data)
+			}
+		}
+
+		if xs[0].Grad == nil || xs[1].Grad == nil {
+			t.Errorf("x.Grad=%v, t.Grad=%v", xs[0].Grad, xs[1].Grad)
+		}
+
+		// update the parameters
+		for _, v := range params {
+			v.Data = tensor.Sub(v.Data, tensor.MulC(0.1, v.Grad.Data))
+		}
+	}
+}
+
+func TestPlan_inplace(t *testing.T) {
+	s := rand.NewPCG(1, 2)
+	w, b, b2 := variable.Randn([]int{3, 4}, s), variable.Randn([]int{4}, s), variable.Randn([]int{1, 4}, s)
+
+	cases := []struct {
+		f func(x ...*variable.Variable[float64]) *variable.Variable[float64]
+		x []*variable.Variable[float64]
+	}{
+		{
+			// bias with shape (1, N)
+			f: func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+				return F.MeanSquaredError(F.ReLU(F.Linear(x[0], w, b2)), x[1])
+			},
+			x: []*variable.Variable[float64]{variable.Randn([]int{5, 3}, s), variable.Randn([]int{5, 4}, s)},
+		},
+		{
+			// no bias, and x is not a matrix
+			f: func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+				return F.Sum[float64]()(F.Tanh(F.Linear(x[0], w)))
+			},
+			x: []*variable.Variable[float64]{variable.Randn([]int{2, 5, 3}, s)},
+		},
+		{
+			// fused chain
+			f: func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+				return F.MeanSquaredError(F.Exp(F.Sin(F.Linear(x[0], w, b))), x[1])
+			},
+			x: []*variable.Variable[float64]{variable.Randn([]int{5, 3}, s), variable.Randn([]int{5, 4}, s)},
+		},
+		{
+			// the backward of the softmax cross-entropy in one pass, with an ignored label
+			f: func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+				return F.CrossEntropy(F.Linear(x[0], w, b), x[1])
+			},
+			x: []*variable.Variable[float64]{variable.Randn([]int{5, 3}, s), variable.New(0, 3, -100, 2, 1)},
+		},
+		{
+			// every label is ignored
+			f: func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+				return F.CrossEntropy(F.Linear(x[0], w, b), x[1])
+			},
+			x: []*variable.Variable[float64]{variable.Randn([]int{2, 3}, s), variable.New(-100, -100)},
+		},
+	}
+
+	for _, c := range cases {
+		params := []*variable.Variable[float64]{w, b, b2}
+		for _, v := range params {
+			v.Cleargrad()
+		}
+
+		want := c.f(c.x...)
+		want.Backward()
+
+		wgrads := make([]*tensor.Tensor[float64], len(params))
+		for i, v := range params {
+			if v.Grad != nil {
+				wgrads[i] = v.Grad.Data
+			}
+		}
+
+		for _, p := range []*jit.Plan[float64]{jit.Trace(c.f, c.x...), jit.Trace(c.f, c.x...).Optimize()} {
+			for _, v := range params {
+				v.Cleargrad()
+			}
+
+			// replayed twice to reuse the buffers
+			p.Forward(c.x...)
+			got := p.Forward(c.x...)
+			p.Backward()
+
+			if !tensor.IsCloseAll(got.Data, want.Data, 1e-10, 1e-10) {
+				t.Errorf("got=%v, want=%v", got.Data.Data, want.Data.Data)
+			}
+
+			for i, v := range params {
+				if wgrads[i] == nil {
+					if v.Grad != nil {
+						t.Errorf("params[%d].Grad=%v", i, v.Grad)
+					}
+
+					continue
+				}
+
+				if !tensor.IsCloseAll(v.Grad.Data, wgrads[i], 1e-10, 1e-10) {
+					t.Errorf("params[%d].Grad=%v, want=%v", i, v.Grad.Data.Data, wgrads[i].Data)
+				}
+			}
+		}
+	}
+}
+
+func TestPlan_requiresGrad(t *testing.T) {
+	w := variable.New(2, 3).SetRequiresGrad(false)
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Sum[float64]()(F.Mul(x[0], w))
+	}
+
+	p := jit.Trace(f, variable.New(0, 0))
+
+	x := variable.New(1, 2)
+	p.Forward(x)
+	p.Backward()
+	p.Backward()
+
+	if w.Grad != nil {
+		t.Errorf("w.Grad=%v", w.Grad)
+	}
+
+	// accumulated twice
+	if !tensor.IsCloseAll(x.Grad.Data, tensor.New([]int{2}, []float64{4, 6}), 1e-10, 1e-10) {
+		t.Errorf("x.Grad=%v", x.Grad)
+	}
+}
+
+func TestPlan_panic(t *testing.T) {
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.Exp(x[0])
+	}
+
+	cases := []struct {
+		name string
+		run  func(p *jit.Plan[float64])
+		want string
+	}{
+		{"len", func(p *jit.Plan[float64]) { p.Forward() }, "len(x)=0 does not match 1 inputs"},
+		{"shape", func(p *jit.Plan[float64]) { p.Forward(variable.New(1, 2, 3)) }, "shape [3] does not match [2]"},
+		{"backward", func(p *jit.Plan[float64]) { p.Backward() }, "Backward is called before Forward"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != c.want {
+					t.Errorf("got=%v, want=%v", r, c.want)
+				}
+			}()
+
+			c.run(jit.Trace(f, variable.New(1, 2)))
+			t.Fail()
+		})
+	}
+}
+
+func TestTrace_nograd(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "backprop must be enabled to trace" {
+			t.Errorf("unexpected panic: %v", r)
+		}
+	}()
+
+	defer variable.Nograd().End()
+	jit.Trace(F.Exp, variable.New(1))
+	t.Fail()
+}
+
+func BenchmarkMLP(b *testing.B) {
+	s := rand.NewPCG(1, 2)
+	w1, b1 := variable.Randn([]int{64, 128}, s), variable.Zeros[float64](128)
+	w2, b2 := variable.Randn([]int{128, 10}, s), variable.Zeros[float64](10)
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		h := F.ReLU(F.Linear(x[0], w1, b1))
+		return F.MeanSquaredError(F.Linear(h, w2, b2), x[1])
+	}
+
+	x, t := variable.Randn([]int{32, 64}, s), variable.Rand([]int{32, 10}, s)
+
+	b.Run("dynamic", func(b *testing.B) {
+		for range b.N {
+			f(x, t).Backward()
+		}
+	})
+
+	b.Run("plan", func(b *testing.B) {
+		p := jit.Trace(f, x, t)
+		for range b.N {
+			p.Forward(x, t)
+			p.Backward()
+		}
+	})
+}
+
+func BenchmarkCrossEntropy(b *testing.B) {
+	s := rand.NewPCG(1, 2)
+	w := variable.Randn([]int{64, 100}, s)
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return F.CrossEntropy(F.Linear(x[0], w), x[1])
+	}
+
+	label := make([]float64, 128)
+	for i := range label {
+		label[i] = float64(i % 100)
+	}
+
+	x, t := variable.Randn([]int{128, 64}, s), variable.New(label...)
+
+	b.Run("dynamic", func(b *testing.B) {
+		for range b.N {
+			f(x, t).Backward()
+		}
+	})
+
+	b.Run("plan", func(b *testing.B) {
+		p := jit.Trace(f, x, t)
+		for range b.N {
+			p.Forward(x, t)
+			p.Backward()
+		}
+	})
+}
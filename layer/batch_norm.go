@@ -0,0 +1,103 @@
+package layer
+
+import (
+	"fmt"
+	"slices"
+
+	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+// BatchNormOptionFunc configures a BatchNormT layer.
+type BatchNormOptionFunc func(*batchNormOpts)
+
+// batchNormOpts holds the options of a BatchNormT layer.
+type batchNormOpts struct {
+	momentum, eps float64
+}
+
+// WithBatchNormMomentum sets the momentum of the running statistics. The default is 0.1.
+func WithBatchNormMomentum(momentum float64) BatchNormOptionFunc {
+	return func(o *batchNormOpts) {
+		o.momentum = momentum
+	}
+}
+
+// WithBatchNormEps sets the value added to the variance for numerical stability. The default is 1e-5.
+func WithBatchNormEps(eps float64) BatchNormOptionFunc {
+	return func(o *batchNormOpts) {
+		o.eps = eps
+	}
+}
+
+// BatchNorm1d returns a new batch normalization layer for inputs with shape (N, C) or (N, C, L).
+func BatchNorm1d[T tensor.Float](channels int, opts ...BatchNormOptionFunc) *BatchNormT[T] {
+	return newBatchNorm[T]([]int{2, 3}, channels, opts...)
+}
+
+// BatchNorm2d returns a new batch normalization layer for inputs with shape (N, C, H, W).
+func BatchNorm2d[T tensor.Float](channels int, opts ...BatchNormOptionFunc) *BatchNormT[T] {
+	return newBatchNorm[T]([]int{4}, channels, opts...)
+}
+
+// BatchNormT is a batch normalization layer.
+// The scale gamma and the shift beta with shape (C,) are trainable parameters.
+// The running mean and variance with shape (C,) are buffers, which are updated in training mode and used in test mode.
+// Buffers are not returned by Params, so optimizers do not update them, and they are saved and loaded with the parameters.
+type BatchNormT[T tensor.Float] struct {
+	ndims         []int
+	momentum, eps T
+	buffers       Parameters[T]
+	Parameters[T]
+}
+
+// newBatchNorm returns a new batch normalization layer for inputs with one of the numbers of dimensions.
+func newBatchNorm[T tensor.Float](ndims []int, channels int, opts ...BatchNormOptionFunc) *BatchNormT[T] {
+	o := batchNormOpts{
+		momentum: 0.1,
+		eps:      1e-5,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	p := make(Parameters[T])
+	p.Add("gamma", variable.Ones[T](channels))
+	p.Add("beta", variable.Zeros[T](channels))
+
+	b := make(Parameters[T])
+	b.Add("mean", variable.Zeros[T](channels).SetRequiresGrad(false))
+	b.Add("var", variable.Ones[T](channels).SetRequiresGrad(false))
+
+	return &BatchNormT[T]{
+		ndims:      ndims,
+		momentum:   T(o.momentum),
+		eps:        T(o.eps),
+		buffers:    b,
+		Parameters: p,
+	}
+}
+
+// Buffers returns the running mean and variance.
+func (l *BatchNormT[T]) Buffers() Parameters[T] {
+	return l.buffers
+}
+
+// First applies the layer and returns the first output.
+func (l *BatchNormT[T]) First(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return l.Forward(x...)[0]
+}
+
+// Forward applies the layer to x.
+func (l *BatchNormT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
+	if !slices.Contains(l.ndims, x[0].NumDims()) {
+		panic(fmt.Sprintf("shape %v does not have %v dimensions", x[0].Shape(), l.ndims))
+	}
+
+	bn := F.BatchNorm(l.buffers["mean"].Data, l.buffers["var"].Data, l.momentum, l.eps)
+	return []*variable.Variable[T]{
+		bn(x[0], l.Parameters["gamma"], l.Parameters["beta"]),
+	}
+}
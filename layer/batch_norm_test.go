@@ -0,0 +1,136 @@
+package layer_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	L "github.com/itsubaki/autograd/layer"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleBatchNorm1d() {
+	l := L.BatchNorm1d[float64](2, L.WithBatchNormMomentum(0.5), L.WithBatchNormEps(0))
+
+	x := variable.New(
+		1, 10,
+		3, 30,
+	).Reshape(2, 2)
+
+	y := l.Forward(x)
+	fmt.Println(y[0])
+
+	for k, v := range l.Params().Seq2() {
+		fmt.Println(k, v)
+	}
+
+	for k, v := range l.Buffers().Seq2() {
+		fmt.Println(k, v)
+	}
+
+	// Output:
+	// variable[2 2]([-1 -1 1 1])
+	// beta beta[2]([0 0])
+	// gamma gamma[2]([1 1])
+	// mean mean[2]([1 10])
+	// var var[2]([1.5 100.5])
+}
+
+func ExampleBatchNorm2d_backward() {
+	l := L.BatchNorm2d[float64](2)
+
+	x := variable.Randn([]int{3, 2, 2, 2}, rand.Const())
+	y := l.Forward(x)
+	y[0].Backward()
+
+	fmt.Println(x.Grad.Shape())
+	for _, v := range l.Params().Seq2() {
+		fmt.Println(v.Name, v.Grad.Shape())
+	}
+
+	for _, v := range l.Buffers().Seq2() {
+		fmt.Println(v.Name, v.Grad)
+	}
+
+	// Output:
+	// [3 2 2 2]
+	// beta [2]
+	// gamma [2]
+	// mean <nil>
+	// var <nil>
+}
+
+func ExampleBatchNorm1d_buffers() {
+	l := L.BatchNorm1d[float64](1)
+	l.Forward(variable.New(1, 2, 3, 4).Reshape(4, 1))
+
+	var buf bytes.Buffer
+	if err := L.Save(&buf, l); err != nil {
+		panic(err)
+	}
+
+	restored := L.BatchNorm1d[float64](1)
+	if err := L.Load(&buf, restored); err != nil {
+		panic(err)
+	}
+
+	for _, v := range restored.Buffers().Seq2() {
+		fmt.Printf("%v %.4f\n", v.Name, v.Data.Data)
+	}
+
+	// Output:
+	// mean [0.2500]
+	// var [1.0667]
+}
+
+func TestBatchNorm2d(t *testing.T) {
+	l := L.BatchNorm2d[float64](3, L.WithBatchNormMomentum(0.2))
+
+	// the running statistics converge to the statistics of the data in training mode
+	for i := range 100 {
+		x := variable.Randn([]int{8, 3, 4, 4}, rand.Const(uint64(i)))
+		l.Forward(variable.AddC(5, variable.MulC(2, x)))
+	}
+
+	mean, variance := l.Buffers()["mean"].Data, l.Buffers()["var"].Data
+	if !tensor.IsCloseAll(mean, tensor.Full([]int{3}, 5.0), 0, 0.2) {
+		t.Errorf("mean=%v", mean.Data)
+	}
+
+	if !tensor.IsCloseAll(variance, tensor.Full([]int{3}, 4.0), 0, 0.5) {
+		t.Errorf("var=%v", variance.Data)
+	}
+
+	// they are used and not updated in test mode
+	x := variable.Randn([]int{2, 3, 4, 4}, rand.Const(100))
+	func() {
+		defer variable.TestMode().End()
+
+		got := l.First(x)
+		want := tensor.Div(
+			tensor.Sub(x.Data, tensor.Reshape(mean, 1, 3, 1, 1)),
+			tensor.Sqrt(tensor.AddC(1e-5, tensor.Reshape(variance, 1, 3, 1, 1))),
+		)
+
+		if !tensor.IsCloseAll(got.Data, want, 1e-12, 1e-12) {
+			t.Errorf("got=%v, want=%v", got.Data.Data, want.Data)
+		}
+	}()
+
+	if !tensor.IsCloseAll(l.Buffers()["mean"].Data, mean, 0, 0) {
+		t.Errorf("mean=%v, want=%v", l.Buffers()["mean"].Data.Data, mean.Data)
+	}
+}
+
+func TestBatchNorm2d_panic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "shape [2 3] does not have [4] dimensions" {
+			t.Errorf("got=%v", r)
+		}
+	}()
+
+	L.BatchNorm2d[float64](3).Forward(variable.Zeros[float64](2, 3))
+	t.Fail()
+}
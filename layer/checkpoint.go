@@ -0,0 +1,306 @@
+package layer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"unsafe"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+const (
+	// checkpointMagic is the magic string at the beginning of a checkpoint.
+	checkpointMagic = "AGCKPT"
+
+	// checkpointVersion is the version of the checkpoint format.
+	// Version 1 has no itemsize, and its data is float64.
+	checkpointVersion uint32 = 2
+)
+
+var (
+	// ErrMissingKey is returned by Load when a parameter is not in the checkpoint.
+	ErrMissingKey = errors.New("missing key")
+
+	// ErrUnexpectedKey is returned by Load when the checkpoint has a parameter that is not in params.
+	ErrUnexpectedKey = errors.New("unexpected key")
+
+	// ErrShapeMismatch is returned by Load when the shape of a parameter differs from the checkpoint.
+	ErrShapeMismatch = errors.New("shape mismatch")
+)
+
+// Save writes the names, shapes and data of the parameters and the buffers of m to w in key-sorted order.
+// The data is written in the precision of T.
+//
+// The format is little-endian:
+//
+//	magic "AGCKPT", version uint32, count uint32
+//	for each parameter:
+//	  len(name) uint32, name, itemsize uint32, ndim uint32, shape [ndim]uint64, data [size]float32 or [size]float64 by itemsize
+func Save[T tensor.Float](w io.Writer, m Module[T]) error {
+	params, err := state(m)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(checkpointMagic)
+	writeUint32(bw, checkpointVersion)
+	writeUint32(bw, uint32(len(params)))
+
+	itemsize := uint32(unsafe.Sizeof(T(0)))
+	for name, p := range params.Seq2() {
+		writeUint32(bw, uint32(len(name)))
+		bw.WriteString(name)
+		writeUint32(bw, itemsize)
+
+		writeUint32(bw, uint32(p.NumDims()))
+		for _, s := range p.Shape() {
+			writeUint64(bw, uint64(s))
+		}
+
+		for _, a := range tensor.Contiguous(p.Data).Data {
+			if itemsize == 4 {
+				writeUint32(bw, math.Float32bits(float32(a)))
+				continue
+			}
+
+			writeUint64(bw, math.Float64bits(float64(a)))
+		}
+	}
+
+	return bw.Flush()
+}
+
+// Load reads a checkpoint written by Save from r and restores the data of the parameters and the buffers of m.
+// The data is converted to T, so a checkpoint saved in float64 can be loaded into a model in float32, and vice versa.
+// Every parameter and buffer of m must be in the checkpoint with the same shape, and the checkpoint must not have others.
+// Otherwise, Load returns an error that lists every missing key, unexpected key and shape mismatch, and m is not modified.
+// A checkpoint in which a name appears more than once is rejected.
+// Parameters that are initialized lazily, such as the weights of Linear without WithInSize, must be created before loading,
+// for example by WithInSize, or WithMLPInSize of the models.
+func Load[T tensor.Float](r io.Reader, m Module[T]) error {
+	params, err := state(m)
+	if err != nil {
+		return err
+	}
+
+	br := bufio.NewReader(r)
+
+	magic := make([]byte, len(checkpointMagic))
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return fmt.Errorf("read magic: %w", err)
+	}
+
+	if string(magic) != checkpointMagic {
+		return fmt.Errorf("invalid magic %q", magic)
+	}
+
+	version, err := readUint32(br)
+	if err != nil {
+		return fmt.Errorf("read version: %w", err)
+	}
+
+	if version != 1 && version != checkpointVersion {
+		return fmt.Errorf("unsupported version %d", version)
+	}
+
+	count, err := readUint32(br)
+	if err != nil {
+		return fmt.Errorf("read count: %w", err)
+	}
+
+	loaded := make(map[string]*tensor.Tensor[float64], count)
+	for range count {
+		name, v, err := readParam(br, version)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := loaded[name]; ok {
+			return fmt.Errorf("duplicate key %q", name)
+		}
+
+		loaded[name] = v
+	}
+
+	return restore(params, loaded)
+}
+
+// state returns the parameters and the buffers of m in one collection.
+func state[T tensor.Float](m Module[T]) (Parameters[T], error) {
+	params := make(Parameters[T])
+	for name, p := range m.Params() {
+		params[name] = p
+	}
+
+	for name, b := range m.Buffers() {
+		if _, ok := params[name]; ok {
+			return nil, fmt.Errorf("duplicate key %q", name)
+		}
+
+		params[name] = b
+	}
+
+	return params, nil
+}
+
+// restore sets the data of params to the loaded tensors with the same names converted to T.
+// It returns an error that lists every missing key, unexpected key and shape mismatch, and params is not modified.
+func restore[T tensor.Float](params Parameters[T], loaded map[string]*tensor.Tensor[float64]) error {
+	var errs []error
+	for name, p := range params.Seq2() {
+		v, ok := loaded[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w %q", ErrMissingKey, name))
+			continue
+		}
+
+		if !tensor.SliceEqual(p.Shape(), v.Shape) {
+			errs = append(errs, fmt.Errorf("%w for %q: shape=%v, checkpoint=%v", ErrShapeMismatch, name, p.Shape(), v.Shape))
+		}
+	}
+
+	names := make([]string, 0, len(loaded))
+	for name := range loaded {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if _, ok := params[name]; !ok {
+			errs = append(errs, fmt.Errorf("%w %q", ErrUnexpectedKey, name))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	// restore
+	for name, p := range params {
+		p.Data = tensor.Convert[T](loaded[name])
+	}
+
+	return nil
+}
+
+// readParam reads the name, shape and data of a parameter in the checkpoint of the version.
+func readParam(r io.Reader, version uint32) (string, *tensor.Tensor[float64], error) {
+	n, err := readUint32(r)
+	if err != nil {
+		return "", nil, fmt.Errorf("read name length: %w", err)
+	}
+
+	name := make([]byte, n)
+	if _, err := io.ReadFull(r, name); err != nil {
+		return "", nil, fmt.Errorf("read name: %w", err)
+	}
+
+	itemsize := uint32(8)
+	if version > 1 {
+		if itemsize, err = readUint32(r); err != nil {
+			return "", nil, fmt.Errorf("read itemsize of %q: %w", name, err)
+		}
+
+		if itemsize != 4 && itemsize != 8 {
+			return "", nil, fmt.Errorf("invalid itemsize %d of %q", itemsize, name)
+		}
+	}
+
+	ndim, err := readUint32(r)
+	if err != nil {
+		return "", nil, fmt.Errorf("read ndim of %q: %w", name, err)
+	}
+
+	shape := make([]int, ndim)
+	for i := range shape {
+		s, err := readUint64(r)
+		if err != nil {
+			return "", nil, fmt.Errorf("read shape of %q: %w", name, err)
+		}
+
+		if s > math.MaxInt {
+			return "", nil, fmt.Errorf("invalid shape of %q", name)
+		}
+
+		shape[i] = int(s)
+	}
+
+	k := int(itemsize)
+	size, err := shapeSize(shape, k)
+	if err != nil {
+		return "", nil, fmt.Errorf("%q: %w", name, err)
+	}
+
+	// the buffer grows with the data read, so a shape larger than the data does not allocate it
+	data, err := io.ReadAll(io.LimitReader(r, int64(size*k)))
+	if err != nil {
+		return "", nil, fmt.Errorf("read data of %q: %w", name, err)
+	}
+
+	if len(data) != size*k {
+		return "", nil, fmt.Errorf("read data of %q: %w", name, io.ErrUnexpectedEOF)
+	}
+
+	v := tensor.Zeros[float64](shape...)
+	for i := range v.Data {
+		if k == 4 {
+			v.Data[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:])))
+			continue
+		}
+
+		v.Data[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[i*8:]))
+	}
+
+	return string(name), v, nil
+}
+
+// shapeSize returns the number of elements of the shape,
+// or an error if a dimension is negative or the number of bytes of the elements of itemsize overflows int.
+func shapeSize(shape []int, itemsize int) (int, error) {
+	n := 1
+	for _, d := range shape {
+		if d < 0 {
+			return 0, fmt.Errorf("invalid shape %v", shape)
+		}
+
+		if d != 0 && n > math.MaxInt/itemsize/d {
+			return 0, fmt.Errorf("shape %v is too large", shape)
+		}
+
+		n *= d
+	}
+
+	return n, nil
+}
+
+func writeUint32(w io.Writer, v uint32) {
+	w.Write(binary.LittleEndian.AppendUint32(nil, v))
+}
+
+func writeUint64(w io.Writer, v uint64) {
+	w.Write(binary.LittleEndian.AppendUint64(nil, v))
+}
+
+func readUint32(r io.Reader) (uint32, error) {
+	b := make([]byte, 4)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func readUint64(r io.Reader) (uint64, error) {
+	b := make([]byte, 8)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint64(b), nil
+}
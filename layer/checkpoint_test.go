@@ -0,0 +1,205 @@
+package layer_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/itsubaki/autograd/layer"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleSave() {
+	p := make(layer.Parameters[float64])
+	p.Add("w", variable.New(1, 2, 3, 4).Reshape(2, 2))
+	p.Add("b", variable.New(5, 6))
+
+	var buf bytes.Buffer
+	if err := layer.Save(&buf, p); err != nil {
+		panic(err)
+	}
+
+	q := make(layer.Parameters[float64])
+	q.Add("w", variable.Zeros[float64](2, 2))
+	q.Add("b", variable.Zeros[float64](2))
+	if err := layer.Load(&buf, q); err != nil {
+		panic(err)
+	}
+
+	for _, v := range q.Seq2() {
+		fmt.Println(v)
+	}
+
+	// Output:
+	// b[2]([5 6])
+	// w[2 2]([1 2 3 4])
+}
+
+func ExampleLoad_mismatch() {
+	p := make(layer.Parameters[float64])
+	p.Add("w", variable.New(1, 2, 3, 4).Reshape(2, 2))
+	p.Add("b", variable.New(5, 6))
+
+	var buf bytes.Buffer
+	if err := layer.Save(&buf, p); err != nil {
+		panic(err)
+	}
+
+	q := make(layer.Parameters[float64])
+	q.Add("w", variable.Zeros[float64](4))
+	q.Add("c", variable.Zeros[float64](2))
+
+	err := layer.Load(&buf, q)
+	fmt.Println(err)
+	fmt.Println(q["w"])
+
+	// Output:
+	// missing key "c"
+	// shape mismatch for "w": shape=[4], checkpoint=[2 2]
+	// unexpected key "b"
+	// w[4]([0 0 0 0])
+}
+
+func ExampleSave_float32() {
+	p := make(layer.Parameters[float32])
+	p.Add("w", variable.Convert[float32](variable.New(0.1, 0.2)))
+
+	var buf bytes.Buffer
+	if err := layer.Save(&buf, p); err != nil {
+		panic(err)
+	}
+
+	q := make(layer.Parameters[float64])
+	q.Add("w", variable.Zeros[float64](2))
+	if err := layer.Load(&buf, q); err != nil {
+		panic(err)
+	}
+
+	fmt.Println(q["w"])
+
+	// Output:
+	// w[2]([0.10000000149011612 0.20000000298023224])
+}
+
+func TestLoad_version1(t *testing.T) {
+	le := binary.LittleEndian
+	data := checkpoint("w", 2)
+	for _, v := range []float64{1, 2} {
+		data = le.AppendUint64(data, math.Float64bits(v))
+	}
+
+	q := layer.Parameters[float32]{"w": variable.Zeros[float32](2)}
+	if err := layer.Load(bytes.NewReader(data), q); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := q["w"].Data.Data; got[0] != 1 || got[1] != 2 {
+		t.Errorf("got=%v, want=[1 2]", got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	p := make(layer.Parameters[float64])
+	p.Add("w", variable.New(1, 2, 3, 4).Reshape(2, 2))
+	p.Add("b", variable.New(5, 6))
+
+	var buf bytes.Buffer
+	if err := layer.Save(&buf, p); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	cases := []struct {
+		params layer.Parameters[float64]
+		want   []error
+	}{
+		{
+			params: layer.Parameters[float64]{"w": variable.Zeros[float64](2, 2), "b": variable.Zeros[float64](2)},
+		},
+		{
+			params: layer.Parameters[float64]{"w": variable.Zeros[float64](2, 2)},
+			want:   []error{layer.ErrUnexpectedKey},
+		},
+		{
+			params: layer.Parameters[float64]{"w": variable.Zeros[float64](2, 2), "b": variable.Zeros[float64](2), "c": variable.Zeros[float64](1)},
+			want:   []error{layer.ErrMissingKey},
+		},
+		{
+			params: layer.Parameters[float64]{"w": variable.Zeros[float64](2, 3), "b": variable.Zeros[float64](1, 2)},
+			want:   []error{layer.ErrShapeMismatch},
+		},
+	}
+
+	for _, c := range cases {
+		err := layer.Load(bytes.NewReader(data), c.params)
+		if len(c.want) == 0 && err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		for _, w := range c.want {
+			if !errors.Is(err, w) {
+				t.Errorf("got=%v, want=%v", err, w)
+			}
+		}
+	}
+}
+
+func TestLoad_invalid(t *testing.T) {
+	p := make(layer.Parameters[float64])
+	p.Add("w", variable.New(1, 2))
+
+	var buf bytes.Buffer
+	if err := layer.Save(&buf, p); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	version := bytes.Clone(data)
+	version[6] = 3
+
+	itemsize := bytes.Clone(data)
+	itemsize[19] = 2
+
+	// the entry of "w" twice
+	duplicate := bytes.Clone(data)
+	duplicate[10] = 2
+	duplicate = append(duplicate, data[14:]...)
+
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"magic", []byte("NOTCKPT")},
+		{"version", version},
+		{"itemsize", itemsize},
+		{"truncated", data[:len(data)-1]},
+		{"duplicate", duplicate},
+		{"overflow", checkpoint("w", 1<<40, 1<<40)},
+		{"large", checkpoint("w", 1<<40)},
+		{"negative", checkpoint("w", math.MaxUint64)},
+	}
+
+	for _, c := range cases {
+		q := layer.Parameters[float64]{"w": variable.Zeros[float64](2)}
+		if err := layer.Load(bytes.NewReader(c.data), q); err == nil {
+			t.Errorf("%v: expected error", c.name)
+		}
+	}
+}
+
+// checkpoint returns a checkpoint of version 1 of a parameter with the name and shape without the data.
+func checkpoint(name string, shape ...uint64) []byte {
+	le := binary.LittleEndian
+	b := le.AppendUint32(le.AppendUint32([]byte("AGCKPT"), 1), 1)
+	b = append(le.AppendUint32(b, uint32(len(name))), name...)
+	b = le.AppendUint32(b, uint32(len(shape)))
+	for _, s := range shape {
+		b = le.AppendUint64(b, s)
+	}
+
+	return b
+}
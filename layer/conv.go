@@ -0,0 +1,153 @@
+package layer
+
+import (
+	"fmt"
+	"math"
+	randv2 "math/rand/v2"
+
+	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+// ConvOptionFunc configures a ConvT layer.
+type ConvOptionFunc func(*convOpts)
+
+// convOpts holds the options of a ConvT layer.
+type convOpts struct {
+	inChannels int
+	noBias     bool
+	opts       F.ConvOpts
+	s          randv2.Source
+}
+
+// WithConvSource sets the random source used to initialize the weights.
+func WithConvSource(s randv2.Source) ConvOptionFunc {
+	return func(o *convOpts) {
+		o.s = s
+	}
+}
+
+// WithInChannels initializes the weights with the given number of input channels.
+func WithInChannels(inChannels int) ConvOptionFunc {
+	return func(o *convOpts) {
+		o.inChannels = inChannels
+	}
+}
+
+// WithConvOpts sets the stride, padding, dilation and groups of the convolution.
+func WithConvOpts(opts F.ConvOpts) ConvOptionFunc {
+	return func(o *convOpts) {
+		o.opts = opts
+	}
+}
+
+// WithConvNoBias removes the bias parameter from the layer.
+func WithConvNoBias() ConvOptionFunc {
+	return func(o *convOpts) {
+		o.noBias = true
+	}
+}
+
+// Conv1d returns a new 1-D convolution layer with the given output channels and kernel size.
+// The number of input channels is inferred from the first input unless WithInChannels is given.
+func Conv1d[T tensor.Float](outChannels int, kernel []int, opts ...ConvOptionFunc) *ConvT[T] {
+	return newConv(F.Conv1d[T], 1, outChannels, kernel, opts...)
+}
+
+// Conv2d returns a new 2-D convolution layer with the given output channels and kernel size.
+// The kernel size has one value for each of the height and width, or a single value for both.
+// The number of input channels is inferred from the first input unless WithInChannels is given.
+func Conv2d[T tensor.Float](outChannels int, kernel []int, opts ...ConvOptionFunc) *ConvT[T] {
+	return newConv(F.Conv2d[T], 2, outChannels, kernel, opts...)
+}
+
+// Conv3d returns a new 3-D convolution layer with the given output channels and kernel size.
+// The kernel size has one value for each of the depth, height and width, or a single value for all of them.
+// The number of input channels is inferred from the first input unless WithInChannels is given.
+func Conv3d[T tensor.Float](outChannels int, kernel []int, opts ...ConvOptionFunc) *ConvT[T] {
+	return newConv(F.Conv3d[T], 3, outChannels, kernel, opts...)
+}
+
+// ConvT is a trainable convolution layer.
+// The weight w has shape (OC, C/groups, *kernel), and the bias b has shape (OC,).
+type ConvT[T tensor.Float] struct {
+	conv        func(opts ...F.ConvOpts) func(x ...*variable.Variable[T]) *variable.Variable[T]
+	outChannels int
+	kernel      []int
+	opts        F.ConvOpts
+	s           randv2.Source
+	Parameters[T]
+}
+
+// newConv returns a new layer of the convolution over ndim spatial axes.
+func newConv[T tensor.Float](conv func(opts ...F.ConvOpts) func(x ...*variable.Variable[T]) *variable.Variable[T], ndim, outChannels int, kernel []int, opts ...ConvOptionFunc) *ConvT[T] {
+	switch len(kernel) {
+	case ndim:
+	case 1:
+		kernel = tensor.Full([]int{ndim}, kernel[0]).Data
+	default:
+		panic(fmt.Sprintf("len(%v) does not match %d spatial axes", kernel, ndim))
+	}
+
+	var o convOpts
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	p := make(Parameters[T])
+	if !o.noBias {
+		p.Add("b", variable.Zeros[T](outChannels))
+	}
+
+	l := &ConvT[T]{
+		conv:        conv,
+		outChannels: outChannels,
+		kernel:      kernel,
+		opts:        o.opts,
+		s:           o.s,
+		Parameters:  p,
+	}
+
+	if o.inChannels > 0 {
+		l.Add("w", l.initw(o.inChannels))
+	}
+
+	return l
+}
+
+// First applies the layer and returns the first output.
+func (l *ConvT[T]) First(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return l.Forward(x...)[0]
+}
+
+// Forward applies the layer to x.
+func (l *ConvT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
+	if _, ok := l.Parameters["w"]; !ok {
+		l.Add("w", l.initw(x[0].Size(1)))
+	}
+
+	xp := []*variable.Variable[T]{x[0], l.Parameters["w"]}
+	if b, ok := l.Parameters["b"]; ok {
+		xp = append(xp, b)
+	}
+
+	return []*variable.Variable[T]{
+		l.conv(l.opts)(xp...),
+	}
+}
+
+// initw returns the weight for the input channels, scaled by the square root of the fan-in.
+func (l *ConvT[T]) initw(inChannels int) *variable.Variable[T] {
+	groups := max(l.opts.Groups, 1)
+	shape := append([]int{l.outChannels, inChannels / groups}, l.kernel...)
+
+	fanIn := 1
+	for _, s := range shape[1:] {
+		fanIn *= s
+	}
+
+	w := tensor.Randn(shape, l.s)
+	xavier := 1.0 / math.Sqrt(float64(fanIn))
+	return variable.From(tensor.Convert[T](tensor.MulC(xavier, w)))
+}
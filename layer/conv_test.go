@@ -0,0 +1,137 @@
+package layer_test
+
+import (
+	"fmt"
+	"testing"
+
+	F "github.com/itsubaki/autograd/function"
+	L "github.com/itsubaki/autograd/layer"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleConv1d() {
+	l := L.Conv1d[float64](2, []int{3},
+		L.WithConvSource(rand.Const()),
+		L.WithConvOpts(F.ConvOpts{Causal: true}),
+	)
+
+	x := variable.Ones[float64](1, 4, 10)
+	y := l.Forward(x)
+	fmt.Println(y[0].Shape())
+
+	for k, v := range l.Params().Seq2() {
+		fmt.Println(k, v.Shape())
+	}
+
+	// Output:
+	// [1 2 10]
+	// b [2]
+	// w [2 4 3]
+}
+
+func ExampleConv2d() {
+	l := L.Conv2d[float64](4, []int{3}, L.WithConvSource(rand.Const()))
+
+	x := variable.Ones[float64](2, 3, 5, 5)
+	y := l.Forward(x)
+	fmt.Println(y[0].Shape())
+
+	for k, v := range l.Params().Seq2() {
+		fmt.Println(k, v.Shape())
+	}
+
+	// Output:
+	// [2 4 3 3]
+	// b [4]
+	// w [4 3 3 3]
+}
+
+func ExampleConv2d_inChannels() {
+	l := L.Conv2d[float64](4, []int{3, 2},
+		L.WithConvOpts(F.ConvOpts{Groups: 2, Padding: []int{1}}),
+		L.WithInChannels(6),
+	)
+
+	for k, v := range l.Params().Seq2() {
+		fmt.Println(k, v.Shape())
+	}
+
+	y := l.Forward(variable.Ones[float64](1, 6, 4, 4))
+	fmt.Println(y[0].Shape())
+
+	// Output:
+	// b [4]
+	// w [4 3 3 2]
+	// [1 4 4 5]
+}
+
+func ExampleConv2d_nobias() {
+	l := L.Conv2d[float64](2, []int{1}, L.WithConvNoBias())
+
+	l.Forward(variable.Ones[float64](1, 1, 2, 2))
+	for _, v := range l.Params().Seq2() {
+		fmt.Println(v.Name)
+	}
+
+	// Output:
+	// w
+}
+
+func ExampleConv2d_backward() {
+	l := L.Conv2d[float64](2, []int{2})
+
+	y := l.Forward(variable.Ones[float64](1, 1, 3, 3))
+	y[0].Backward()
+
+	for _, v := range l.Params().Seq2() {
+		fmt.Println(v.Name, v.Grad)
+	}
+
+	// Output:
+	// b variable[2]([4 4])
+	// w variable[2 1 2 2]([4 4 4 4 4 4 4 4])
+}
+
+func ExampleConv3d() {
+	l := L.Conv3d[float64](4, []int{1, 3, 3}, L.WithConvOpts(F.ConvOpts{Padding: []int{0, 1, 1}}))
+
+	x := variable.Ones[float64](2, 1, 3, 8, 8)
+	y := l.Forward(x)
+	fmt.Println(y[0].Shape())
+
+	for k, v := range l.Params().Seq2() {
+		fmt.Println(k, v.Shape())
+	}
+
+	// Output:
+	// [2 4 3 8 8]
+	// b [4]
+	// w [4 1 1 3 3]
+}
+
+func TestConv2d(t *testing.T) {
+	opts := F.ConvOpts{Stride: []int{2}, Padding: []int{1}}
+	l := L.Conv2d[float64](3, []int{3}, L.WithConvSource(rand.Const()), L.WithConvOpts(opts))
+
+	x := variable.Randn([]int{2, 2, 5, 5}, rand.Const(1))
+	got := l.First(x)
+
+	p := l.Params()
+	want := F.Conv2d[float64](opts)(x, p["w"], p["b"])
+	if !tensor.IsCloseAll(got.Data, want.Data, 1e-12, 1e-12) {
+		t.Errorf("got=%v, want=%v", got.Data.Data, want.Data.Data)
+	}
+}
+
+func TestConv2d_panic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "len([1 2 3]) does not match 2 spatial axes" {
+			t.Errorf("got=%v", r)
+		}
+	}()
+
+	L.Conv2d[float64](1, []int{1, 2, 3})
+	t.Fail()
+}
@@ -1,26 +1,37 @@
 package layer
 
-import "github.com/itsubaki/autograd/variable"
+import (
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
 
 // Layer is the interface implemented by trainable layers.
-type Layer interface {
-	First(x ...*variable.Variable) *variable.Variable
-	Forward(x ...*variable.Variable) []*variable.Variable
-	Params() Parameters
+type Layer[T tensor.Float] interface {
+	First(x ...*variable.Variable[T]) *variable.Variable[T]
+	Forward(x ...*variable.Variable[T]) []*variable.Variable[T]
+	Params() Parameters[T]
+	Buffers() Parameters[T]
 	Cleargrads()
 }
 
+// Module is the interface implemented by collections of parameters and buffers, such as Parameters, layers and models.
+// Buffers are the state that is not trained but saved with the parameters, such as the running statistics of BatchNorm.
+type Module[T tensor.Float] interface {
+	Params() Parameters[T]
+	Buffers() Parameters[T]
+}
+
 // Layers is a named collection of layers.
-type Layers map[string]Layer
+type Layers[T tensor.Float] map[string]Layer[T]
 
 // Add stores a layer under the given name.
-func (l Layers) Add(name string, layer Layer) {
+func (l Layers[T]) Add(name string, layer Layer[T]) {
 	l[name] = layer
 }
 
 // Params returns the parameters of all layers in the collection.
-func (l Layers) Params() Parameters {
-	params := make(Parameters)
+func (l Layers[T]) Params() Parameters[T] {
+	params := make(Parameters[T])
 	for k := range l {
 		for _, p := range l[k].Params() {
 			params[k+"."+p.Name] = p
@@ -30,8 +41,20 @@ func (l Layers) Params() Parameters {
 	return params
 }
 
+// Buffers returns the buffers of all layers in the collection.
+func (l Layers[T]) Buffers() Parameters[T] {
+	buffers := make(Parameters[T])
+	for k := range l {
+		for _, b := range l[k].Buffers() {
+			buffers[k+"."+b.Name] = b
+		}
+	}
+
+	return buffers
+}
+
 // Cleargrads clears gradients for all layers in the collection.
-func (l Layers) Cleargrads() {
+func (l Layers[T]) Cleargrads() {
 	for k := range l {
 		l[k].Cleargrads()
 	}
@@ -10,72 +10,85 @@ import (
 )
 
 // OptionFunc configures a LinearT layer.
-type OptionFunc func(*LinearT)
+type OptionFunc func(*linearOpts)
+
+// linearOpts holds the options of a LinearT layer.
+type linearOpts struct {
+	inSize int
+	noBias bool
+	s      randv2.Source
+}
 
 // WithSource sets the random source used to initialize the weights.
 func WithSource(s randv2.Source) OptionFunc {
-	return func(l *LinearT) {
-		l.s = s
+	return func(o *linearOpts) {
+		o.s = s
 	}
 }
 
 // WithInSize initializes the weight matrix with the given input size.
 func WithInSize(inSize int) OptionFunc {
-	return func(l *LinearT) {
-		l.Add("w", initw(inSize, l.outSize, l.s))
+	return func(o *linearOpts) {
+		o.inSize = inSize
 	}
 }
 
 // WithNoBias removes the bias parameter from the layer.
 func WithNoBias() OptionFunc {
-	return func(l *LinearT) {
-		l.Delete("b")
+	return func(o *linearOpts) {
+		o.noBias = true
 	}
 }
 
-// Linear returns a new linear layer with the given output size.
-func Linear(outSize int, opts ...OptionFunc) *LinearT {
-	p := make(Parameters)
-	p.Add("b", variable.Zeros(1, outSize))
+// Linear returns a new linear layer with the given output size and parameters of type T.
+func Linear[T tensor.Float](outSize int, opts ...OptionFunc) *LinearT[T] {
+	var o linearOpts
+	for _, opt := range opts {
+		opt(&o)
+	}
 
-	l := &LinearT{
-		outSize:    outSize,
-		Parameters: p,
+	p := make(Parameters[T])
+	if !o.noBias {
+		p.Add("b", variable.Zeros[T](1, outSize))
 	}
 
-	for _, opt := range opts {
-		opt(l)
+	if o.inSize > 0 {
+		p.Add("w", initw[T](o.inSize, outSize, o.s))
 	}
 
-	return l
+	return &LinearT[T]{
+		outSize:    outSize,
+		s:          o.s,
+		Parameters: p,
+	}
 }
 
 // LinearT is a trainable linear layer.
-type LinearT struct {
+type LinearT[T tensor.Float] struct {
 	outSize int
 	s       randv2.Source
-	Parameters
+	Parameters[T]
 }
 
 // First applies the layer and returns the first output.
-func (l *LinearT) First(x ...*variable.Variable) *variable.Variable {
+func (l *LinearT[T]) First(x ...*variable.Variable[T]) *variable.Variable[T] {
 	return l.Forward(x...)[0]
 }
 
 // Forward applies the layer to x.
-func (l *LinearT) Forward(x ...*variable.Variable) []*variable.Variable {
+func (l *LinearT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
 	if _, ok := l.Parameters["w"]; !ok {
 		inSize := last(x[0].Shape())
-		l.Add("w", initw(inSize, l.outSize, l.s))
+		l.Add("w", initw[T](inSize, l.outSize, l.s))
 	}
 
-	return []*variable.Variable{
+	return []*variable.Variable[T]{
 		F.Linear(l.xparams(x[0])...),
 	}
 }
 
-func (l *LinearT) xparams(x *variable.Variable) []*variable.Variable {
-	xp := []*variable.Variable{x, l.Parameters["w"]}
+func (l *LinearT[T]) xparams(x *variable.Variable[T]) []*variable.Variable[T] {
+	xp := []*variable.Variable[T]{x, l.Parameters["w"]}
 	if b, ok := l.Parameters["b"]; ok {
 		xp = append(xp, b)
 	}
@@ -83,10 +96,10 @@ func (l *LinearT) xparams(x *variable.Variable) []*variable.Variable {
 	return xp
 }
 
-func initw(inSize, outSize int, s randv2.Source) *variable.Variable {
+func initw[T tensor.Float](inSize, outSize int, s randv2.Source) *variable.Variable[T] {
 	w := tensor.Randn([]int{inSize, outSize}, s)
 	xavier := 1.0 / math.Sqrt(float64(inSize))
-	return variable.From(tensor.MulC(xavier, w))
+	return variable.From(tensor.Convert[T](tensor.MulC(xavier, w)))
 }
 
 // last returns the last element of a slice of integers.
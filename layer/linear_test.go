@@ -9,7 +9,7 @@ import (
 )
 
 func ExampleLinear() {
-	l := L.Linear(5, L.WithSource(rand.Const()))
+	l := L.Linear[float64](5, L.WithSource(rand.Const()))
 
 	x := variable.New(
 		1, 2, 3,
@@ -29,7 +29,7 @@ func ExampleLinear() {
 }
 
 func ExampleLinear_inSize() {
-	l := L.Linear(5,
+	l := L.Linear[float64](5,
 		L.WithSource(rand.Const()),
 		L.WithInSize(3),
 	)
@@ -52,7 +52,7 @@ func ExampleLinear_inSize() {
 }
 
 func ExampleLinear_nobias() {
-	l := L.Linear(5, L.WithNoBias())
+	l := L.Linear[float64](5, L.WithNoBias())
 
 	x := variable.New(
 		1, 2, 3,
@@ -68,7 +68,7 @@ func ExampleLinear_nobias() {
 }
 
 func ExampleLinear_backward() {
-	l := L.Linear(5)
+	l := L.Linear[float64](5)
 
 	y := l.Forward(variable.New(
 		1, 2, 3,
@@ -96,7 +96,7 @@ func ExampleLinear_backward() {
 }
 
 func ExampleLinear_batch() {
-	l := L.Linear(5, L.WithSource(rand.Const()))
+	l := L.Linear[float64](5, L.WithSource(rand.Const()))
 
 	x := variable.New(
 		1, 2,
@@ -4,62 +4,82 @@ import (
 	randv2 "math/rand/v2"
 
 	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/tensor"
 	"github.com/itsubaki/autograd/variable"
 )
 
 // LSTMOptionFunc configures an LSTMT layer.
-type LSTMOptionFunc func(*LSTMT)
+type LSTMOptionFunc func(*lstmOpts)
+
+// lstmOpts holds the options of an LSTMT layer.
+type lstmOpts struct {
+	inSize int
+	s      randv2.Source
+}
 
 // WithLSTMSource sets the random source used to initialize the layer.
 func WithLSTMSource(s randv2.Source) LSTMOptionFunc {
-	return func(l *LSTMT) {
-		l.s = s
+	return func(o *lstmOpts) {
+		o.s = s
 	}
 }
 
-// LSTM returns a new LSTM layer.
-func LSTM(hiddenSize int, opts ...LSTMOptionFunc) *LSTMT {
-	lstm := &LSTMT{
-		Layers: make(Layers),
+// WithLSTMInSize initializes the input weights with the given input size.
+func WithLSTMInSize(inSize int) LSTMOptionFunc {
+	return func(o *lstmOpts) {
+		o.inSize = inSize
 	}
+}
 
+// LSTM returns a new LSTM layer.
+// The input weights are initialized lazily by the first forward pass unless WithLSTMInSize is given.
+func LSTM[T tensor.Float](hiddenSize int, opts ...LSTMOptionFunc) *LSTMT[T] {
+	var o lstmOpts
 	for _, opt := range opts {
-		opt(lstm)
+		opt(&o)
+	}
+
+	lstm := &LSTMT[T]{
+		Layers: make(Layers[T]),
+	}
+
+	xopts := []OptionFunc{WithSource(o.s)}
+	if o.inSize > 0 {
+		xopts = append(xopts, WithInSize(o.inSize))
 	}
 
-	lstm.Add("x2f", Linear(hiddenSize, WithSource(lstm.s)))
-	lstm.Add("x2i", Linear(hiddenSize, WithSource(lstm.s)))
-	lstm.Add("x2o", Linear(hiddenSize, WithSource(lstm.s)))
-	lstm.Add("x2u", Linear(hiddenSize, WithSource(lstm.s)))
-	lstm.Add("h2f", Linear(hiddenSize, WithSource(lstm.s), WithInSize(hiddenSize), WithNoBias()))
-	lstm.Add("h2i", Linear(hiddenSize, WithSource(lstm.s), WithInSize(hiddenSize), WithNoBias()))
-	lstm.Add("h2o", Linear(hiddenSize, WithSource(lstm.s), WithInSize(hiddenSize), WithNoBias()))
-	lstm.Add("h2u", Linear(hiddenSize, WithSource(lstm.s), WithInSize(hiddenSize), WithNoBias()))
+	lstm.Add("x2f", Linear[T](hiddenSize, xopts...))
+	lstm.Add("x2i", Linear[T](hiddenSize, xopts...))
+	lstm.Add("x2o", Linear[T](hiddenSize, xopts...))
+	lstm.Add("x2u", Linear[T](hiddenSize, xopts...))
+	lstm.Add("h2f", Linear[T](hiddenSize, WithSource(o.s), WithInSize(hiddenSize), WithNoBias()))
+	lstm.Add("h2i", Linear[T](hiddenSize, WithSource(o.s), WithInSize(hiddenSize), WithNoBias()))
+	lstm.Add("h2o", Linear[T](hiddenSize, WithSource(o.s), WithInSize(hiddenSize), WithNoBias()))
+	lstm.Add("h2u", Linear[T](hiddenSize, WithSource(o.s), WithInSize(hiddenSize), WithNoBias()))
 
 	return lstm
 }
 
 // LSTMT is an LSTM layer with persistent hidden and cell states.
-type LSTMT struct {
-	h, c *variable.Variable
-	s    randv2.Source
-	Layers
+type LSTMT[T tensor.Float] struct {
+	h, c *variable.Variable[T]
+	Layers[T]
 }
 
 // ResetState clears the hidden and cell states.
-func (l *LSTMT) ResetState() {
+func (l *LSTMT[T]) ResetState() {
 	l.h = nil
 	l.c = nil
 }
 
 // First applies the layer and returns the first output.
-func (l *LSTMT) First(x ...*variable.Variable) *variable.Variable {
+func (l *LSTMT[T]) First(x ...*variable.Variable[T]) *variable.Variable[T] {
 	return l.Forward(x...)[0]
 }
 
 // Forward applies the layer to x.
-func (l *LSTMT) Forward(x ...*variable.Variable) []*variable.Variable {
-	var f, i, o, u *variable.Variable
+func (l *LSTMT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
+	var f, i, o, u *variable.Variable[T]
 	if l.h == nil {
 		f = F.Sigmoid(l.Layers["x2f"].First(x...))
 		i = F.Sigmoid(l.Layers["x2i"].First(x...))
@@ -72,7 +92,7 @@ func (l *LSTMT) Forward(x ...*variable.Variable) []*variable.Variable {
 		u = F.Tanh(F.Add(l.Layers["x2u"].First(x...), l.Layers["h2u"].First(l.h)))
 	}
 
-	var cnew *variable.Variable
+	var cnew *variable.Variable[T]
 	if l.c == nil {
 		cnew = F.Mul(i, u) // i * u
 	} else {
@@ -82,7 +102,7 @@ func (l *LSTMT) Forward(x ...*variable.Variable) []*variable.Variable {
 	hnew := F.Mul(o, F.Tanh(cnew)) // o * tanh(cnew)
 
 	l.h, l.c = hnew, cnew
-	return []*variable.Variable{
+	return []*variable.Variable[T]{
 		hnew,
 	}
 }
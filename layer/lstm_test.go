@@ -9,7 +9,7 @@ import (
 )
 
 func ExampleLSTM() {
-	l := L.LSTM(2, L.WithLSTMSource(rand.Const()))
+	l := L.LSTM[float64](2, L.WithLSTMSource(rand.Const()))
 
 	x := variable.New(1.0).Reshape(1, 1)
 	y := l.Forward(x)
@@ -36,7 +36,7 @@ func ExampleLSTM() {
 }
 
 func ExampleLSTM_backward() {
-	l := L.LSTM(2, L.WithLSTMSource(rand.Const()))
+	l := L.LSTM[float64](2, L.WithLSTMSource(rand.Const()))
 
 	x := variable.New(1.0).Reshape(1, 1)
 	y := l.First(x)
@@ -65,7 +65,7 @@ func ExampleLSTM_backward() {
 }
 
 func ExampleLSTM_cleargrads() {
-	l := L.LSTM(3)
+	l := L.LSTM[float64](3)
 
 	x := variable.New(1.0).Reshape(1, 1)
 	y := l.First(x)
@@ -91,8 +91,31 @@ func ExampleLSTM_cleargrads() {
 	// x2u.w <nil>
 }
 
+func ExampleLSTM_inSize() {
+	l := L.LSTM[float64](2, L.WithLSTMInSize(3))
+
+	// the weights are created without a forward pass
+	for k, v := range l.Params().Seq2() {
+		fmt.Println(k, v.Shape())
+	}
+
+	// Output:
+	// h2f.w [2 2]
+	// h2i.w [2 2]
+	// h2o.w [2 2]
+	// h2u.w [2 2]
+	// x2f.b [1 2]
+	// x2f.w [3 2]
+	// x2i.b [1 2]
+	// x2i.w [3 2]
+	// x2o.b [1 2]
+	// x2o.w [3 2]
+	// x2u.b [1 2]
+	// x2u.w [3 2]
+}
+
 func ExampleLSTMT_ResetState() {
-	l := L.LSTM(3)
+	l := L.LSTM[float64](3)
 
 	x := variable.New(1.0).Reshape(1, 1)
 	l.Forward(x)   // set hidden state
@@ -119,7 +142,7 @@ func ExampleLSTMT_ResetState() {
 }
 
 func ExampleLSTM_batch() {
-	l := L.LSTM(2, L.WithLSTMSource(rand.Const()))
+	l := L.LSTM[float64](2, L.WithLSTMSource(rand.Const()))
 
 	x := variable.New(
 		1, 2,
@@ -0,0 +1,87 @@
+package layer
+
+import (
+	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+// NormOptionFunc configures a NormT layer.
+type NormOptionFunc func(*normOpts)
+
+// normOpts holds the options of a NormT layer.
+type normOpts struct {
+	eps float64
+}
+
+// WithNormEps sets the value added to the variance or the mean square for numerical stability. The default is 1e-5.
+func WithNormEps(eps float64) NormOptionFunc {
+	return func(o *normOpts) {
+		o.eps = eps
+	}
+}
+
+// LayerNorm returns a new layer normalization layer over the trailing axes with the given shape.
+// The scale gamma and the shift beta have the shape.
+func LayerNorm[T tensor.Float](shape []int, opts ...NormOptionFunc) *NormT[T] {
+	p := make(Parameters[T])
+	p.Add("gamma", variable.Ones[T](shape...))
+	p.Add("beta", variable.Zeros[T](shape...))
+	return newNorm(F.LayerNorm[T], shape, p, opts...)
+}
+
+// RMSNorm returns a new root mean square normalization layer over the trailing axes with the given shape.
+// The scale gamma has the shape.
+func RMSNorm[T tensor.Float](shape []int, opts ...NormOptionFunc) *NormT[T] {
+	p := make(Parameters[T])
+	p.Add("gamma", variable.Ones[T](shape...))
+	return newNorm(F.RMSNorm[T], shape, p, opts...)
+}
+
+// NormT is a normalization layer with a trainable scale and an optional shift.
+type NormT[T tensor.Float] struct {
+	norm func(axes []int, eps T) func(x ...*variable.Variable[T]) *variable.Variable[T]
+	axes []int
+	eps  T
+	Parameters[T]
+}
+
+// newNorm returns a new layer of the normalization over the trailing axes with the given shape.
+func newNorm[T tensor.Float](norm func(axes []int, eps T) func(x ...*variable.Variable[T]) *variable.Variable[T], shape []int, p Parameters[T], opts ...NormOptionFunc) *NormT[T] {
+	axes := make([]int, len(shape))
+	for i := range axes {
+		axes[i] = i - len(shape)
+	}
+
+	o := normOpts{
+		eps: 1e-5,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &NormT[T]{
+		norm:       norm,
+		axes:       axes,
+		eps:        T(o.eps),
+		Parameters: p,
+	}
+}
+
+// First applies the layer and returns the first output.
+func (l *NormT[T]) First(x ...*variable.Variable[T]) *variable.Variable[T] {
+	return l.Forward(x...)[0]
+}
+
+// Forward applies the layer to x.
+func (l *NormT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
+	xp := []*variable.Variable[T]{x[0], l.Parameters["gamma"]}
+	if b, ok := l.Parameters["beta"]; ok {
+		xp = append(xp, b)
+	}
+
+	return []*variable.Variable[T]{
+		l.norm(l.axes, l.eps)(xp...),
+	}
+}
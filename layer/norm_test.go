@@ -0,0 +1,106 @@
+package layer_test
+
+import (
+	"fmt"
+	"testing"
+
+	F "github.com/itsubaki/autograd/function"
+	L "github.com/itsubaki/autograd/layer"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleLayerNorm() {
+	l := L.LayerNorm[float64]([]int{3}, L.WithNormEps(0))
+
+	x := variable.New(
+		1, 2, 3,
+		2, 4, 6,
+	).Reshape(2, 3)
+
+	y := l.Forward(x)
+	fmt.Printf("%.4f\n", y[0].Data.Data)
+
+	for k, v := range l.Params().Seq2() {
+		fmt.Println(k, v)
+	}
+
+	// Output:
+	// [-1.2247 0.0000 1.2247 -1.2247 0.0000 1.2247]
+	// beta beta[3]([0 0 0])
+	// gamma gamma[3]([1 1 1])
+}
+
+func ExampleLayerNorm_backward() {
+	l := L.LayerNorm[float64]([]int{2, 2})
+
+	x := variable.Randn([]int{3, 2, 2}, rand.Const())
+	y := l.First(x)
+	F.Sum[float64]()(y).Backward()
+
+	for _, v := range l.Params().Seq2() {
+		fmt.Println(v.Name, v.Grad.Shape())
+	}
+
+	// Output:
+	// beta [2 2]
+	// gamma [2 2]
+}
+
+func ExampleRMSNorm() {
+	l := L.RMSNorm[float64]([]int{2}, L.WithNormEps(0))
+
+	x := variable.New(
+		3, 4,
+		-6, 8,
+	).Reshape(2, 2)
+
+	y := l.Forward(x)
+	fmt.Printf("%.4f\n", y[0].Data.Data)
+
+	for k, v := range l.Params().Seq2() {
+		fmt.Println(k, v)
+	}
+
+	// Output:
+	// [0.8485 1.1314 -0.8485 1.1314]
+	// gamma gamma[2]([1 1])
+}
+
+func TestNorm(t *testing.T) {
+	x := variable.Randn([]int{2, 5, 4}, rand.Const(1))
+
+	cases := []struct {
+		l    *L.NormT[float64]
+		f    func(x ...*variable.Variable[float64]) *variable.Variable[float64]
+		want int
+	}{
+		{L.LayerNorm[float64]([]int{4}), F.LayerNorm([]int{2}, 1e-5), 2},
+		{L.LayerNorm[float64]([]int{5, 4}), F.LayerNorm([]int{1, 2}, 1e-5), 2},
+		{L.RMSNorm[float64]([]int{4}), F.RMSNorm([]int{2}, 1e-5), 1},
+	}
+
+	for _, c := range cases {
+		// learnable scale and shift
+		for _, p := range c.l.Params().Seq2() {
+			p.Data = tensor.Randn(p.Shape(), rand.Const(2))
+		}
+
+		got := c.l.First(x)
+
+		xp := []*variable.Variable[float64]{x, c.l.Params()["gamma"]}
+		if b, ok := c.l.Params()["beta"]; ok {
+			xp = append(xp, b)
+		}
+
+		want := c.f(xp...)
+		if !tensor.IsCloseAll(got.Data, want.Data, 1e-12, 1e-12) {
+			t.Errorf("got=%v, want=%v", got.Data.Data, want.Data.Data)
+		}
+
+		if len(c.l.Params()) != c.want {
+			t.Errorf("len(params)=%d, want=%d", len(c.l.Params()), c.want)
+		}
+	}
+}
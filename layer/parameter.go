@@ -4,47 +4,58 @@ import (
 	"iter"
 	"slices"
 
+	"github.com/itsubaki/autograd/tensor"
 	"github.com/itsubaki/autograd/variable"
 )
 
-// Parameter is an alias for a trainable variable.
-type Parameter = *variable.Variable
-
 // Parameters is a named collection of parameters.
-type Parameters map[string]Parameter
+type Parameters[T tensor.Float] map[string]*variable.Variable[T]
 
 // Add stores a parameter under the given name and updates param.Name.
-func (p Parameters) Add(name string, param Parameter) {
+func (p Parameters[T]) Add(name string, param *variable.Variable[T]) {
 	param.Name = name
 	p[name] = param
 }
 
 // Delete removes the parameter with the given name.
-func (p Parameters) Delete(name string) {
+func (p Parameters[T]) Delete(name string) {
 	delete(p, name)
 }
 
 // Params returns the parameter collection itself.
-func (p Parameters) Params() Parameters {
+func (p Parameters[T]) Params() Parameters[T] {
 	return p
 }
 
+// Buffers returns nil, because a collection of parameters has no buffers.
+func (p Parameters[T]) Buffers() Parameters[T] {
+	return nil
+}
+
 // Cleargrads clears gradients for all parameters in the collection.
-func (p Parameters) Cleargrads() {
+func (p Parameters[T]) Cleargrads() {
 	for k := range p {
 		p[k].Cleargrad()
 	}
 }
 
+// SetRequiresGrad sets whether gradients are computed for all parameters in the collection.
+// Parameters that do not require gradients have no gradient after Backward, and are not updated by optimizers.
+func (p Parameters[T]) SetRequiresGrad(requires bool) {
+	for k := range p {
+		p[k].SetRequiresGrad(requires)
+	}
+}
+
 // Seq2 returns the parameters in key-sorted order.
-func (p Parameters) Seq2() iter.Seq2[string, Parameter] {
+func (p Parameters[T]) Seq2() iter.Seq2[string, *variable.Variable[T]] {
 	keys := make([]string, 0, len(p))
 	for k := range p {
 		keys = append(keys, k)
 	}
 	slices.Sort(keys)
 
-	return func(yield func(string, Parameter) bool) {
+	return func(yield func(string, *variable.Variable[T]) bool) {
 		for _, k := range keys {
 			if !yield(k, p[k]) {
 				return
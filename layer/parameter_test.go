@@ -8,7 +8,7 @@ import (
 )
 
 func ExampleParameters_Params() {
-	p := make(layer.Parameters)
+	p := make(layer.Parameters[float64])
 	p.Add("w", variable.New(1, 2))
 	p.Add("b", variable.New(3, 4))
 
@@ -25,7 +25,7 @@ func ExampleParameters_Cleargrads() {
 	v := variable.New(1, 2)
 	v.Grad = variable.New(3, 4)
 
-	p := make(layer.Parameters)
+	p := make(layer.Parameters[float64])
 	p.Add("w", v)
 	p.Cleargrads()
 
@@ -37,8 +37,29 @@ func ExampleParameters_Cleargrads() {
 	// w[2]([1 2]) <nil>
 }
 
+func ExampleParameters_SetRequiresGrad() {
+	p := make(layer.Parameters[float64])
+	p.Add("w", variable.New(1, 2))
+	p.Add("b", variable.New(3, 4))
+	p.SetRequiresGrad(false)
+
+	x := variable.New(5, 6)
+	y := variable.Add(variable.Mul(x, p["w"]), p["b"])
+	y.Backward()
+
+	for _, v := range p.Seq2() {
+		fmt.Println(v.RequiresGrad(), v.Grad)
+	}
+	fmt.Println(x.Grad)
+
+	// Output:
+	// false <nil>
+	// false <nil>
+	// variable[2]([1 2])
+}
+
 func ExampleParameters_Seq2_break() {
-	p := make(layer.Parameters)
+	p := make(layer.Parameters[float64])
 	p.Add("w", variable.New(1, 2))
 	p.Add("b", variable.New(3, 4))
 
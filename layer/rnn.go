@@ -4,63 +4,83 @@ import (
 	randv2 "math/rand/v2"
 
 	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/tensor"
 	"github.com/itsubaki/autograd/variable"
 )
 
 // RNNOptionFunc configures an RNNT layer.
-type RNNOptionFunc func(*RNNT)
+type RNNOptionFunc func(*rnnOpts)
+
+// rnnOpts holds the options of an RNNT layer.
+type rnnOpts struct {
+	inSize int
+	s      randv2.Source
+}
 
 // WithRNNSource sets the random source used to initialize the layer.
 func WithRNNSource(s randv2.Source) RNNOptionFunc {
-	return func(l *RNNT) {
-		l.s = s
+	return func(o *rnnOpts) {
+		o.s = s
 	}
 }
 
-// RNN returns a new recurrent neural network layer.
-func RNN(hiddenSize int, opts ...RNNOptionFunc) *RNNT {
-	rnn := &RNNT{
-		Layers: make(Layers),
+// WithRNNInSize initializes the input weights with the given input size.
+func WithRNNInSize(inSize int) RNNOptionFunc {
+	return func(o *rnnOpts) {
+		o.inSize = inSize
 	}
+}
 
+// RNN returns a new recurrent neural network layer.
+// The input weights are initialized lazily by the first forward pass unless WithRNNInSize is given.
+func RNN[T tensor.Float](hiddenSize int, opts ...RNNOptionFunc) *RNNT[T] {
+	var o rnnOpts
 	for _, opt := range opts {
-		opt(rnn)
+		opt(&o)
+	}
+
+	rnn := &RNNT[T]{
+		Layers: make(Layers[T]),
+	}
+
+	xopts := []OptionFunc{WithSource(o.s)}
+	if o.inSize > 0 {
+		xopts = append(xopts, WithInSize(o.inSize))
 	}
 
-	rnn.Add("x2h", Linear(hiddenSize, WithSource(rnn.s)))
-	rnn.Add("h2h", Linear(hiddenSize, WithSource(rnn.s), WithInSize(hiddenSize), WithNoBias()))
+	rnn.Add("x2h", Linear[T](hiddenSize, xopts...))
+	rnn.Add("h2h", Linear[T](hiddenSize, WithSource(o.s), WithInSize(hiddenSize), WithNoBias()))
 
 	return rnn
 }
 
 // RNNT is a simple recurrent neural network layer.
-type RNNT struct {
-	h *variable.Variable
-	s randv2.Source
-	Layers
+type RNNT[T tensor.Float] struct {
+	h *variable.Variable[T]
+	Layers[T]
 }
 
 // ResetState clears the hidden state.
-func (l *RNNT) ResetState() {
+func (l *RNNT[T]) ResetState() {
 	l.h = nil
 }
 
 // First applies the layer and returns the first output.
-func (l *RNNT) First(x ...*variable.Variable) *variable.Variable {
+func (l *RNNT[T]) First(x ...*variable.Variable[T]) *variable.Variable[T] {
 	return l.Forward(x...)[0]
 }
 
 // Forward applies the layer to x.
-func (l *RNNT) Forward(x ...*variable.Variable) []*variable.Variable {
+func (l *RNNT[T]) Forward(x ...*variable.Variable[T]) []*variable.Variable[T] {
 	if l.h == nil {
 		l.h = F.Tanh(l.Layers["x2h"].First(x...))
-		return []*variable.Variable{
+		return []*variable.Variable[T]{
 			l.h,
 		}
 	}
 
 	l.h = F.Tanh(F.Add(l.Layers["x2h"].First(x...), (l.Layers["h2h"].First(l.h))))
-	return []*variable.Variable{
+	return []*variable.Variable[T]{
 		l.h,
 	}
 }
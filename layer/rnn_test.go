@@ -9,7 +9,7 @@ import (
 )
 
 func ExampleRNN() {
-	l := L.RNN(2, L.WithRNNSource(rand.Const()))
+	l := L.RNN[float64](2, L.WithRNNSource(rand.Const()))
 
 	x := variable.New(1.0).Reshape(1, 1)
 	y := l.Forward(x)
@@ -27,7 +27,7 @@ func ExampleRNN() {
 }
 
 func ExampleRNN_backward() {
-	l := L.RNN(2, L.WithRNNSource(rand.Const()))
+	l := L.RNN[float64](2, L.WithRNNSource(rand.Const()))
 
 	x := variable.New(1.0).Reshape(1, 1)
 	y := l.First(x)
@@ -56,7 +56,7 @@ func ExampleRNN_backward() {
 }
 
 func ExampleRNN_cleargrads() {
-	l := L.RNN(3)
+	l := L.RNN[float64](3)
 
 	x := variable.New(1).Reshape(1, 1)
 	y := l.First(x)
@@ -73,8 +73,22 @@ func ExampleRNN_cleargrads() {
 	// x2h.w <nil>
 }
 
+func ExampleRNN_inSize() {
+	l := L.RNN[float64](2, L.WithRNNInSize(3))
+
+	// the weights are created without a forward pass
+	for k, v := range l.Params().Seq2() {
+		fmt.Println(k, v.Shape())
+	}
+
+	// Output:
+	// h2h.w [2 2]
+	// x2h.b [1 2]
+	// x2h.w [3 2]
+}
+
 func ExampleRNNT_ResetState() {
-	l := L.RNN(3)
+	l := L.RNN[float64](3)
 
 	x := variable.New(1).Reshape(1, 1)
 	l.Forward(x)   // set hidden state
@@ -92,7 +106,7 @@ func ExampleRNNT_ResetState() {
 }
 
 func ExampleRNN_batch() {
-	l := L.RNN(2, L.WithRNNSource(rand.Const()))
+	l := L.RNN[float64](2, L.WithRNNSource(rand.Const()))
 
 	x := variable.New(
 		1, 2,
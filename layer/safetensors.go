@@ -0,0 +1,230 @@
+package layer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"unsafe"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// Mapping maps a tensor in a safetensors file to a parameter.
+// It returns the parameter name and the tensor to load, such as the transpose of the stored tensor.
+// The tensor is in float64, and converted to the type of the parameter after mapping.
+// If ok is false, the tensor is skipped.
+type Mapping func(name string, v *tensor.Tensor[float64]) (param string, w *tensor.Tensor[float64], ok bool)
+
+// safetensor is an entry of the header of a safetensors file.
+type safetensor struct {
+	Dtype       string `json:"dtype"`
+	Shape       []int  `json:"shape"`
+	DataOffsets [2]int `json:"data_offsets"`
+}
+
+// SaveSafetensors writes the parameters and the buffers of m to w in the safetensors format
+// as F32 tensors if T is float32, or F64 tensors otherwise.
+// The header is a JSON object keyed by their names, followed by the little-endian data in key-sorted order.
+func SaveSafetensors[T tensor.Float](w io.Writer, m Module[T]) error {
+	params, err := state(m)
+	if err != nil {
+		return err
+	}
+
+	header := make(map[string]safetensor, len(params))
+	dtype := "F64"
+	if unsafe.Sizeof(T(0)) == 4 {
+		dtype = "F32"
+	}
+
+	var data bytes.Buffer
+	for name, p := range params.Seq2() {
+		begin := data.Len()
+		for _, a := range tensor.Contiguous(p.Data).Data {
+			if dtype == "F32" {
+				data.Write(binary.LittleEndian.AppendUint32(nil, math.Float32bits(float32(a))))
+				continue
+			}
+
+			data.Write(binary.LittleEndian.AppendUint64(nil, math.Float64bits(float64(a))))
+		}
+
+		header[name] = safetensor{
+			Dtype:       dtype,
+			Shape:       append([]int{}, p.Shape()...),
+			DataOffsets: [2]int{begin, data.Len()},
+		}
+	}
+
+	h, err := json.Marshal(header)
+	if err != nil {
+		return fmt.Errorf("marshal header: %w", err)
+	}
+
+	// pad the header with spaces so that the data is 8-byte aligned
+	if r := len(h) % 8; r != 0 {
+		h = append(h, bytes.Repeat([]byte(" "), 8-r)...)
+	}
+
+	var buf bytes.Buffer
+	buf.Write(binary.LittleEndian.AppendUint64(nil, uint64(len(h))))
+	buf.Write(h)
+	buf.Write(data.Bytes())
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// LoadSafetensors reads a safetensors file from r and restores the data of the parameters and the buffers of m.
+// F64, F32, F16, BF16, I64, I32, I16, I8, U8 and BOOL tensors are converted to float64, and then to T after mapping.
+// If mappings are given, they are applied in order to each tensor, which is loaded as the parameter they return.
+// Otherwise, tensors are loaded by name.
+// Like Load, every parameter and buffer must be loaded with the same shape and no other tensor may be loaded.
+func LoadSafetensors[T tensor.Float](r io.Reader, m Module[T], mapping ...Mapping) error {
+	params, err := state(m)
+	if err != nil {
+		return err
+	}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("read: %w", err)
+	}
+
+	if len(b) < 8 {
+		return fmt.Errorf("read header size: %w", io.ErrUnexpectedEOF)
+	}
+
+	n := binary.LittleEndian.Uint64(b)
+	if n > uint64(len(b)-8) {
+		return fmt.Errorf("header size=%d exceeds file size=%d", n, len(b))
+	}
+
+	var header map[string]json.RawMessage
+	if err := json.Unmarshal(b[8:8+n], &header); err != nil {
+		return fmt.Errorf("unmarshal header: %w", err)
+	}
+
+	data := b[8+n:]
+	loaded := make(map[string]*tensor.Tensor[float64], len(header))
+	for name, raw := range header {
+		if name == "__metadata__" {
+			continue
+		}
+
+		var t safetensor
+		if err := json.Unmarshal(raw, &t); err != nil {
+			return fmt.Errorf("unmarshal %q: %w", name, err)
+		}
+
+		v, err := t.decode(data)
+		if err != nil {
+			return fmt.Errorf("decode %q: %w", name, err)
+		}
+
+		key, ok := name, true
+		for _, m := range mapping {
+			if key, v, ok = m(key, v); !ok {
+				break
+			}
+		}
+
+		if !ok {
+			continue
+		}
+
+		if _, ok := loaded[key]; ok {
+			return fmt.Errorf("duplicate key %q", key)
+		}
+
+		loaded[key] = v
+	}
+
+	return restore(params, loaded)
+}
+
+// decode returns the tensor stored in data at the offsets of t.
+func (t safetensor) decode(data []byte) (*tensor.Tensor[float64], error) {
+	itemsize, decode, err := safetensorDecoder(t.Dtype)
+	if err != nil {
+		return nil, err
+	}
+
+	begin, end := t.DataOffsets[0], t.DataOffsets[1]
+	if begin < 0 || end < begin || end > len(data) {
+		return nil, fmt.Errorf("data_offsets=%v out of range for data size=%d", t.DataOffsets, len(data))
+	}
+
+	size, err := shapeSize(t.Shape, itemsize)
+	if err != nil {
+		return nil, err
+	}
+
+	if end-begin != size*itemsize {
+		return nil, fmt.Errorf("data size=%d does not match shape %v and dtype %v", end-begin, t.Shape, t.Dtype)
+	}
+
+	v := tensor.Zeros[float64](t.Shape...)
+
+	b := data[begin:end]
+	for i := range v.Data {
+		v.Data[i] = decode(b[i*itemsize : (i+1)*itemsize])
+	}
+
+	return v, nil
+}
+
+// safetensorDecoder returns the size in bytes and the decoder of an element of the safetensors dtype.
+func safetensorDecoder(dtype string) (int, func(b []byte) float64, error) {
+	le := binary.LittleEndian
+	switch dtype {
+	case "F64":
+		return 8, func(b []byte) float64 { return math.Float64frombits(le.Uint64(b)) }, nil
+	case "F32":
+		return 4, func(b []byte) float64 { return float64(math.Float32frombits(le.Uint32(b))) }, nil
+	case "F16":
+		return 2, func(b []byte) float64 { return float16(le.Uint16(b)) }, nil
+	case "BF16":
+		return 2, func(b []byte) float64 { return float64(math.Float32frombits(uint32(le.Uint16(b)) << 16)) }, nil
+	case "I64":
+		return 8, func(b []byte) float64 { return float64(int64(le.Uint64(b))) }, nil
+	case "I32":
+		return 4, func(b []byte) float64 { return float64(int32(le.Uint32(b))) }, nil
+	case "I16":
+		return 2, func(b []byte) float64 { return float64(int16(le.Uint16(b))) }, nil
+	case "I8":
+		return 1, func(b []byte) float64 { return float64(int8(b[0])) }, nil
+	case "U8", "BOOL":
+		return 1, func(b []byte) float64 { return float64(b[0]) }, nil
+	default:
+		return 0, nil, fmt.Errorf("unsupported dtype %q", dtype)
+	}
+}
+
+// float16 returns the IEEE 754 half-precision value h as float64.
+func float16(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1.0
+	}
+
+	exp := int(h>>10) & 0x1f
+	frac := float64(h & 0x3ff)
+
+	switch exp {
+	case 0:
+		// subnormal
+		return sign * math.Ldexp(frac, -24)
+	case 0x1f:
+		if frac != 0 {
+			return math.NaN()
+		}
+
+		return sign * math.Inf(1)
+	default:
+		return sign * math.Ldexp(1+frac/1024, exp-15)
+	}
+}
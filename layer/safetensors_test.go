@@ -0,0 +1,246 @@
+package layer_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/itsubaki/autograd/layer"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleLoadSafetensors() {
+	// testdata/linear.safetensors has the weight (2, 3) and bias (2) of torch.nn.Linear(3, 2)
+	f, err := os.Open("testdata/linear.safetensors")
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	l := layer.Linear[float64](2, layer.WithInSize(3))
+	torch := func(name string, v *tensor.Tensor[float64]) (string, *tensor.Tensor[float64], bool) {
+		switch name {
+		case "weight":
+			return "w", tensor.Transpose(v), true
+		case "bias":
+			return "b", tensor.Reshape(v, 1, -1), true
+		default:
+			return "", nil, false
+		}
+	}
+
+	if err := layer.LoadSafetensors(f, l.Params(), torch); err != nil {
+		panic(err)
+	}
+
+	for k, v := range l.Params().Seq2() {
+		fmt.Println(k, v)
+	}
+
+	x := variable.New(1, 1, 1).Reshape(1, 3)
+	fmt.Println(l.First(x))
+
+	// Output:
+	// b b[1 2]([0.5 -0.5])
+	// w w[3 2]([1 4 2 5 3 6])
+	// variable[1 2]([6.5 14.5])
+}
+
+func ExampleSaveSafetensors() {
+	p := make(layer.Parameters[float64])
+	p.Add("w", variable.New(1, 2, 3, 4).Reshape(2, 2))
+	p.Add("b", variable.New(5, 6))
+
+	var buf bytes.Buffer
+	if err := layer.SaveSafetensors(&buf, p); err != nil {
+		panic(err)
+	}
+
+	n := binary.LittleEndian.Uint64(buf.Bytes())
+	fmt.Println(strings.TrimSpace(string(buf.Bytes()[8 : 8+n])))
+
+	q := make(layer.Parameters[float64])
+	q.Add("w", variable.Zeros[float64](2, 2))
+	q.Add("b", variable.Zeros[float64](2))
+	if err := layer.LoadSafetensors(&buf, q); err != nil {
+		panic(err)
+	}
+
+	for _, v := range q.Seq2() {
+		fmt.Println(v)
+	}
+
+	// Output:
+	// {"b":{"dtype":"F64","shape":[2],"data_offsets":[0,16]},"w":{"dtype":"F64","shape":[2,2],"data_offsets":[16,48]}}
+	// b[2]([5 6])
+	// w[2 2]([1 2 3 4])
+}
+
+func ExampleSaveSafetensors_float32() {
+	p := make(layer.Parameters[float32])
+	p.Add("w", variable.Convert[float32](variable.New(1, 2, 3, 4).Reshape(2, 2)))
+
+	var buf bytes.Buffer
+	if err := layer.SaveSafetensors(&buf, p); err != nil {
+		panic(err)
+	}
+
+	n := binary.LittleEndian.Uint64(buf.Bytes())
+	fmt.Println(strings.TrimSpace(string(buf.Bytes()[8 : 8+n])))
+
+	q := make(layer.Parameters[float32])
+	q.Add("w", variable.Zeros[float32](2, 2))
+	if err := layer.LoadSafetensors(&buf, q); err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%T %v\n", q["w"].Data.Data, q["w"].Data.Data)
+
+	// Output:
+	// {"w":{"dtype":"F32","shape":[2,2],"data_offsets":[0,16]}}
+	// []float32 [1 2 3 4]
+}
+
+func TestLoadSafetensors_dtypes(t *testing.T) {
+	f, err := os.Open("testdata/dtypes.safetensors")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	p := layer.Parameters[float64]{
+		"f16":  variable.Zeros[float64](3),
+		"bf16": variable.Zeros[float64](2),
+		"i64":  variable.Zeros[float64](2),
+	}
+
+	if err := layer.LoadSafetensors(f, p); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]float64{
+		"f16":  {1.5, -2.0, 0.25},
+		"bf16": {1.0, -3.0},
+		"i64":  {-1, 7},
+	}
+
+	for k, v := range want {
+		if !tensor.IsCloseAll(p[k].Data, tensor.New([]int{len(v)}, v)) {
+			t.Errorf("%v: got=%v, want=%v", k, p[k].Data.Data, v)
+		}
+	}
+}
+
+func TestLoadSafetensors_lstm(t *testing.T) {
+	x := variable.New(1, 2).Reshape(1, 2)
+
+	l0 := layer.LSTM[float64](3, layer.WithLSTMSource(rand.Const(1)))
+	y0 := l0.First(x)
+
+	var buf bytes.Buffer
+	if err := layer.SaveSafetensors(&buf, l0.Params()); err != nil {
+		t.Fatal(err)
+	}
+
+	l1 := layer.LSTM[float64](3, layer.WithLSTMSource(rand.Const(2)))
+	l1.First(x)
+	l1.ResetState()
+
+	if err := layer.LoadSafetensors(&buf, l1.Params()); err != nil {
+		t.Fatal(err)
+	}
+
+	y1 := l1.First(x)
+	if !tensor.IsCloseAll(y0.Data, y1.Data) {
+		t.Errorf("got=%v, want=%v", y1.Data.Data, y0.Data.Data)
+	}
+}
+
+func TestLoadSafetensors_mismatch(t *testing.T) {
+	data, err := os.ReadFile("testdata/linear.safetensors")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		params  layer.Parameters[float64]
+		mapping []layer.Mapping
+		want    error
+	}{
+		{
+			params: layer.Parameters[float64]{"weight": variable.Zeros[float64](3, 2), "bias": variable.Zeros[float64](2)},
+			want:   layer.ErrShapeMismatch,
+		},
+		{
+			params: layer.Parameters[float64]{"weight": variable.Zeros[float64](2, 3)},
+			want:   layer.ErrUnexpectedKey,
+		},
+		{
+			params: layer.Parameters[float64]{"weight": variable.Zeros[float64](2, 3), "bias": variable.Zeros[float64](2), "extra": variable.Zeros[float64](1)},
+			want:   layer.ErrMissingKey,
+		},
+		{
+			params: layer.Parameters[float64]{"weight": variable.Zeros[float64](2, 3)},
+			mapping: []layer.Mapping{
+				func(name string, v *tensor.Tensor[float64]) (string, *tensor.Tensor[float64], bool) {
+					return name, v, name != "bias"
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		err := layer.LoadSafetensors(bytes.NewReader(data), c.params, c.mapping...)
+		if c.want == nil && err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		if c.want != nil && !errors.Is(err, c.want) {
+			t.Errorf("got=%v, want=%v", err, c.want)
+		}
+	}
+}
+
+func TestLoadSafetensors_invalid(t *testing.T) {
+	header := func(h string, data ...byte) []byte {
+		b := binary.LittleEndian.AppendUint64(nil, uint64(len(h)))
+		return append(append(b, h...), data...)
+	}
+
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"size", binary.LittleEndian.AppendUint64(nil, 100)},
+		{"json", header("{")},
+		{"entry", header(`{"w":1}`)},
+		{"dtype", header(`{"w":{"dtype":"C64","shape":[1],"data_offsets":[0,8]}}`, make([]byte, 8)...)},
+		{"offsets", header(`{"w":{"dtype":"F64","shape":[1],"data_offsets":[0,16]}}`, make([]byte, 8)...)},
+		{"shape", header(`{"w":{"dtype":"F64","shape":[2],"data_offsets":[0,8]}}`, make([]byte, 8)...)},
+		{"negative", header(`{"w":{"dtype":"F64","shape":[-1],"data_offsets":[0,0]}}`)},
+		{"overflow", header(`{"w":{"dtype":"F64","shape":[4611686018427387904,4],"data_offsets":[0,0]}}`)},
+	}
+
+	for _, c := range cases {
+		p := layer.Parameters[float64]{"w": variable.Zeros[float64](1)}
+		if err := layer.LoadSafetensors(bytes.NewReader(c.data), p); err == nil {
+			t.Errorf("%v: expected error", c.name)
+		}
+
+		// the tensors are validated even if they are skipped
+		skip := func(name string, v *tensor.Tensor[float64]) (string, *tensor.Tensor[float64], bool) {
+			return name, v, false
+		}
+
+		if err := layer.LoadSafetensors(bytes.NewReader(c.data), layer.Parameters[float64]{}, skip); err == nil {
+			t.Errorf("%v: expected error", c.name)
+		}
+	}
+}
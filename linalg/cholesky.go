@@ -0,0 +1,47 @@
+package linalg
+
+import (
+	"math"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// Cholesky returns the lower triangular matrix l such that a = l * l^T.
+// a must be symmetric positive definite. Only the lower triangle of a is read.
+// It panics if a is not positive definite.
+func Cholesky[T tensor.Float](a *tensor.Tensor[T]) *tensor.Tensor[T] {
+	m := square(a)
+	n := m.rows
+	return stack(m.batch, []int{n, n}, func(i int, o []T) {
+		copy(o, cholesky(m.at(i), n))
+	})
+}
+
+// cholesky returns the lower triangular factor of the (n x n) matrix a.
+func cholesky[T tensor.Float](a []T, n int) []T {
+	l := make([]T, n*n)
+	for j := range n {
+		d := a[j*n+j]
+		for k := range j {
+			d -= l[j*n+k] * l[j*n+k]
+		}
+
+		if d <= 0 || math.IsNaN(float64(d)) {
+			panic("matrix is not positive definite")
+		}
+
+		ljj := sqrt(d)
+		l[j*n+j] = ljj
+
+		for i := j + 1; i < n; i++ {
+			s := a[i*n+j]
+			for k := range j {
+				s -= l[i*n+k] * l[j*n+k]
+			}
+
+			l[i*n+j] = s / ljj
+		}
+	}
+
+	return l
+}
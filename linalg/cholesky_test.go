@@ -0,0 +1,69 @@
+package linalg_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/autograd/linalg"
+	"github.com/itsubaki/autograd/tensor"
+)
+
+func ExampleCholesky() {
+	a := tensor.New([]int{3, 3}, []float64{
+		4, 12, -16,
+		12, 37, -43,
+		-16, -43, 98,
+	})
+
+	l := linalg.Cholesky(a)
+	printRows(l)
+
+	// Output:
+	// [2 0 0]
+	// [6 1 0]
+	// [-8 5 3]
+}
+
+func ExampleCholesky_notPositiveDefinite() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println(r)
+			return
+		}
+
+		panic("unexpected panic for positive definite matrix")
+	}()
+
+	a := tensor.New([]int{2, 2}, []float64{
+		1, 2,
+		2, 1,
+	})
+
+	_ = linalg.Cholesky(a)
+	panic("unreachable")
+
+	// Output:
+	// matrix is not positive definite
+}
+
+func TestCholesky(t *testing.T) {
+	cases := []struct {
+		a *tensor.Tensor[float64]
+	}{
+		{spd(1, 1)},
+		{spd(4, 4)},
+		{spd(2, 3, 3)},
+	}
+
+	for _, c := range cases {
+		l := linalg.Cholesky(c.a)
+		got := tensor.MatMul(l, mT(l))
+		if !tensor.IsCloseAll(got, c.a) {
+			t.Errorf("got=%v, want=%v", got.Data, c.a.Data)
+		}
+
+		if !tensor.IsCloseAll(l, tensor.Tril(l)) {
+			t.Errorf("l is not lower triangular: %v", l.Data)
+		}
+	}
+}
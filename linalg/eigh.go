@@ -0,0 +1,123 @@
+package linalg
+
+import (
+	"sort"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// Eigh returns the eigenvalues and eigenvectors of the symmetric matrix a.
+// The eigenvalues w have shape (..., n) and are in ascending order.
+// The eigenvectors are the columns of v with shape (..., n, n), such that a = v * diag(w) * v^T.
+// The largest component in absolute value of each eigenvector is positive.
+func Eigh[T tensor.Float](a *tensor.Tensor[T]) (*tensor.Tensor[T], *tensor.Tensor[T]) {
+	m := square(a)
+	n := m.rows
+
+	ws := make([][]T, m.len())
+	vs := make([][]T, m.len())
+	for i := range m.len() {
+		ws[i], vs[i] = eigh(m.at(i), n)
+	}
+
+	w := stack(m.batch, []int{n}, func(i int, o []T) { copy(o, ws[i]) })
+	v := stack(m.batch, []int{n, n}, func(i int, o []T) { copy(o, vs[i]) })
+	return w, v
+}
+
+// eigh returns the eigenvalues and eigenvectors of the symmetric (n x n) matrix a using the cyclic Jacobi method.
+func eigh[T tensor.Float](a []T, n int) ([]T, []T) {
+	v := identity[T](n)
+	for range maxSweeps {
+		var off, total T
+		for i := range n {
+			for j := range n {
+				total += a[i*n+j] * a[i*n+j]
+				if i != j {
+					off += a[i*n+j] * a[i*n+j]
+				}
+			}
+		}
+
+		if e := eps[T](); off <= e*e*total {
+			break
+		}
+
+		for p := range n - 1 {
+			for q := p + 1; q < n; q++ {
+				apq := a[p*n+q]
+				if apq == 0 {
+					continue
+				}
+
+				// rotation angle that zeroes a[p, q]
+				theta := (a[q*n+q] - a[p*n+p]) / (2 * apq)
+				t := copysign(1, theta) / (abs(theta) + sqrt(theta*theta+1))
+				c := 1 / sqrt(t*t+1)
+				s := t * c
+
+				// a = J^T a J
+				for k := range n {
+					akp, akq := a[k*n+p], a[k*n+q]
+					a[k*n+p] = c*akp - s*akq
+					a[k*n+q] = s*akp + c*akq
+				}
+
+				for k := range n {
+					apk, aqk := a[p*n+k], a[q*n+k]
+					a[p*n+k] = c*apk - s*aqk
+					a[q*n+k] = s*apk + c*aqk
+				}
+
+				// v = v J
+				for k := range n {
+					vkp, vkq := v[k*n+p], v[k*n+q]
+					v[k*n+p] = c*vkp - s*vkq
+					v[k*n+q] = s*vkp + c*vkq
+				}
+			}
+		}
+	}
+
+	// sort in ascending order
+	idx := make([]int, n)
+	for i := range n {
+		idx[i] = i
+	}
+
+	sort.SliceStable(idx, func(i, j int) bool {
+		return a[idx[i]*n+idx[i]] < a[idx[j]*n+idx[j]]
+	})
+
+	w := make([]T, n)
+	vec := make([]T, n*n)
+	for c, k := range idx {
+		w[c] = a[k*n+k]
+		for r := range n {
+			vec[r*n+c] = v[r*n+k]
+		}
+	}
+
+	normalize(vec, n, n)
+	return w, vec
+}
+
+// normalize flips the sign of each column of the (rows x cols) matrix a so that its largest component in absolute value is positive.
+func normalize[T tensor.Float](a []T, rows, cols int) {
+	for c := range cols {
+		r := 0
+		for i := 1; i < rows; i++ {
+			if abs(a[i*cols+c]) > abs(a[r*cols+c]) {
+				r = i
+			}
+		}
+
+		if a[r*cols+c] >= 0 {
+			continue
+		}
+
+		for i := range rows {
+			a[i*cols+c] = -a[i*cols+c]
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package linalg_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/autograd/linalg"
+	"github.com/itsubaki/autograd/tensor"
+)
+
+func ExampleEigh() {
+	a := tensor.New([]int{2, 2}, []float64{
+		2, 1,
+		1, 2,
+	})
+
+	w, v := linalg.Eigh(a)
+	fmt.Println(round(w).Data)
+	printRows(v)
+
+	// Output:
+	// [1 3]
+	// [0.7071 0.7071]
+	// [-0.7071 0.7071]
+}
+
+func TestEigh(t *testing.T) {
+	cases := []struct {
+		a *tensor.Tensor[float64]
+	}{
+		{spd(1, 1)},
+		{spd(4, 4)},
+		{spd(2, 5, 5)},
+		{tensor.Eye[float64](3)},
+		{tensor.New([]int{3, 3}, []float64{0, 1, 0, 1, 0, 1, 0, 1, 0})},
+	}
+
+	for _, c := range cases {
+		w, v := linalg.Eigh(c.a)
+		got := tensor.MatMul(tensor.MatMul(v, diag(w)), mT(v))
+		if !tensor.IsCloseAll(got, c.a) {
+			t.Errorf("got=%v, want=%v", got.Data, c.a.Data)
+		}
+
+		n := w.Shape[w.NumDims()-1]
+		for _, row := range w.Seq2() {
+			for i := 1; i < n; i++ {
+				if row[i-1] > row[i] {
+					t.Errorf("eigenvalues are not ascending: %v", row)
+				}
+			}
+		}
+	}
+}
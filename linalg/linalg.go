@@ -0,0 +1,132 @@
+// Package linalg provides dense linear algebra on tensors of tensor.Float.
+// Every function treats the last two dimensions as a matrix and is batched over the leading dimensions like tensor.MatMul.
+package linalg
+
+import (
+	"fmt"
+	"math"
+	"unsafe"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// eps returns the relative tolerance used to detect convergence in the precision of T.
+func eps[T tensor.Float]() T {
+	var zero T
+	if unsafe.Sizeof(zero) == 4 {
+		return 1e-6
+	}
+
+	return 1e-15
+}
+
+// maxSweeps is the maximum number of Jacobi sweeps.
+const maxSweeps = 100
+
+// matrices is a batch of row-major matrices with the same shape.
+type matrices[T tensor.Float] struct {
+	batch      []int
+	rows, cols int
+	data       []T
+}
+
+// newMatrices returns v as a batch of matrices.
+func newMatrices[T tensor.Float](v *tensor.Tensor[T]) *matrices[T] {
+	ndim := v.NumDims()
+	if ndim < 2 {
+		panic(fmt.Sprintf("ndim=%v is less than 2", ndim))
+	}
+
+	return &matrices[T]{
+		batch: append([]int{}, v.Shape[:ndim-2]...),
+		rows:  v.Shape[ndim-2],
+		cols:  v.Shape[ndim-1],
+		data:  tensor.Contiguous(v).Data,
+	}
+}
+
+// square returns v as a batch of square matrices.
+func square[T tensor.Float](v *tensor.Tensor[T]) *matrices[T] {
+	m := newMatrices(v)
+	if m.rows != m.cols {
+		panic(fmt.Sprintf("shape %v is not square", v.Shape))
+	}
+
+	return m
+}
+
+// len returns the number of matrices in the batch.
+func (m *matrices[T]) len() int {
+	n := 1
+	for _, s := range m.batch {
+		n *= s
+	}
+
+	return n
+}
+
+// at returns a copy of the i-th matrix in the batch.
+func (m *matrices[T]) at(i int) []T {
+	size := m.rows * m.cols
+	return append([]T{}, m.data[i*size:(i+1)*size]...)
+}
+
+// stack returns a tensor with shape batch + shape.
+// f is called for each matrix in the batch with the slice of the output data it fills.
+func stack[T tensor.Float](batch, shape []int, f func(i int, o []T)) *tensor.Tensor[T] {
+	out := tensor.Zeros[T](append(append([]int{}, batch...), shape...)...)
+
+	size := 1
+	for _, s := range shape {
+		size *= s
+	}
+
+	n := 1
+	for _, s := range batch {
+		n *= s
+	}
+
+	for i := range n {
+		f(i, out.Data[i*size:(i+1)*size])
+	}
+
+	return out
+}
+
+// transpose returns the transpose of a (rows x cols) matrix.
+func transpose[T tensor.Float](a []T, rows, cols int) []T {
+	out := make([]T, rows*cols)
+	for i := range rows {
+		for j := range cols {
+			out[j*rows+i] = a[i*cols+j]
+		}
+	}
+
+	return out
+}
+
+// identity returns an (n x n) identity matrix.
+func identity[T tensor.Float](n int) []T {
+	out := make([]T, n*n)
+	for i := range n {
+		out[i*n+i] = 1
+	}
+
+	return out
+}
+
+func sqrt[T tensor.Float](x T) T {
+	return T(math.Sqrt(float64(x)))
+}
+
+func abs[T tensor.Float](x T) T {
+	return T(math.Abs(float64(x)))
+}
+
+func log[T tensor.Float](x T) T {
+	return T(math.Log(float64(x)))
+}
+
+func copysign[T tensor.Float](f, sign T) T {
+	return T(math.Copysign(float64(f), float64(sign)))
+}
@@ -0,0 +1,94 @@
+package linalg_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/itsubaki/autograd/linalg"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// round returns v rounded to 4 decimal places, with -0 normalized to 0.
+func round(v *tensor.Tensor[float64]) *tensor.Tensor[float64] {
+	return tensor.F(v, func(a float64) float64 {
+		return math.Round(a*1e4)/1e4 + 0
+	})
+}
+
+// printRows prints each row of v.
+func printRows(v *tensor.Tensor[float64]) {
+	for _, row := range round(v).Seq2() {
+		fmt.Println(row)
+	}
+}
+
+// spd returns a random symmetric positive definite matrix with the given shape.
+func spd(shape ...int) *tensor.Tensor[float64] {
+	a := tensor.Rand(shape, rand.Const())
+	n := shape[len(shape)-1]
+
+	// a * a^T + n * I
+	aat := tensor.MatMul(a, tensor.Transpose(a, axes(len(shape))...))
+	return tensor.Add(aat, tensor.MulC(float64(n), tensor.Eye[float64](n)))
+}
+
+// axes returns the axes that swap the last two dimensions of an ndim tensor.
+func axes(ndim int) []int {
+	out := make([]int, ndim)
+	for i := range ndim {
+		out[i] = i
+	}
+
+	out[ndim-2], out[ndim-1] = out[ndim-1], out[ndim-2]
+	return out
+}
+
+// mT returns the transpose of the last two dimensions of v.
+func mT(v *tensor.Tensor[float64]) *tensor.Tensor[float64] {
+	return tensor.Transpose(v, axes(v.NumDims())...)
+}
+
+// diag returns the matrices with v on their diagonal.
+func diag(v *tensor.Tensor[float64]) *tensor.Tensor[float64] {
+	n := v.Shape[v.NumDims()-1]
+	return tensor.Mul(tensor.Unsqueeze(v, -2), tensor.Eye[float64](n))
+}
+
+func TestFloat32(t *testing.T) {
+	a := spd(2, 3, 3)
+	a32 := tensor.Convert[float32](a)
+
+	cases := []struct {
+		name string
+		f64  []*tensor.Tensor[float64]
+		f32  []*tensor.Tensor[float32]
+	}{
+		{"solve", []*tensor.Tensor[float64]{linalg.Solve(a, mT(a))}, []*tensor.Tensor[float32]{linalg.Solve(a32, tensor.Convert[float32](mT(a)))}},
+		{"inv", []*tensor.Tensor[float64]{linalg.Inv(a)}, []*tensor.Tensor[float32]{linalg.Inv(a32)}},
+		{"det", []*tensor.Tensor[float64]{linalg.Det(a)}, []*tensor.Tensor[float32]{linalg.Det(a32)}},
+		{"cholesky", []*tensor.Tensor[float64]{linalg.Cholesky(a)}, []*tensor.Tensor[float32]{linalg.Cholesky(a32)}},
+		{"logdet", slice(linalg.LogDet(a)), slice(linalg.LogDet(a32))},
+		{"qr", slice(linalg.QR(a)), slice(linalg.QR(a32))},
+		{"eigh", slice(linalg.Eigh(a)), slice(linalg.Eigh(a32))},
+		{"lu", slice3(linalg.LU(a)), slice3(linalg.LU(a32))},
+		{"svd", slice3(linalg.SVD(a)), slice3(linalg.SVD(a32))},
+	}
+
+	for _, c := range cases {
+		for i := range c.f64 {
+			if !tensor.IsCloseAll(c.f32[i], tensor.Convert[float32](c.f64[i]), 1e-4, 1e-4) {
+				t.Errorf("%v: got=%v, want=%v", c.name, c.f32[i].Data, c.f64[i].Data)
+			}
+		}
+	}
+}
+
+func slice[T tensor.Float](v, w *tensor.Tensor[T]) []*tensor.Tensor[T] {
+	return []*tensor.Tensor[T]{v, w}
+}
+
+func slice3[T tensor.Float](u, v, w *tensor.Tensor[T]) []*tensor.Tensor[T] {
+	return []*tensor.Tensor[T]{u, v, w}
+}
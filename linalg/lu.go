@@ -0,0 +1,86 @@
+package linalg
+
+import "github.com/itsubaki/autograd/tensor"
+
+// LU returns the LU decomposition of a with partial pivoting, such that a = p * l * u.
+// p is a permutation matrix, l is unit lower triangular and u is upper triangular.
+func LU[T tensor.Float](a *tensor.Tensor[T]) (*tensor.Tensor[T], *tensor.Tensor[T], *tensor.Tensor[T]) {
+	m := square(a)
+	n := m.rows
+
+	lus := make([][]T, m.len())
+	perms := make([][]int, m.len())
+	for i := range m.len() {
+		lus[i] = m.at(i)
+		perms[i], _ = lu(lus[i], n)
+	}
+
+	shape := []int{n, n}
+	p := stack(m.batch, shape, func(b int, o []T) {
+		// a[perm[i], :] = (l * u)[i, :]
+		for i, r := range perms[b] {
+			o[r*n+i] = 1
+		}
+	})
+
+	l := stack(m.batch, shape, func(b int, o []T) {
+		for i := range n {
+			o[i*n+i] = 1
+			copy(o[i*n:i*n+i], lus[b][i*n:i*n+i])
+		}
+	})
+
+	u := stack(m.batch, shape, func(b int, o []T) {
+		for i := range n {
+			copy(o[i*n+i:(i+1)*n], lus[b][i*n+i:(i+1)*n])
+		}
+	})
+
+	return p, l, u
+}
+
+// lu factorizes the (n x n) matrix a in place into unit lower triangular l and upper triangular u with partial pivoting.
+// The strictly lower part of a holds l and the upper part holds u.
+// It returns the row permutation perm such that a[perm[i], :] = (l * u)[i, :] and the sign of the permutation.
+func lu[T tensor.Float](a []T, n int) ([]int, T) {
+	perm := make([]int, n)
+	for i := range n {
+		perm[i] = i
+	}
+
+	sign := T(1)
+	for k := range n {
+		// pivot
+		p, pmax := k, abs(a[k*n+k])
+		for i := k + 1; i < n; i++ {
+			if v := abs(a[i*n+k]); v > pmax {
+				p, pmax = i, v
+			}
+		}
+
+		if p != k {
+			for j := range n {
+				a[k*n+j], a[p*n+j] = a[p*n+j], a[k*n+j]
+			}
+
+			perm[k], perm[p] = perm[p], perm[k]
+			sign = -sign
+		}
+
+		if a[k*n+k] == 0 {
+			// singular
+			continue
+		}
+
+		// eliminate
+		for i := k + 1; i < n; i++ {
+			a[i*n+k] /= a[k*n+k]
+			lik := a[i*n+k]
+			for j := k + 1; j < n; j++ {
+				a[i*n+j] -= lik * a[k*n+j]
+			}
+		}
+	}
+
+	return perm, sign
+}
@@ -0,0 +1,75 @@
+package linalg_test
+
+import (
+	"testing"
+
+	"github.com/itsubaki/autograd/linalg"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+)
+
+func ExampleLU() {
+	a := tensor.New([]int{3, 3}, []float64{
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 10,
+	})
+
+	p, l, u := linalg.LU(a)
+	printRows(p)
+	printRows(l)
+	printRows(u)
+	printRows(tensor.MatMul(p, tensor.MatMul(l, u)))
+
+	// Output:
+	// [0 1 0]
+	// [0 0 1]
+	// [1 0 0]
+	// [1 0 0]
+	// [0.1429 1 0]
+	// [0.5714 0.5 1]
+	// [7 8 10]
+	// [0 0.8571 1.5714]
+	// [0 0 -0.5]
+	// [1 2 3]
+	// [4 5 6]
+	// [7 8 10]
+}
+
+func TestLU(t *testing.T) {
+	cases := []struct {
+		a *tensor.Tensor[float64]
+	}{
+		{tensor.Rand([]int{1, 1}, rand.Const())},
+		{tensor.Rand([]int{4, 4}, rand.Const())},
+		{tensor.Rand([]int{2, 3, 5, 5}, rand.Const())},
+		{tensor.New([]int{2, 2}, []float64{0, 1, 1, 0})},
+		{tensor.New([]int{2, 2}, []float64{1, 2, 2, 4})},
+	}
+
+	for _, c := range cases {
+		p, l, u := linalg.LU(c.a)
+		got := tensor.MatMul(p, tensor.MatMul(l, u))
+		if !tensor.IsCloseAll(got, c.a) {
+			t.Errorf("got=%v, want=%v", got.Data, c.a.Data)
+		}
+
+		if !tensor.IsCloseAll(l, tensor.Tril(l)) {
+			t.Errorf("l is not lower triangular: %v", l.Data)
+		}
+
+		if !tensor.IsCloseAll(mT(u), tensor.Tril(mT(u))) {
+			t.Errorf("u is not upper triangular: %v", u.Data)
+		}
+	}
+}
+
+func TestLU_notSquare(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic")
+		}
+	}()
+
+	_, _, _ = linalg.LU(tensor.Zeros[float64](2, 3))
+}
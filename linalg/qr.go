@@ -0,0 +1,125 @@
+package linalg
+
+import "github.com/itsubaki/autograd/tensor"
+
+// QR returns the reduced QR decomposition of a (..., m, n), such that a = q * r.
+// q has shape (..., m, k) with orthonormal columns and r has shape (..., k, n) and is upper triangular, where k = min(m, n).
+// The diagonal of r is non-negative.
+func QR[T tensor.Float](a *tensor.Tensor[T]) (*tensor.Tensor[T], *tensor.Tensor[T]) {
+	mat := newMatrices(a)
+	m, n := mat.rows, mat.cols
+	k := min(m, n)
+
+	qs := make([][]T, mat.len())
+	rs := make([][]T, mat.len())
+	for i := range mat.len() {
+		qs[i], rs[i] = qr(mat.at(i), m, n)
+	}
+
+	q := stack(mat.batch, []int{m, k}, func(i int, o []T) { copy(o, qs[i]) })
+	r := stack(mat.batch, []int{k, n}, func(i int, o []T) { copy(o, rs[i]) })
+	return q, r
+}
+
+// qr returns the reduced QR decomposition of the (m x n) matrix a using Householder reflections.
+// q is (m x k) and r is (k x n), where k = min(m, n).
+func qr[T tensor.Float](a []T, m, n int) ([]T, []T) {
+	k := min(m, n)
+
+	// householder vectors
+	vs := make([][]T, k)
+	for j := range k {
+		v := make([]T, m)
+		var norm T
+		for i := j; i < m; i++ {
+			v[i] = a[i*n+j]
+			norm += v[i] * v[i]
+		}
+
+		norm = sqrt(norm)
+		if norm == 0 {
+			continue
+		}
+
+		// v = x + sign(x0) * |x| * e0
+		if v[j] >= 0 {
+			v[j] += norm
+		} else {
+			v[j] -= norm
+		}
+
+		var vv T
+		for i := j; i < m; i++ {
+			vv += v[i] * v[i]
+		}
+
+		// a = (I - 2 v v^T / v^T v) a
+		for c := j; c < n; c++ {
+			var dot T
+			for i := j; i < m; i++ {
+				dot += v[i] * a[i*n+c]
+			}
+
+			f := 2 * dot / vv
+			for i := j; i < m; i++ {
+				a[i*n+c] -= f * v[i]
+			}
+		}
+
+		vs[j] = v
+	}
+
+	// q = H_0 H_1 ... H_{k-1} I[:, :k]
+	q := make([]T, m*k)
+	for i := range k {
+		q[i*k+i] = 1
+	}
+
+	for j := k - 1; j >= 0; j-- {
+		v := vs[j]
+		if v == nil {
+			continue
+		}
+
+		var vv T
+		for i := j; i < m; i++ {
+			vv += v[i] * v[i]
+		}
+
+		for c := range k {
+			var dot T
+			for i := j; i < m; i++ {
+				dot += v[i] * q[i*k+c]
+			}
+
+			f := 2 * dot / vv
+			for i := j; i < m; i++ {
+				q[i*k+c] -= f * v[i]
+			}
+		}
+	}
+
+	r := make([]T, k*n)
+	for i := range k {
+		for c := i; c < n; c++ {
+			r[i*n+c] = a[i*n+c]
+		}
+	}
+
+	// make the diagonal of r non-negative
+	for i := range k {
+		if r[i*n+i] >= 0 {
+			continue
+		}
+
+		for c := i; c < n; c++ {
+			r[i*n+c] = -r[i*n+c]
+		}
+
+		for row := range m {
+			q[row*k+i] = -q[row*k+i]
+		}
+	}
+
+	return q, r
+}
@@ -0,0 +1,58 @@
+package linalg_test
+
+import (
+	"testing"
+
+	"github.com/itsubaki/autograd/linalg"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+)
+
+func ExampleQR() {
+	a := tensor.New([]int{3, 2}, []float64{
+		3, 1,
+		4, 2,
+		0, 2,
+	})
+
+	q, r := linalg.QR(a)
+	printRows(q)
+	printRows(r)
+
+	// Output:
+	// [0.6 -0.1569]
+	// [0.8 0.1177]
+	// [0 0.9806]
+	// [5 2.2]
+	// [0 2.0396]
+}
+
+func TestQR(t *testing.T) {
+	cases := []struct {
+		a *tensor.Tensor[float64]
+	}{
+		{tensor.Rand([]int{4, 4}, rand.Const())},
+		{tensor.Rand([]int{5, 3}, rand.Const())},
+		{tensor.Rand([]int{3, 5}, rand.Const())},
+		{tensor.Rand([]int{2, 4, 3}, rand.Const())},
+		{tensor.Zeros[float64](3, 2)},
+	}
+
+	for _, c := range cases {
+		q, r := linalg.QR(c.a)
+		got := tensor.MatMul(q, r)
+		if !tensor.IsCloseAll(got, c.a) {
+			t.Errorf("got=%v, want=%v", got.Data, c.a.Data)
+		}
+
+		if !tensor.IsCloseAll(mT(r), tensor.Tril(mT(r))) {
+			t.Errorf("r is not upper triangular: %v", r.Data)
+		}
+
+		k := min(c.a.Shape[c.a.NumDims()-2], c.a.Shape[c.a.NumDims()-1])
+		qtq := tensor.MatMul(mT(q), q)
+		if !tensor.IsCloseAll(qtq, tensor.BroadcastTo(tensor.Eye[float64](k), qtq.Shape...)) {
+			t.Errorf("q is not orthonormal: %v", qtq.Data)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// Solve returns the solution x of a * x = b.
+// a has shape (..., n, n) and b has shape (..., n, k). The batch dimensions are broadcast.
+// It panics if a is singular.
+func Solve[T tensor.Float](a, b *tensor.Tensor[T]) *tensor.Tensor[T] {
+	if a.NumDims() < 2 || b.NumDims() < 2 {
+		panic(fmt.Sprintf("shapes %v and %v must have at least 2 dimensions", a.Shape, b.Shape))
+	}
+
+	a, b = tensor.Broadcast(a, b, 2)
+	ma, mb := square(a), newMatrices(b)
+	if ma.rows != mb.rows {
+		panic(fmt.Sprintf("shapes %v and %v are not aligned for solve", a.Shape, b.Shape))
+	}
+
+	n, k := ma.rows, mb.cols
+	return stack(ma.batch, []int{n, k}, func(i int, o []T) {
+		copy(o, solve(ma.at(i), mb.at(i), n, k))
+	})
+}
+
+// Inv returns the inverse of a.
+// It panics if a is singular.
+func Inv[T tensor.Float](a *tensor.Tensor[T]) *tensor.Tensor[T] {
+	m := square(a)
+	n := m.rows
+	return stack(m.batch, []int{n, n}, func(i int, o []T) {
+		copy(o, solve(m.at(i), identity[T](n), n, n))
+	})
+}
+
+// Det returns the determinant of a.
+func Det[T tensor.Float](a *tensor.Tensor[T]) *tensor.Tensor[T] {
+	m := square(a)
+	n := m.rows
+	return stack(m.batch, nil, func(i int, o []T) {
+		a := m.at(i)
+		_, sign := lu(a, n)
+
+		det := sign
+		for j := range n {
+			det *= a[j*n+j]
+		}
+
+		o[0] = det
+	})
+}
+
+// LogDet returns the sign and the natural logarithm of the absolute value of the determinant of a.
+// For a singular matrix, the sign is 0 and the logarithm is -Inf.
+func LogDet[T tensor.Float](a *tensor.Tensor[T]) (*tensor.Tensor[T], *tensor.Tensor[T]) {
+	m := square(a)
+	n := m.rows
+
+	signs := make([]T, m.len())
+	logdet := stack(m.batch, nil, func(i int, o []T) {
+		a := m.at(i)
+		_, sign := lu(a, n)
+
+		var sum T
+		for j := range n {
+			d := a[j*n+j]
+			if d == 0 {
+				sign, sum = 0, T(math.Inf(-1))
+				break
+			}
+
+			if d < 0 {
+				sign = -sign
+			}
+
+			sum += log(abs(d))
+		}
+
+		signs[i], o[0] = sign, sum
+	})
+
+	sign := stack(m.batch, nil, func(i int, o []T) {
+		o[0] = signs[i]
+	})
+
+	return sign, logdet
+}
+
+// solve returns the solution x of a * x = b for an (n x n) matrix a and an (n x k) matrix b.
+func solve[T tensor.Float](a, b []T, n, k int) []T {
+	perm, _ := lu(a, n)
+	for i := range n {
+		if a[i*n+i] == 0 {
+			panic("matrix is singular")
+		}
+	}
+
+	// permute
+	x := make([]T, n*k)
+	for i, r := range perm {
+		copy(x[i*k:(i+1)*k], b[r*k:(r+1)*k])
+	}
+
+	// forward substitution: l * y = b
+	for i := range n {
+		for j := range i {
+			lij := a[i*n+j]
+			for c := range k {
+				x[i*k+c] -= lij * x[j*k+c]
+			}
+		}
+	}
+
+	// back substitution: u * x = y
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			uij := a[i*n+j]
+			for c := range k {
+				x[i*k+c] -= uij * x[j*k+c]
+			}
+		}
+
+		uii := a[i*n+i]
+		for c := range k {
+			x[i*k+c] /= uii
+		}
+	}
+
+	return x
+}
@@ -0,0 +1,166 @@
+package linalg_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/itsubaki/autograd/linalg"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+)
+
+func ExampleSolve() {
+	a := tensor.New([]int{2, 2}, []float64{
+		3, 1,
+		1, 2,
+	})
+	b := tensor.New([]int{2, 1}, []float64{
+		9,
+		8,
+	})
+
+	x := linalg.Solve(a, b)
+	printRows(x)
+
+	// Output:
+	// [2]
+	// [3]
+}
+
+func ExampleSolve_singular() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println(r)
+			return
+		}
+
+		panic("unexpected panic for non-singular matrix")
+	}()
+
+	a := tensor.New([]int{2, 2}, []float64{
+		1, 2,
+		2, 4,
+	})
+
+	_ = linalg.Solve(a, tensor.Ones[float64](2, 1))
+	panic("unreachable")
+
+	// Output:
+	// matrix is singular
+}
+
+func ExampleInv() {
+	a := tensor.New([]int{2, 2}, []float64{
+		4, 7,
+		2, 6,
+	})
+
+	printRows(linalg.Inv(a))
+
+	// Output:
+	// [0.6 -0.7]
+	// [-0.2 0.4]
+}
+
+func ExampleDet() {
+	a := tensor.New([]int{2, 2, 2}, []float64{
+		1, 2,
+		3, 4,
+
+		2, 0,
+		0, 3,
+	})
+
+	fmt.Println(round(linalg.Det(a)).Data)
+
+	// Output:
+	// [-2 6]
+}
+
+func ExampleLogDet() {
+	a := tensor.New([]int{3, 2, 2}, []float64{
+		1, 2,
+		3, 4,
+
+		2, 0,
+		0, 3,
+
+		1, 2,
+		2, 4,
+	})
+
+	sign, logdet := linalg.LogDet(a)
+	fmt.Println(sign.Data)
+	fmt.Println(round(logdet).Data[:2], math.IsInf(logdet.Data[2], -1))
+	fmt.Printf("%.4f %.4f\n", math.Log(2), math.Log(6))
+
+	// Output:
+	// [-1 1 0]
+	// [0.6931 1.7918] true
+	// 0.6931 1.7918
+}
+
+func TestSolve(t *testing.T) {
+	cases := []struct {
+		a, b *tensor.Tensor[float64]
+	}{
+		{spd(3, 3), tensor.Rand([]int{3, 2}, rand.Const())},
+		{tensor.Rand([]int{4, 4}, rand.Const()), tensor.Rand([]int{4, 1}, rand.Const())},
+		{spd(2, 3, 3), tensor.Rand([]int{3, 4}, rand.Const())},
+		{spd(3, 3), tensor.Rand([]int{2, 3, 4}, rand.Const())},
+	}
+
+	for _, c := range cases {
+		x := linalg.Solve(c.a, c.b)
+		got := tensor.MatMul(c.a, x)
+		want := tensor.BroadcastTo(c.b, got.Shape...)
+		if !tensor.IsCloseAll(got, want) {
+			t.Errorf("got=%v, want=%v", got.Data, want.Data)
+		}
+	}
+}
+
+func TestInv(t *testing.T) {
+	cases := []struct {
+		a *tensor.Tensor[float64]
+	}{
+		{spd(3, 3)},
+		{tensor.Rand([]int{2, 4, 4}, rand.Const())},
+	}
+
+	for _, c := range cases {
+		inv := linalg.Inv(c.a)
+		got := tensor.MatMul(c.a, inv)
+		n := c.a.Shape[c.a.NumDims()-1]
+		if !tensor.IsCloseAll(got, tensor.BroadcastTo(tensor.Eye[float64](n), got.Shape...)) {
+			t.Errorf("got=%v", got.Data)
+		}
+	}
+}
+
+func TestDet(t *testing.T) {
+	cases := []struct {
+		a *tensor.Tensor[float64]
+	}{
+		{spd(3, 3)},
+		{tensor.Rand([]int{2, 4, 4}, rand.Const())},
+	}
+
+	for _, c := range cases {
+		// det(a) = sign * exp(logdet)
+		det := linalg.Det(c.a)
+		sign, logdet := linalg.LogDet(c.a)
+		got := tensor.Mul(sign, tensor.Exp(logdet))
+		if !tensor.IsCloseAll(got, det) {
+			t.Errorf("got=%v, want=%v", got.Data, det.Data)
+		}
+
+		// |det(a)| = prod(s)
+		_, s, _ := linalg.SVD(c.a)
+		abs := tensor.Reduce(s, 1.0, func(acc, v float64) float64 { return acc * v }, -1)
+		if !tensor.IsCloseAll(abs, tensor.F(det, math.Abs)) {
+			t.Errorf("got=%v, want=%v", abs.Data, det.Data)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package linalg
+
+import (
+	"sort"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// SVD returns the reduced singular value decomposition of a (..., m, n), such that a = u * diag(s) * vt.
+// u has shape (..., m, k), s has shape (..., k) and vt has shape (..., k, n), where k = min(m, n).
+// The singular values are in descending order, and the largest component in absolute value of each column of u is positive.
+func SVD[T tensor.Float](a *tensor.Tensor[T]) (*tensor.Tensor[T], *tensor.Tensor[T], *tensor.Tensor[T]) {
+	mat := newMatrices(a)
+	m, n := mat.rows, mat.cols
+	k := min(m, n)
+
+	us := make([][]T, mat.len())
+	ss := make([][]T, mat.len())
+	vts := make([][]T, mat.len())
+	for i := range mat.len() {
+		if m >= n {
+			us[i], ss[i], vts[i] = svd(mat.at(i), m, n)
+			continue
+		}
+
+		// a^T = u' s v'^T, a = v' s u'^T
+		ut, s, vtt := svd(transpose(mat.at(i), m, n), n, m)
+		us[i], ss[i], vts[i] = transpose(vtt, k, m), s, transpose(ut, n, k)
+	}
+
+	u := stack(mat.batch, []int{m, k}, func(i int, o []T) { copy(o, us[i]) })
+	s := stack(mat.batch, []int{k}, func(i int, o []T) { copy(o, ss[i]) })
+	vt := stack(mat.batch, []int{k, n}, func(i int, o []T) { copy(o, vts[i]) })
+	return u, s, vt
+}
+
+// svd returns the reduced singular value decomposition of the (m x n) matrix a with m >= n using the one-sided Jacobi method.
+// u is (m x n), s is (n) and vt is (n x n).
+func svd[T tensor.Float](a []T, m, n int) ([]T, []T, []T) {
+	v := identity[T](n)
+	for range maxSweeps {
+		var rotated bool
+		for p := range n - 1 {
+			for q := p + 1; q < n; q++ {
+				var alpha, beta, gamma T
+				for i := range m {
+					aip, aiq := a[i*n+p], a[i*n+q]
+					alpha += aip * aip
+					beta += aiq * aiq
+					gamma += aip * aiq
+				}
+
+				if gamma == 0 || abs(gamma) <= eps[T]()*sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+
+				// rotation angle that makes the columns p and q orthogonal
+				zeta := (beta - alpha) / (2 * gamma)
+				t := copysign(1, zeta) / (abs(zeta) + sqrt(zeta*zeta+1))
+				c := 1 / sqrt(t*t+1)
+				s := t * c
+
+				for i := range m {
+					aip, aiq := a[i*n+p], a[i*n+q]
+					a[i*n+p] = c*aip - s*aiq
+					a[i*n+q] = s*aip + c*aiq
+				}
+
+				for i := range n {
+					vip, viq := v[i*n+p], v[i*n+q]
+					v[i*n+p] = c*vip - s*viq
+					v[i*n+q] = s*vip + c*viq
+				}
+			}
+		}
+
+		if !rotated {
+			break
+		}
+	}
+
+	// singular values are the norms of the columns
+	sv := make([]T, n)
+	for j := range n {
+		var norm T
+		for i := range m {
+			norm += a[i*n+j] * a[i*n+j]
+		}
+
+		sv[j] = sqrt(norm)
+	}
+
+	// sort in descending order
+	idx := make([]int, n)
+	for i := range n {
+		idx[i] = i
+	}
+
+	sort.SliceStable(idx, func(i, j int) bool {
+		return sv[idx[i]] > sv[idx[j]]
+	})
+
+	var smax T
+	if n > 0 {
+		smax = sv[idx[0]]
+	}
+	tol := T(m) * smax * eps[T]()
+
+	u := make([]T, m*n)
+	s := make([]T, n)
+	vt := make([]T, n*n)
+	for c, k := range idx {
+		s[c] = sv[k]
+		for i := range n {
+			vt[c*n+i] = v[i*n+k]
+		}
+
+		if s[c] <= tol {
+			// completed below
+			continue
+		}
+
+		for i := range m {
+			u[i*n+c] = a[i*n+k] / s[c]
+		}
+	}
+
+	// complete the columns of u for zero singular values
+	for c := range n {
+		if s[c] > tol {
+			continue
+		}
+
+		complete(u, m, n, c)
+	}
+
+	// make the largest component of each column of u positive
+	for c := range n {
+		r := 0
+		for i := 1; i < m; i++ {
+			if abs(u[i*n+c]) > abs(u[r*n+c]) {
+				r = i
+			}
+		}
+
+		if u[r*n+c] >= 0 {
+			continue
+		}
+
+		for i := range m {
+			u[i*n+c] = -u[i*n+c]
+		}
+
+		for i := range n {
+			vt[c*n+i] = -vt[c*n+i]
+		}
+	}
+
+	return u, s, vt
+}
+
+// complete sets the column c of the (m x n) matrix u to a unit vector orthogonal to the other non-zero columns.
+func complete[T tensor.Float](u []T, m, n, c int) {
+	x := make([]T, m)
+	for e := range m {
+		clear(x)
+		x[e] = 1
+
+		// gram-schmidt
+		for j := range n {
+			if j == c {
+				continue
+			}
+
+			var dot T
+			for i := range m {
+				dot += u[i*n+j] * x[i]
+			}
+
+			for i := range m {
+				x[i] -= dot * u[i*n+j]
+			}
+		}
+
+		var norm T
+		for i := range m {
+			norm += x[i] * x[i]
+		}
+
+		norm = sqrt(norm)
+		if norm < sqrt(eps[T]()) {
+			continue
+		}
+
+		for i := range m {
+			u[i*n+c] = x[i] / norm
+		}
+
+		return
+	}
+}
@@ -0,0 +1,69 @@
+package linalg_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/autograd/linalg"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+)
+
+func ExampleSVD() {
+	a := tensor.New([]int{2, 3}, []float64{
+		3, 2, 2,
+		2, 3, -2,
+	})
+
+	u, s, vt := linalg.SVD(a)
+	printRows(u)
+	fmt.Println(round(s).Data)
+	printRows(vt)
+
+	// Output:
+	// [0.7071 0.7071]
+	// [0.7071 -0.7071]
+	// [5 3]
+	// [0.7071 0.7071 0]
+	// [0.2357 -0.2357 0.9428]
+}
+
+func TestSVD(t *testing.T) {
+	cases := []struct {
+		a *tensor.Tensor[float64]
+	}{
+		{tensor.Rand([]int{4, 4}, rand.Const())},
+		{tensor.Rand([]int{5, 3}, rand.Const())},
+		{tensor.Rand([]int{3, 5}, rand.Const())},
+		{tensor.Rand([]int{2, 4, 3}, rand.Const())},
+		{tensor.New([]int{3, 3}, []float64{1, 2, 3, 2, 4, 6, 1, 1, 1})},
+		{tensor.Zeros[float64](3, 2)},
+	}
+
+	for _, c := range cases {
+		u, s, vt := linalg.SVD(c.a)
+		got := tensor.MatMul(tensor.MatMul(u, diag(s)), vt)
+		if !tensor.IsCloseAll(got, c.a) {
+			t.Errorf("got=%v, want=%v", got.Data, c.a.Data)
+		}
+
+		k := s.Shape[s.NumDims()-1]
+		utu := tensor.MatMul(mT(u), u)
+		if !tensor.IsCloseAll(utu, tensor.BroadcastTo(tensor.Eye[float64](k), utu.Shape...)) {
+			t.Errorf("u is not orthonormal: %v", utu.Data)
+		}
+
+		vvt := tensor.MatMul(vt, mT(vt))
+		if !tensor.IsCloseAll(vvt, tensor.BroadcastTo(tensor.Eye[float64](k), vvt.Shape...)) {
+			t.Errorf("v is not orthonormal: %v", vvt.Data)
+		}
+
+		for _, row := range s.Seq2() {
+			for i := 1; i < k; i++ {
+				if row[i-1] < row[i] || row[i] < 0 {
+					t.Errorf("singular values are not descending: %v", row)
+				}
+			}
+		}
+	}
+}
@@ -2,15 +2,16 @@ package model
 
 import (
 	F "github.com/itsubaki/autograd/function"
+	"github.com/itsubaki/autograd/tensor"
 	"github.com/itsubaki/autograd/variable"
 )
 
 var (
-	_ Activation = F.ReLU
-	_ Activation = F.Sigmoid
-	_ Activation = F.Softmax(1)
-	_ Activation = F.Tanh
+	_ Activation[float64] = F.ReLU
+	_ Activation[float64] = F.Sigmoid
+	_ Activation[float64] = F.Softmax[float64](1)
+	_ Activation[float64] = F.Tanh
 )
 
 // Activation represents an activation function.
-type Activation func(x ...*variable.Variable) *variable.Variable
+type Activation[T tensor.Float] func(x ...*variable.Variable[T]) *variable.Variable[T]
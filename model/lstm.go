@@ -4,44 +4,59 @@ import (
 	randv2 "math/rand/v2"
 
 	L "github.com/itsubaki/autograd/layer"
+	"github.com/itsubaki/autograd/tensor"
 	"github.com/itsubaki/autograd/variable"
 )
 
 // LSTMOptionFunc configures an LSTM model.
-type LSTMOptionFunc func(*LSTM)
+type LSTMOptionFunc[T tensor.Float] func(*LSTM[T])
 
 // WithLSTMSource sets the random source used to initialize the model.
-func WithLSTMSource(s randv2.Source) LSTMOptionFunc {
-	return func(l *LSTM) {
+func WithLSTMSource[T tensor.Float](s randv2.Source) LSTMOptionFunc[T] {
+	return func(l *LSTM[T]) {
 		l.s = s
 	}
 }
 
+// WithLSTMInSize initializes the weights of the model with the given input size.
+func WithLSTMInSize[T tensor.Float](inSize int) LSTMOptionFunc[T] {
+	return func(l *LSTM[T]) {
+		l.inSize = inSize
+	}
+}
+
 // LSTM is an LSTM-based sequence model followed by a linear output layer.
-type LSTM struct {
-	s randv2.Source
-	Model
+type LSTM[T tensor.Float] struct {
+	inSize int
+	s      randv2.Source
+	Model[T]
 }
 
 // NewLSTM returns a new LSTM model.
-func NewLSTM(hiddenSize, outSize int, opts ...LSTMOptionFunc) *LSTM {
-	lstm := &LSTM{}
+// The input weights are initialized lazily by the first forward pass unless WithLSTMInSize is given.
+func NewLSTM[T tensor.Float](hiddenSize, outSize int, opts ...LSTMOptionFunc[T]) *LSTM[T] {
+	lstm := &LSTM[T]{}
 	for _, opt := range opts {
 		opt(lstm)
 	}
 
-	lstm.Add("lstm", L.LSTM(hiddenSize, L.WithLSTMSource(lstm.s)))
-	lstm.Add("linear", L.Linear(outSize, L.WithSource(lstm.s)))
+	lopts := []L.OptionFunc{L.WithSource(lstm.s)}
+	if lstm.inSize > 0 {
+		lopts = append(lopts, L.WithInSize(hiddenSize))
+	}
+
+	lstm.Add("lstm", L.LSTM[T](hiddenSize, L.WithLSTMSource(lstm.s), L.WithLSTMInSize(lstm.inSize)))
+	lstm.Add("linear", L.Linear[T](outSize, lopts...))
 	return lstm
 }
 
 // ResetState clears the hidden and cell states of the LSTM layer.
-func (m *LSTM) ResetState() {
-	m.L["lstm"].(*L.LSTMT).ResetState()
+func (m *LSTM[T]) ResetState() {
+	m.L["lstm"].(*L.LSTMT[T]).ResetState()
 }
 
 // Forward applies the model to x and returns the output.
-func (m *LSTM) Forward(x *variable.Variable) *variable.Variable {
+func (m *LSTM[T]) Forward(x *variable.Variable[T]) *variable.Variable[T] {
 	for _, name := range m.Layers {
 		x = m.L[name].First(x)
 	}
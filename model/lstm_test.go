@@ -1,27 +1,29 @@
 package model_test
 
 import (
+	"bytes"
 	"fmt"
 
+	L "github.com/itsubaki/autograd/layer"
 	"github.com/itsubaki/autograd/model"
 	"github.com/itsubaki/autograd/rand"
 	"github.com/itsubaki/autograd/variable"
 )
 
 func ExampleLSTM() {
-	m := model.NewLSTM(2, 3)
+	m := model.NewLSTM[float64](2, 3)
 
 	for _, name := range m.Layers {
 		fmt.Printf("%s %T\n", name, m.L[name])
 	}
 
 	// Output:
-	// lstm *layer.LSTMT
-	// linear *layer.LinearT
+	// lstm *layer.LSTMT[float64]
+	// linear *layer.LinearT[float64]
 }
 
 func ExampleLSTM_backward() {
-	m := model.NewLSTM(1, 1, model.WithLSTMSource(rand.Const()))
+	m := model.NewLSTM(1, 1, model.WithLSTMSource[float64](rand.Const()))
 
 	x := variable.New(
 		1, 2,
@@ -57,7 +59,7 @@ func ExampleLSTM_backward() {
 }
 
 func ExampleLSTM_ResetState() {
-	m := model.NewLSTM(1, 1)
+	m := model.NewLSTM[float64](1, 1)
 
 	x := variable.New(
 		1, 2,
@@ -89,7 +91,7 @@ func ExampleLSTM_ResetState() {
 }
 
 func ExampleLSTM_Params() {
-	m := model.NewLSTM(100, 1)
+	m := model.NewLSTM[float64](100, 1)
 
 	x := variable.New(
 		1, 2, 3,
@@ -118,7 +120,7 @@ func ExampleLSTM_Params() {
 }
 
 func ExampleLSTM_batch() {
-	m := model.NewLSTM(5, 1, model.WithLSTMSource(rand.Const()))
+	m := model.NewLSTM(5, 1, model.WithLSTMSource[float64](rand.Const()))
 
 	x := variable.New(
 		1, 2,
@@ -157,3 +159,26 @@ func ExampleLSTM_batch() {
 	// lstm.x2u.b [1 5]
 	// lstm.x2u.w [2 5]
 }
+
+func ExampleLSTM_checkpoint() {
+	m := model.NewLSTM(3, 1, model.WithLSTMSource[float64](rand.Const(1)))
+	x := variable.New(1, 2).Reshape(1, 2)
+	y := m.Forward(x)
+
+	var buf bytes.Buffer
+	if err := L.Save(&buf, m.Params()); err != nil {
+		panic(err)
+	}
+
+	// rebuild the model with the weights for the input size
+	restored := model.NewLSTM(3, 1, model.WithLSTMInSize[float64](2), model.WithLSTMSource[float64](rand.Const(2)))
+
+	if err := L.Load(&buf, restored.Params()); err != nil {
+		panic(err)
+	}
+
+	fmt.Println(y.Data.Data[0] == restored.Forward(x).Data.Data[0])
+
+	// Output:
+	// true
+}
@@ -6,58 +6,75 @@ import (
 
 	F "github.com/itsubaki/autograd/function"
 	L "github.com/itsubaki/autograd/layer"
+	"github.com/itsubaki/autograd/tensor"
 	"github.com/itsubaki/autograd/variable"
 )
 
 // MLPOpts holds configuration values for an MLP.
-type MLPOpts struct {
-	Activation Activation
+type MLPOpts[T tensor.Float] struct {
+	Activation Activation[T]
 	Source     randv2.Source
 }
 
 // MLPOptionFunc configures an MLP.
-type MLPOptionFunc func(*MLP)
+type MLPOptionFunc[T tensor.Float] func(*MLP[T])
 
 // WithMLPSource sets the random source used to initialize the model.
-func WithMLPSource(s randv2.Source) MLPOptionFunc {
-	return func(l *MLP) {
+func WithMLPSource[T tensor.Float](s randv2.Source) MLPOptionFunc[T] {
+	return func(l *MLP[T]) {
 		l.s = s
 	}
 }
 
 // WithMLPActivation sets the activation used between hidden layers.
-func WithMLPActivation(activation Activation) MLPOptionFunc {
-	return func(l *MLP) {
+func WithMLPActivation[T tensor.Float](activation Activation[T]) MLPOptionFunc[T] {
+	return func(l *MLP[T]) {
 		l.Activation = activation
 	}
 }
 
+// WithMLPInSize initializes the weights of the model with the given input size.
+func WithMLPInSize[T tensor.Float](inSize int) MLPOptionFunc[T] {
+	return func(l *MLP[T]) {
+		l.inSize = inSize
+	}
+}
+
 // MLP is a multilayer perceptron.
-type MLP struct {
-	Activation Activation
+type MLP[T tensor.Float] struct {
+	Activation Activation[T]
+	inSize     int
 	s          randv2.Source
-	Model
+	Model[T]
 }
 
 // NewMLP returns a new multilayer perceptron with the given layer sizes.
-func NewMLP(outSize []int, opts ...MLPOptionFunc) *MLP {
-	mlp := &MLP{
-		Activation: F.Sigmoid,
+// The weights are initialized lazily by the first forward pass unless WithMLPInSize is given.
+func NewMLP[T tensor.Float](outSize []int, opts ...MLPOptionFunc[T]) *MLP[T] {
+	mlp := &MLP[T]{
+		Activation: F.Sigmoid[T],
 	}
 
 	for _, opt := range opts {
 		opt(mlp)
 	}
 
+	inSize := mlp.inSize
 	for i := range outSize {
-		mlp.Add(fmt.Sprintf("linear[%d]", i), L.Linear(outSize[i], L.WithSource(mlp.s)))
+		opts := []L.OptionFunc{L.WithSource(mlp.s)}
+		if inSize > 0 {
+			opts = append(opts, L.WithInSize(inSize))
+			inSize = outSize[i]
+		}
+
+		mlp.Add(fmt.Sprintf("linear[%d]", i), L.Linear[T](outSize[i], opts...))
 	}
 
 	return mlp
 }
 
 // Forward applies the model to x and returns the output.
-func (m *MLP) Forward(x *variable.Variable) *variable.Variable {
+func (m *MLP[T]) Forward(x *variable.Variable[T]) *variable.Variable[T] {
 	last := len(m.Layers) - 1
 	for _, name := range m.Layers[:last] {
 		x = m.Activation(m.L[name].First(x))
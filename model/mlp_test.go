@@ -1,31 +1,36 @@
 package model_test
 
 import (
+	"bytes"
 	"fmt"
+	"os"
+	"strings"
 
 	F "github.com/itsubaki/autograd/function"
+	L "github.com/itsubaki/autograd/layer"
 	"github.com/itsubaki/autograd/model"
 	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
 	"github.com/itsubaki/autograd/variable"
 )
 
 func ExampleMLP() {
-	m := model.NewMLP([]int{1, 2, 3})
+	m := model.NewMLP[float64]([]int{1, 2, 3})
 
 	for _, name := range m.Layers {
 		fmt.Printf("%s %T\n", name, m.L[name])
 	}
 
 	// Output:
-	// linear[0] *layer.LinearT
-	// linear[1] *layer.LinearT
-	// linear[2] *layer.LinearT
+	// linear[0] *layer.LinearT[float64]
+	// linear[1] *layer.LinearT[float64]
+	// linear[2] *layer.LinearT[float64]
 }
 
 func ExampleMLP_backward() {
 	m := model.NewMLP([]int{5, 1},
-		model.WithMLPSource(rand.Const()),
-		model.WithMLPActivation(F.ReLU),
+		model.WithMLPSource[float64](rand.Const()),
+		model.WithMLPActivation[float64](F.ReLU),
 	)
 
 	x := variable.New(
@@ -48,8 +53,8 @@ func ExampleMLP_backward() {
 
 func ExampleMLP_cleargrads() {
 	m := model.NewMLP([]int{5, 1},
-		model.WithMLPSource(rand.Const()),
-		model.WithMLPActivation(F.ReLU),
+		model.WithMLPSource[float64](rand.Const()),
+		model.WithMLPActivation[float64](F.ReLU),
 	)
 
 	x := variable.New(
@@ -72,7 +77,7 @@ func ExampleMLP_cleargrads() {
 }
 
 func ExampleMLP_Params() {
-	m := model.NewMLP([]int{5, 1})
+	m := model.NewMLP[float64]([]int{5, 1})
 
 	x := variable.New(
 		1, 2,
@@ -90,10 +95,25 @@ func ExampleMLP_Params() {
 	// linear[1].w [5 1]
 }
 
+func ExampleWithMLPInSize() {
+	m := model.NewMLP([]int{5, 1}, model.WithMLPInSize[float64](2))
+
+	// the weights are created without a forward pass
+	for k, v := range m.Params().Seq2() {
+		fmt.Println(k, v.Shape())
+	}
+
+	// Output:
+	// linear[0].b [1 5]
+	// linear[0].w [2 5]
+	// linear[1].b [1 1]
+	// linear[1].w [5 1]
+}
+
 func ExampleMLP_batch() {
 	m := model.NewMLP([]int{5, 1},
-		model.WithMLPSource(rand.Const()),
-		model.WithMLPActivation(F.ReLU),
+		model.WithMLPSource[float64](rand.Const()),
+		model.WithMLPActivation[float64](F.ReLU),
 	)
 
 	x := variable.New(
@@ -123,3 +143,63 @@ func ExampleMLP_batch() {
 	// linear[1].b [1 1]
 	// linear[1].w [5 1]
 }
+
+func ExampleMLP_checkpoint() {
+	m := model.NewMLP([]int{5, 1}, model.WithMLPSource[float64](rand.Const(1)))
+	x := variable.New(1, 2).Reshape(1, 2)
+	y := m.Forward(x)
+
+	var buf bytes.Buffer
+	if err := L.Save(&buf, m.Params()); err != nil {
+		panic(err)
+	}
+
+	// rebuild the model with the weights for the input size
+	restored := model.NewMLP([]int{5, 1}, model.WithMLPInSize[float64](2), model.WithMLPSource[float64](rand.Const(2)))
+
+	if err := L.Load(&buf, restored.Params()); err != nil {
+		panic(err)
+	}
+
+	fmt.Println(y.Data.Data[0] == restored.Forward(x).Data.Data[0])
+
+	// Output:
+	// true
+}
+
+func ExampleMLP_safetensors() {
+	// testdata/mlp.safetensors has the parameters of
+	// torch.nn.Sequential(Linear(2, 3), Sigmoid(), Linear(3, 1))
+	f, err := os.Open("testdata/mlp.safetensors")
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	m := model.NewMLP([]int{3, 1}, model.WithMLPInSize[float64](2))
+	x := variable.New(1, 2).Reshape(1, 2)
+
+	// "0.weight" -> "linear[0].w"
+	torch := func(name string, v *tensor.Tensor[float64]) (string, *tensor.Tensor[float64], bool) {
+		index, param, _ := strings.Cut(name, ".")
+		index = map[string]string{"0": "0", "2": "1"}[index]
+
+		switch param {
+		case "weight":
+			return fmt.Sprintf("linear[%s].w", index), tensor.Transpose(v), true
+		case "bias":
+			return fmt.Sprintf("linear[%s].b", index), tensor.Reshape(v, 1, -1), true
+		default:
+			return "", nil, false
+		}
+	}
+
+	if err := L.LoadSafetensors(f, m.Params(), torch); err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%.4f\n", m.Forward(x).Data.Data)
+
+	// Output:
+	// [2.1214]
+}
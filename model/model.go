@@ -4,36 +4,39 @@ import (
 	"fmt"
 
 	L "github.com/itsubaki/autograd/layer"
+	"github.com/itsubaki/autograd/tensor"
 	"github.com/itsubaki/autograd/variable"
 )
 
 var (
-	_ Layer = (*L.LinearT)(nil)
-	_ Layer = (*L.RNNT)(nil)
-	_ Layer = (*L.LSTMT)(nil)
+	_ Layer[float64] = (*L.LinearT[float64])(nil)
+	_ Layer[float64] = (*L.RNNT[float64])(nil)
+	_ Layer[float64] = (*L.LSTMT[float64])(nil)
+	_ Layer[float64] = (*L.BatchNormT[float64])(nil)
 )
 
 // Layer is the interface implemented by trainable model layers.
-type Layer interface {
-	First(x ...*variable.Variable) *variable.Variable
-	Forward(x ...*variable.Variable) []*variable.Variable
-	Params() L.Parameters
+type Layer[T tensor.Float] interface {
+	First(x ...*variable.Variable[T]) *variable.Variable[T]
+	Forward(x ...*variable.Variable[T]) []*variable.Variable[T]
+	Params() L.Parameters[T]
+	Buffers() L.Parameters[T]
 	Cleargrads()
 }
 
 // Model represents a stack of layers.
-type Model struct {
+type Model[T tensor.Float] struct {
 	Layers []string
-	L      map[string]Layer
+	L      map[string]Layer[T]
 }
 
-func (m *Model) Add(name string, layer Layer) {
+func (m *Model[T]) Add(name string, layer Layer[T]) {
 	if m.Layers == nil {
 		m.Layers = make([]string, 0)
 	}
 
 	if m.L == nil {
-		m.L = make(map[string]Layer)
+		m.L = make(map[string]Layer[T])
 	}
 
 	m.Layers = append(m.Layers, name)
@@ -41,8 +44,8 @@ func (m *Model) Add(name string, layer Layer) {
 }
 
 // Params returns all parameters in the model keyed by layer index and parameter name.
-func (m *Model) Params() L.Parameters {
-	params := make(L.Parameters, 0)
+func (m *Model[T]) Params() L.Parameters[T] {
+	params := make(L.Parameters[T], 0)
 	for name, layer := range m.L {
 		for k, p := range layer.Params() {
 			params[fmt.Sprintf("%s.%s", name, k)] = p
@@ -52,8 +55,20 @@ func (m *Model) Params() L.Parameters {
 	return params
 }
 
+// Buffers returns all buffers in the model keyed by layer name and buffer name.
+func (m *Model[T]) Buffers() L.Parameters[T] {
+	buffers := make(L.Parameters[T], 0)
+	for name, layer := range m.L {
+		for k, b := range layer.Buffers() {
+			buffers[fmt.Sprintf("%s.%s", name, k)] = b
+		}
+	}
+
+	return buffers
+}
+
 // Cleargrads clears the gradients of all model parameters.
-func (m *Model) Cleargrads() {
+func (m *Model[T]) Cleargrads() {
 	for _, l := range m.L {
 		l.Cleargrads()
 	}
@@ -1 +1,86 @@
 package model_test
+
+import (
+	"bytes"
+	"testing"
+
+	L "github.com/itsubaki/autograd/layer"
+	"github.com/itsubaki/autograd/model"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func TestModel_save(t *testing.T) {
+	newModel := func(seed uint64) *model.Model[float64] {
+		m := &model.Model[float64]{}
+		m.Add("linear", L.Linear[float64](3, L.WithInSize(2), L.WithSource(rand.Const(seed))))
+		m.Add("bn", L.BatchNorm1d[float64](3))
+		return m
+	}
+
+	m := newModel(1)
+	for i := range 3 {
+		x := variable.Randn([]int{4, 2}, rand.Const(uint64(i)))
+		m.L["bn"].First(m.L["linear"].First(x))
+	}
+
+	cases := []struct {
+		name string
+		save func(m *model.Model[float64]) ([]byte, error)
+		load func(b []byte, m *model.Model[float64]) error
+	}{
+		{
+			"checkpoint",
+			func(m *model.Model[float64]) ([]byte, error) {
+				var buf bytes.Buffer
+				err := L.Save(&buf, m)
+				return buf.Bytes(), err
+			},
+			func(b []byte, m *model.Model[float64]) error {
+				return L.Load(bytes.NewReader(b), m)
+			},
+		},
+		{
+			"safetensors",
+			func(m *model.Model[float64]) ([]byte, error) {
+				var buf bytes.Buffer
+				err := L.SaveSafetensors(&buf, m)
+				return buf.Bytes(), err
+			},
+			func(b []byte, m *model.Model[float64]) error {
+				return L.LoadSafetensors(bytes.NewReader(b), m)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		b, err := c.save(m)
+		if err != nil {
+			t.Fatalf("%v: %v", c.name, err)
+		}
+
+		restored := newModel(2)
+		if err := c.load(b, restored); err != nil {
+			t.Fatalf("%v: %v", c.name, err)
+		}
+
+		for name, v := range m.Buffers() {
+			if !tensor.IsCloseAll(restored.Buffers()[name].Data, v.Data, 0, 0) {
+				t.Errorf("%v: %v=%v, want=%v", c.name, name, restored.Buffers()[name].Data.Data, v.Data.Data)
+			}
+		}
+
+		// the restored model gives the same outputs in test mode
+		x := variable.Randn([]int{2, 2}, rand.Const(3))
+		func() {
+			defer variable.TestMode().End()
+
+			want := m.L["bn"].First(m.L["linear"].First(x))
+			got := restored.L["bn"].First(restored.L["linear"].First(x))
+			if !tensor.IsCloseAll(got.Data, want.Data, 0, 0) {
+				t.Errorf("%v: got=%v, want=%v", c.name, got.Data.Data, want.Data.Data)
+			}
+		}()
+	}
+}
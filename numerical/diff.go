@@ -7,41 +7,63 @@ import (
 )
 
 // Func represents a function used for numerical differentiation.
-type Func func(x ...*variable.Variable) *variable.Variable
+type Func[T tensor.Float] func(x ...*variable.Variable[T]) *variable.Variable[T]
 
 var (
-	_ Func = F.Add
-	_ Func = F.Sub
-	_ Func = F.Mul
-	_ Func = F.Div
-	_ Func = F.Sin
-	_ Func = F.Cos
-	_ Func = F.Tanh
-	_ Func = F.Exp
-	_ Func = F.Log
-	_ Func = F.Pow(2.0)
-	_ Func = F.Square
-	_ Func = F.Neg
-	_ Func = F.Sum()
-	_ Func = F.SumTo(1, 1)
-	_ Func = F.BroadcastTo(1, 3)
-	_ Func = F.Reshape(2, 2)
-	_ Func = F.Transpose(-1, -2)
-	_ Func = F.MatMul
-	_ Func = F.Max()
-	_ Func = F.Min()
-	_ Func = F.Clip(0.0, 1.0)
-	_ Func = F.Linear
-	_ Func = F.Sigmoid
-	_ Func = F.ReLU
-	_ Func = F.MeanSquaredError
-	_ Func = F.GetItem(0, []int{0, 0, 1})
-	_ Func = F.Softmax(1)
-	_ Func = F.CrossEntropy
+	_ Func[float64] = F.Add
+	_ Func[float64] = F.Sub
+	_ Func[float64] = F.Mul
+	_ Func[float64] = F.Div
+	_ Func[float64] = F.Sin
+	_ Func[float64] = F.Cos
+	_ Func[float64] = F.Tanh
+	_ Func[float64] = F.Exp
+	_ Func[float64] = F.Log
+	_ Func[float64] = F.Pow(2.0)
+	_ Func[float64] = F.Square
+	_ Func[float64] = F.Neg
+	_ Func[float64] = F.Sum[float64]()
+	_ Func[float64] = F.SumTo[float64](1, 1)
+	_ Func[float64] = F.BroadcastTo[float64](1, 3)
+	_ Func[float64] = F.Reshape[float64](2, 2)
+	_ Func[float64] = F.Transpose[float64](-1, -2)
+	_ Func[float64] = F.MatMul
+	_ Func[float64] = F.Einsum[float64]("ij,jk->ik")
+	_ Func[float64] = F.Max[float64]()
+	_ Func[float64] = F.Min[float64]()
+	_ Func[float64] = F.Clip(0.0, 1.0)
+	_ Func[float64] = F.Linear
+	_ Func[float64] = F.Sigmoid
+	_ Func[float64] = F.ReLU
+	_ Func[float64] = F.MeanSquaredError
+	_ Func[float64] = F.GetItem[float64](0, []int{0, 0, 1})
+	_ Func[float64] = F.Slice[float64](tensor.All(), tensor.R(0, 2))
+	_ Func[float64] = F.Gather[float64](1, tensor.New([]int{1, 1}, []int{0}))
+	_ Func[float64] = F.ScatterAdd[float64](1, tensor.New([]int{1, 1}, []int{0}))
+	_ Func[float64] = F.Inv
+	_ Func[float64] = F.Det
+	_ Func[float64] = F.LogDet
+	_ Func[float64] = F.Solve
+	_ Func[float64] = F.Cholesky
+	_ Func[float64] = F.Softmax[float64](1)
+	_ Func[float64] = F.CrossEntropy
+	_ Func[float64] = F.Im2col[float64]([]int{1}, []int{1}, []int{0}, []int{1})
+	_ Func[float64] = F.Col2im[float64]([]int{1, 1, 1}, []int{1}, []int{1}, []int{0}, []int{1})
+	_ Func[float64] = F.Conv1d[float64]()
+	_ Func[float64] = F.Conv2d[float64]()
+	_ Func[float64] = F.Conv3d[float64]()
+	_ Func[float64] = F.ConvTranspose2d[float64]()
+	_ Func[float64] = F.MaxPool2d[float64]([]int{1})
+	_ Func[float64] = F.AvgPool2d[float64]([]int{1})
+	_ Func[float64] = F.AdaptiveAvgPool2d[float64]([]int{1})
+	_ Func[float64] = F.GlobalAvgPool
+	_ Func[float64] = F.BatchNorm[float64](nil, nil, 0, 0)
+	_ Func[float64] = F.LayerNorm[float64](nil, 0)
+	_ Func[float64] = F.RMSNorm[float64](nil, 0)
 )
 
 // Diff computes the numerical derivative of f at x using central differences.
-func Diff(f Func, x []*variable.Variable, h ...float64) *variable.Variable {
+func Diff[T tensor.Float](f Func[T], x []*variable.Variable[T], h ...T) *variable.Variable[T] {
 	if len(h) == 0 {
 		h = append(h, 1e-4)
 	}
@@ -49,20 +71,58 @@ func Diff(f Func, x []*variable.Variable, h ...float64) *variable.Variable {
 	y0 := f(xh(x, h[0], tensor.AddC)...)          // f(x+h)
 	y1 := f(xh(x, -1.0*h[0], tensor.AddC)...)     // f(x-h)
 	df := tensor.F2(y0.Data, y1.Data, diff(h[0])) // (f(x+h) - f(x-h)) / 2h
-	return &variable.Variable{Data: df}
+	return &variable.Variable[T]{Data: df}
+}
+
+// Grad computes the numerical gradient of the sum of f(x) with respect to each element of x using central differences.
+// Unlike Diff, each element is perturbed separately, so f need not be elementwise.
+func Grad[T tensor.Float](f Func[T], x []*variable.Variable[T], h ...T) []*variable.Variable[T] {
+	if len(h) == 0 {
+		h = append(h, 1e-4)
+	}
+
+	sum := func(x []*variable.Variable[T]) T {
+		return tensor.Sum(f(x...).Data).At()
+	}
+
+	grads := make([]*variable.Variable[T], len(x))
+	for i := range x {
+		g := tensor.Zeros[T](x[i].Shape()...)
+		for j := range g.Data {
+			x0, x1 := clone(x), clone(x)
+			x0[i].Data.Data[j] += h[0] // x+h
+			x1[i].Data.Data[j] -= h[0] // x-h
+
+			g.Data[j] = diff(h[0])(sum(x0), sum(x1))
+		}
+
+		grads[i] = &variable.Variable[T]{Data: g}
+	}
+
+	return grads
 }
 
 // diff returns the numerical derivative of a and b using central differences.
-func diff(h float64) func(a, b float64) float64 {
-	return func(a, b float64) float64 { return (a - b) / (2 * h) }
+func diff[T tensor.Float](h T) func(a, b T) T {
+	return func(a, b T) T { return (a - b) / (2 * h) }
 }
 
 // xh applies a function f to each element of x with a given step size h.
-func xh(x []*variable.Variable, h float64, f func(c float64, v *tensor.Tensor[float64]) *tensor.Tensor[float64]) []*variable.Variable {
-	x0 := make([]*variable.Variable, len(x))
+func xh[T tensor.Float](x []*variable.Variable[T], h T, f func(c T, v *tensor.Tensor[T]) *tensor.Tensor[T]) []*variable.Variable[T] {
+	x0 := make([]*variable.Variable[T], len(x))
 	for i := range x {
 		x0[i] = variable.From(f(h, x[i].Data))
 	}
 
 	return x0
 }
+
+// clone returns a contiguous copy of each element of x.
+func clone[T tensor.Float](x []*variable.Variable[T]) []*variable.Variable[T] {
+	out := make([]*variable.Variable[T], len(x))
+	for i := range x {
+		out[i] = variable.From(tensor.Clone(x[i].Data))
+	}
+
+	return out
+}
@@ -10,11 +10,43 @@ import (
 
 func ExampleDiff() {
 	// p22
-	v := []*variable.Variable{variable.New(2.0)}
-	f := F.Square
+	v := []*variable.Variable[float64]{variable.New(2.0)}
+	f := F.Square[float64]
 
 	fmt.Println(numerical.Diff(f, v))
 
 	// Output:
 	// variable(4.000000000004)
 }
+
+func ExampleGrad() {
+	// y = x0 * x1
+	x := []*variable.Variable[float64]{
+		variable.New(2.0, 3.0),
+		variable.New(4.0, 5.0),
+	}
+
+	for _, g := range numerical.Grad(F.Mul, x) {
+		fmt.Printf("%.4f\n", g.Data.Data)
+	}
+
+	// Output:
+	// [4.0000 5.0000]
+	// [2.0000 3.0000]
+}
+
+func ExampleGrad_matmul() {
+	// sum(x0 * x1)
+	x := []*variable.Variable[float64]{
+		variable.New(1, 2, 3, 4).Reshape(2, 2),
+		variable.New(1, 0, 0, 1).Reshape(2, 2),
+	}
+
+	for _, g := range numerical.Grad(F.MatMul, x) {
+		fmt.Printf("%.4f\n", g.Data.Data)
+	}
+
+	// Output:
+	// [1.0000 1.0000 1.0000 1.0000]
+	// [4.0000 4.0000 6.0000 6.0000]
+}
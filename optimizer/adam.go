@@ -1,6 +1,7 @@
 package optimizer
 
 import (
+	"fmt"
 	"math"
 
 	"github.com/itsubaki/autograd/tensor"
@@ -8,26 +9,26 @@ import (
 )
 
 // Adam is an optimizer that uses the Adam algorithm.
-type Adam struct {
-	Alpha  float64
-	Beta1  float64
-	Beta2  float64
-	Hook   []Hook
+type Adam[T tensor.Float] struct {
+	Alpha  T
+	Beta1  T
+	Beta2  T
+	Hook   []Hook[T]
 	iter   int
-	ms, vs map[*variable.Variable]*tensor.Tensor[float64]
+	ms, vs map[*variable.Variable[T]]*tensor.Tensor[T]
 }
 
 // Update updates the parameters of the model.
-func (o *Adam) Update(model Model) {
+func (o *Adam[T]) Update(model Model[T]) {
 	if len(o.ms) == 0 {
-		o.ms = make(map[*variable.Variable]*tensor.Tensor[float64])
-		o.vs = make(map[*variable.Variable]*tensor.Tensor[float64])
+		o.ms = make(map[*variable.Variable[T]]*tensor.Tensor[T])
+		o.vs = make(map[*variable.Variable[T]]*tensor.Tensor[T])
 	}
 
 	o.iter++
-	fix1 := 1.0 - math.Pow(o.Beta1, float64(o.iter))
-	fix2 := 1.0 - math.Pow(o.Beta2, float64(o.iter))
-	lr := o.Alpha * math.Sqrt(fix2) / fix1
+	fix1 := 1.0 - math.Pow(float64(o.Beta1), float64(o.iter))
+	fix2 := 1.0 - math.Pow(float64(o.Beta2), float64(o.iter))
+	lr := o.Alpha * T(math.Sqrt(fix2)/fix1)
 
 	params := Params(model, o.Hook)
 	for _, p := range params {
@@ -36,20 +37,45 @@ func (o *Adam) Update(model Model) {
 			o.vs[p] = tensor.ZeroLike(p.Data)
 		}
 
-		o.ms[p] = tensor.F2(o.ms[p], p.Grad.Data, func(m, grad float64) float64 {
+		o.ms[p] = tensor.F2(o.ms[p], p.Grad.Data, func(m, grad T) T {
 			return m + (1-o.Beta1)*(grad-m)
 		})
 
-		o.vs[p] = tensor.F2(o.vs[p], p.Grad.Data, func(v, grad float64) float64 {
+		o.vs[p] = tensor.F2(o.vs[p], p.Grad.Data, func(v, grad T) T {
 			return v + (1-o.Beta2)*(grad*grad-v)
 		})
 
 		// update function
-		update := tensor.F2(o.ms[p], o.vs[p], func(m, v float64) float64 {
-			return lr * m / (math.Sqrt(v) + 1e-8)
+		update := tensor.F2(o.ms[p], o.vs[p], func(m, v T) T {
+			return lr * m / (T(math.Sqrt(float64(v))) + 1e-8)
 		})
 
 		// param = param - (lr * m / (sqrt(v) + 1e-8))
 		p.Data = tensor.Sub(p.Data, update)
 	}
 }
+
+// State returns the iteration count and the moment estimates keyed by the parameter names of model.
+func (o *Adam[T]) State(model Model[T]) State[T] {
+	return State[T]{
+		Iter: o.iter,
+		M:    export(model, o.ms),
+		V:    export(model, o.vs),
+	}
+}
+
+// SetState restores the state returned by State into the parameters of model with the same names.
+func (o *Adam[T]) SetState(model Model[T], s State[T]) error {
+	ms, err := restore(model, s.M)
+	if err != nil {
+		return fmt.Errorf("m: %w", err)
+	}
+
+	vs, err := restore(model, s.V)
+	if err != nil {
+		return fmt.Errorf("v: %w", err)
+	}
+
+	o.iter, o.ms, o.vs = s.Iter, ms, vs
+	return nil
+}
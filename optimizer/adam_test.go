@@ -2,9 +2,11 @@ package optimizer_test
 
 import (
 	"fmt"
+	"testing"
 
 	"github.com/itsubaki/autograd/hook"
 	"github.com/itsubaki/autograd/optimizer"
+	"github.com/itsubaki/autograd/tensor"
 	"github.com/itsubaki/autograd/variable"
 )
 
@@ -13,7 +15,7 @@ func ExampleAdam() {
 	p.Grad = variable.New(1.0)
 	m := &TestModel{P: p}
 
-	o := optimizer.Adam{
+	o := optimizer.Adam[float64]{
 		Alpha: 0.001,
 		Beta1: 0.9,
 		Beta2: 0.999,
@@ -35,11 +37,11 @@ func ExampleAdam_hook() {
 	p.Grad = variable.New(1.0)
 	m := &TestModel{P: p}
 
-	o := optimizer.Adam{
+	o := optimizer.Adam[float64]{
 		Alpha: 0.001,
 		Beta1: 0.9,
 		Beta2: 0.999,
-		Hook: []optimizer.Hook{
+		Hook: []optimizer.Hook[float64]{
 			hook.WeightDecay(0.1),
 		},
 	}
@@ -54,3 +56,49 @@ func ExampleAdam_hook() {
 	// variable(0.9990000002874797)
 	// variable(0.997998680251141)
 }
+
+func ExampleAdam_State() {
+	p := variable.New(1.0)
+	p.Grad = variable.New(1.0)
+	m := &TestModel{P: p}
+
+	o := optimizer.Adam[float64]{Alpha: 0.001, Beta1: 0.9, Beta2: 0.999}
+	o.Update(m)
+
+	// resume with fresh parameters and a fresh optimizer
+	q := variable.New(p.Data.Data...)
+	q.Grad = variable.New(1.0)
+	resumed := &TestModel{P: q}
+
+	r := optimizer.Adam[float64]{Alpha: 0.001, Beta1: 0.9, Beta2: 0.999}
+	if err := r.SetState(resumed, o.State(m)); err != nil {
+		panic(err)
+	}
+
+	o.Update(m)
+	r.Update(resumed)
+	fmt.Println(p)
+	fmt.Println(q)
+
+	// Output:
+	// variable(0.9980000005398904)
+	// variable(0.9980000005398904)
+}
+
+func TestAdam_SetState(t *testing.T) {
+	m := &TestModel{P: variable.New(1.0, 2.0)}
+
+	cases := []struct {
+		state optimizer.State[float64]
+	}{
+		{optimizer.State[float64]{M: map[string]*tensor.Tensor[float64]{"q": tensor.Zeros[float64](2)}}},
+		{optimizer.State[float64]{V: map[string]*tensor.Tensor[float64]{"p": tensor.Zeros[float64](3)}}},
+	}
+
+	for _, c := range cases {
+		var o optimizer.Adam[float64]
+		if err := o.SetState(m, c.state); err == nil {
+			t.Errorf("expected error")
+		}
+	}
+}
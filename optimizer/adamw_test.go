@@ -12,7 +12,7 @@ func ExampleAdamW() {
 	p.Grad = variable.New(1.0)
 	m := &TestModel{P: p}
 
-	o := optimizer.AdamW{
+	o := optimizer.AdamW[float64]{
 		Alpha:       0.001,
 		Beta1:       0.9,
 		Beta2:       0.999,
@@ -29,3 +29,31 @@ func ExampleAdamW() {
 	// variable(0.9989684091623926)
 	// variable(0.9979449255202475)
 }
+
+func ExampleAdamW_State() {
+	p := variable.New(1.0)
+	p.Grad = variable.New(1.0)
+	m := &TestModel{P: p}
+
+	o := optimizer.AdamW[float64]{Alpha: 0.001, Beta1: 0.9, Beta2: 0.999, WeightDecay: 0.1}
+	o.Update(m)
+
+	// resume with fresh parameters and a fresh optimizer
+	q := variable.New(p.Data.Data...)
+	q.Grad = variable.New(1.0)
+	resumed := &TestModel{P: q}
+
+	r := optimizer.AdamW[float64]{Alpha: 0.001, Beta1: 0.9, Beta2: 0.999, WeightDecay: 0.1}
+	if err := r.SetState(resumed, o.State(m)); err != nil {
+		panic(err)
+	}
+
+	o.Update(m)
+	r.Update(resumed)
+	fmt.Println(p)
+	fmt.Println(q)
+
+	// Output:
+	// variable(0.9979449255202475)
+	// variable(0.9979449255202475)
+}
@@ -1,22 +1,24 @@
 package optimizer
 
 import (
+	"fmt"
+
 	"github.com/itsubaki/autograd/tensor"
 	"github.com/itsubaki/autograd/variable"
 )
 
 // Momentum is an optimizer that uses momentum-based gradient descent.
-type Momentum struct {
-	LearningRate float64
-	Momentum     float64
-	Hook         []Hook
-	vs           map[*variable.Variable]*tensor.Tensor[float64]
+type Momentum[T tensor.Float] struct {
+	LearningRate T
+	Momentum     T
+	Hook         []Hook[T]
+	vs           map[*variable.Variable[T]]*tensor.Tensor[T]
 }
 
 // Update updates the parameters of the model.
-func (o *Momentum) Update(model Model) {
+func (o *Momentum[T]) Update(model Model[T]) {
 	if len(o.vs) == 0 {
-		o.vs = make(map[*variable.Variable]*tensor.Tensor[float64])
+		o.vs = make(map[*variable.Variable[T]]*tensor.Tensor[T])
 	}
 
 	params := Params(model, o.Hook)
@@ -32,6 +34,24 @@ func (o *Momentum) Update(model Model) {
 }
 
 // momentum returns a function that computes the momentum update for a given velocity v and gradient grad.
-func momentum(momentum, lr float64) func(v, grad float64) float64 {
-	return func(v, grad float64) float64 { return momentum*v - lr*grad }
+func momentum[T tensor.Float](momentum, lr T) func(v, grad T) T {
+	return func(v, grad T) T { return momentum*v - lr*grad }
+}
+
+// State returns the velocities keyed by the parameter names of model.
+func (o *Momentum[T]) State(model Model[T]) State[T] {
+	return State[T]{
+		V: export(model, o.vs),
+	}
+}
+
+// SetState restores the state returned by State into the parameters of model with the same names.
+func (o *Momentum[T]) SetState(model Model[T], s State[T]) error {
+	vs, err := restore(model, s.V)
+	if err != nil {
+		return fmt.Errorf("v: %w", err)
+	}
+
+	o.vs = vs
+	return nil
 }
@@ -2,9 +2,11 @@ package optimizer_test
 
 import (
 	"fmt"
+	"testing"
 
 	"github.com/itsubaki/autograd/hook"
 	"github.com/itsubaki/autograd/optimizer"
+	"github.com/itsubaki/autograd/tensor"
 	"github.com/itsubaki/autograd/variable"
 )
 
@@ -13,7 +15,7 @@ func ExampleMomentum() {
 	p.Grad = variable.New(1.0)
 	m := &TestModel{P: p}
 
-	o := optimizer.Momentum{
+	o := optimizer.Momentum[float64]{
 		LearningRate: 0.001,
 		Momentum:     0.9,
 	}
@@ -34,10 +36,10 @@ func ExampleMomentum_hook() {
 	p.Grad = variable.New(1.0)
 	m := &TestModel{P: p}
 
-	o := optimizer.Momentum{
+	o := optimizer.Momentum[float64]{
 		LearningRate: 0.001,
 		Momentum:     0.9,
-		Hook: []optimizer.Hook{
+		Hook: []optimizer.Hook[float64]{
 			hook.WeightDecay(0.1),
 		},
 	}
@@ -52,3 +54,40 @@ func ExampleMomentum_hook() {
 	// variable(0.9989)
 	// variable(0.99671011)
 }
+
+func ExampleMomentum_State() {
+	p := variable.New(1.0)
+	p.Grad = variable.New(1.0)
+	m := &TestModel{P: p}
+
+	o := optimizer.Momentum[float64]{LearningRate: 0.001, Momentum: 0.9}
+	o.Update(m)
+
+	// resume with fresh parameters and a fresh optimizer
+	q := variable.New(p.Data.Data...)
+	q.Grad = variable.New(1.0)
+	resumed := &TestModel{P: q}
+
+	r := optimizer.Momentum[float64]{LearningRate: 0.001, Momentum: 0.9}
+	if err := r.SetState(resumed, o.State(m)); err != nil {
+		panic(err)
+	}
+
+	o.Update(m)
+	r.Update(resumed)
+	fmt.Println(p)
+	fmt.Println(q)
+
+	// Output:
+	// variable(0.9971)
+	// variable(0.9971)
+}
+
+func TestMomentum_SetState(t *testing.T) {
+	m := &TestModel{P: variable.New(1.0, 2.0)}
+
+	var o optimizer.Momentum[float64]
+	if err := o.SetState(m, optimizer.State[float64]{V: map[string]*tensor.Tensor[float64]{"q": tensor.Zeros[float64](2)}}); err == nil {
+		t.Errorf("expected error")
+	}
+}
@@ -1,32 +1,36 @@
 package optimizer
 
 import (
+	"fmt"
+
 	"github.com/itsubaki/autograd/hook"
 	"github.com/itsubaki/autograd/layer"
 	"github.com/itsubaki/autograd/model"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
 )
 
 var (
-	_ Model = (*model.MLP)(nil)
-	_ Model = (*model.LSTM)(nil)
+	_ Model[float64] = (*model.MLP[float64])(nil)
+	_ Model[float64] = (*model.LSTM[float64])(nil)
 )
 
 var (
-	_ Hook = hook.WeightDecay(0.0)
-	_ Hook = hook.ClipGrad(0.0)
+	_ Hook[float64] = hook.WeightDecay(0.0)
+	_ Hook[float64] = hook.ClipGrad(0.0)
 )
 
 // Model is the interface implemented by optimizable models.
-type Model interface {
-	Params() layer.Parameters
+type Model[T tensor.Float] interface {
+	Params() layer.Parameters[T]
 }
 
 // Hook transforms parameters before an optimizer update is applied.
-type Hook func(params []layer.Parameter)
+type Hook[T tensor.Float] func(params []*variable.Variable[T])
 
 // Params returns model parameters that currently have gradients and applies hooks to them.
-func Params(m Model, hook []Hook) []layer.Parameter {
-	params := make([]layer.Parameter, 0)
+func Params[T tensor.Float](m Model[T], hook []Hook[T]) []*variable.Variable[T] {
+	params := make([]*variable.Variable[T], 0)
 	for _, p := range m.Params() {
 		if p.Grad == nil {
 			continue
@@ -41,3 +45,44 @@ func Params(m Model, hook []Hook) []layer.Parameter {
 
 	return params
 }
+
+// State is the state of an optimizer keyed by parameter name.
+// M and V are the first and second moment estimates, or the velocities for Momentum.
+type State[T tensor.Float] struct {
+	Iter int
+	M, V map[string]*tensor.Tensor[T]
+}
+
+// export returns a copy of s keyed by the names of the parameters of m.
+func export[T tensor.Float](m Model[T], s map[*variable.Variable[T]]*tensor.Tensor[T]) map[string]*tensor.Tensor[T] {
+	out := make(map[string]*tensor.Tensor[T])
+	for name, p := range m.Params() {
+		if v, ok := s[p]; ok {
+			out[name] = tensor.Clone(v)
+		}
+	}
+
+	return out
+}
+
+// restore returns a copy of s keyed by the parameters of m with the same names.
+// It returns an error if a name is not a parameter of m or if the shape differs from the parameter.
+func restore[T tensor.Float](m Model[T], s map[string]*tensor.Tensor[T]) (map[*variable.Variable[T]]*tensor.Tensor[T], error) {
+	params := m.Params()
+
+	out := make(map[*variable.Variable[T]]*tensor.Tensor[T])
+	for name, v := range s {
+		p, ok := params[name]
+		if !ok {
+			return nil, fmt.Errorf("parameter %q not found", name)
+		}
+
+		if !tensor.SliceEqual(p.Shape(), v.Shape) {
+			return nil, fmt.Errorf("shape %v of %q does not match the parameter shape %v", v.Shape, name, p.Shape())
+		}
+
+		out[p] = tensor.Clone(v)
+	}
+
+	return out, nil
+}
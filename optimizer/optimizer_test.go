@@ -1,9 +1,16 @@
 package optimizer_test
 
 import (
+	"bytes"
+	"encoding/gob"
 	"fmt"
+	"slices"
+	"testing"
 
+	"github.com/itsubaki/autograd/layer"
+	"github.com/itsubaki/autograd/model"
 	"github.com/itsubaki/autograd/optimizer"
+	"github.com/itsubaki/autograd/rand"
 	"github.com/itsubaki/autograd/variable"
 )
 
@@ -18,3 +25,55 @@ func ExampleParams() {
 
 	// Output:
 }
+
+func TestState_mlp(t *testing.T) {
+	x := variable.New(1, 2).Reshape(1, 2)
+	train := func(m *model.MLP[float64], o *optimizer.Adam[float64]) {
+		m.Cleargrads()
+		m.Forward(x).Backward()
+		o.Update(m)
+	}
+
+	m := model.NewMLP([]int{3, 1}, model.WithMLPSource[float64](rand.Const(1)))
+	o := &optimizer.Adam[float64]{Alpha: 0.01, Beta1: 0.9, Beta2: 0.999}
+	train(m, o)
+	train(m, o)
+
+	// checkpoint
+	var params bytes.Buffer
+	if err := layer.Save(&params, m.Params()); err != nil {
+		t.Fatal(err)
+	}
+
+	var state bytes.Buffer
+	if err := gob.NewEncoder(&state).Encode(o.State(m)); err != nil {
+		t.Fatal(err)
+	}
+
+	// restore into a fresh model and optimizer
+	resumed := model.NewMLP([]int{3, 1}, model.WithMLPSource[float64](rand.Const(2)))
+	resumed.Forward(x)
+	if err := layer.Load(&params, resumed.Params()); err != nil {
+		t.Fatal(err)
+	}
+
+	var s optimizer.State[float64]
+	if err := gob.NewDecoder(&state).Decode(&s); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &optimizer.Adam[float64]{Alpha: 0.01, Beta1: 0.9, Beta2: 0.999}
+	if err := r.SetState(resumed, s); err != nil {
+		t.Fatal(err)
+	}
+
+	train(m, o)
+	train(resumed, r)
+
+	want := m.Params()
+	for name, p := range resumed.Params() {
+		if !slices.Equal(p.Data.Data, want[name].Data.Data) {
+			t.Errorf("%v: got=%v, want=%v", name, p.Data.Data, want[name].Data.Data)
+		}
+	}
+}
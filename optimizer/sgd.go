@@ -3,13 +3,13 @@ package optimizer
 import "github.com/itsubaki/autograd/tensor"
 
 // SGD is an optimizer that uses the stochastic gradient descent algorithm.
-type SGD struct {
-	LearningRate float64
-	Hook         []Hook
+type SGD[T tensor.Float] struct {
+	LearningRate T
+	Hook         []Hook[T]
 }
 
 // Update updates the parameters of the model.
-func (o *SGD) Update(model Model) {
+func (o *SGD[T]) Update(model Model[T]) {
 	params := Params(model, o.Hook)
 	for _, p := range params {
 		p.Data = tensor.F2(p.Data, p.Grad.Data, sgd(o.LearningRate))
@@ -17,6 +17,6 @@ func (o *SGD) Update(model Model) {
 }
 
 // sgd returns a function that computes the SGD update for a given parameter value a and gradient b using the specified learning rate lr.
-func sgd(lr float64) func(a, b float64) float64 {
-	return func(a, b float64) float64 { return a - lr*b }
+func sgd[T tensor.Float](lr T) func(a, b T) T {
+	return func(a, b T) T { return a - lr*b }
 }
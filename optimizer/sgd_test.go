@@ -10,11 +10,11 @@ import (
 )
 
 type TestModel struct {
-	P layer.Parameter
+	P *variable.Variable[float64]
 }
 
-func (m *TestModel) Params() layer.Parameters {
-	return map[string]layer.Parameter{
+func (m *TestModel) Params() layer.Parameters[float64] {
+	return layer.Parameters[float64]{
 		"p": m.P,
 	}
 }
@@ -24,7 +24,7 @@ func ExampleSGD() {
 	p.Grad = variable.New(1.0)
 	m := &TestModel{P: p}
 
-	o := optimizer.SGD{
+	o := optimizer.SGD[float64]{
 		LearningRate: 0.1,
 	}
 	o.Update(m)
@@ -40,9 +40,9 @@ func ExampleSGD_hook() {
 	p.Grad = variable.New(1.0)
 	m := &TestModel{P: p}
 
-	o := optimizer.SGD{
+	o := optimizer.SGD[float64]{
 		LearningRate: 0.1,
-		Hook: []optimizer.Hook{
+		Hook: []optimizer.Hook[float64]{
 			hook.WeightDecay(0.1),
 		},
 	}
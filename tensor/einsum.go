@@ -0,0 +1,151 @@
+package tensor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Einsum returns the Einstein summation of the operands described by subscripts.
+// For example, "ij,jk->ik" is a matrix product, "bij,bjk->bik" is a batched matrix product,
+// "i,j->ij" is an outer product and "ii->" is a trace.
+// Without "->", the output labels are the labels that appear exactly once, in alphabetical order.
+// A label repeated within an operand selects its diagonal, and a label repeated in the output
+// writes to the diagonal of the output and leaves the other elements zero.
+func Einsum[T Number](subscripts string, operands ...*Tensor[T]) *Tensor[T] {
+	in, out := ParseEinsum(subscripts, len(operands))
+
+	// size of each label
+	sizes := make(map[byte]int)
+	for i, v := range operands {
+		if len(in[i]) != v.NumDims() {
+			panic(fmt.Sprintf("subscripts %q do not match ndim=%v of operand %d", in[i], v.NumDims(), i))
+		}
+
+		for j := range len(in[i]) {
+			l := in[i][j]
+			if s, ok := sizes[l]; ok && s != v.Shape[j] {
+				panic(fmt.Sprintf("label %q has inconsistent sizes %v and %v", l, s, v.Shape[j]))
+			}
+
+			sizes[l] = v.Shape[j]
+		}
+	}
+
+	// labels: output labels first, then contracted labels
+	var labels []byte
+	for i := range len(out) {
+		if _, ok := sizes[out[i]]; !ok {
+			panic(fmt.Sprintf("output label %q does not appear in the inputs", out[i]))
+		}
+
+		if !slices.Contains(labels, out[i]) {
+			labels = append(labels, out[i])
+		}
+	}
+
+	for _, s := range in {
+		for j := range len(s) {
+			if !slices.Contains(labels, s[j]) {
+				labels = append(labels, s[j])
+			}
+		}
+	}
+
+	shape := make([]int, len(labels))
+	for i, l := range labels {
+		shape[i] = sizes[l]
+	}
+
+	oshape := make([]int, len(out))
+	for i := range len(out) {
+		oshape[i] = sizes[out[i]]
+	}
+	o := Zeros[T](oshape...)
+
+	// Example: "ij,jk->ik", labels = [i, k, j]
+	//   a (i, j): stride = [a.Stride[0], 0, a.Stride[1]]
+	//   b (j, k): stride = [0, b.Stride[1], b.Stride[0]]
+	//   o (i, k): stride = [o.Stride[0], o.Stride[1], 0]
+	//
+	// A label that does not appear in a tensor gets stride 0, and a label
+	// that appears more than once gets the sum of its strides (diagonal).
+	layout := func(sub string, v *Tensor[T]) *Layout {
+		s := make([]int, len(labels))
+		for j := range len(sub) {
+			s[slices.Index(labels, sub[j])] += v.Stride[j]
+		}
+
+		return &Layout{
+			Shape:  shape,
+			Stride: s,
+			Offset: v.Offset,
+		}
+	}
+
+	layouts := make([]*Layout, len(operands)+1)
+	for i, v := range operands {
+		layouts[i] = layout(in[i], v)
+	}
+	layouts[len(operands)] = layout(out, o)
+
+	n := len(operands)
+	it := NewIterator(layouts...)
+	for it.Next() {
+		prod := operands[0].Data[it.Offset(0)]
+		for i := 1; i < n; i++ {
+			prod *= operands[i].Data[it.Offset(i)]
+		}
+
+		o.Data[it.Offset(n)] += prod
+	}
+
+	return o
+}
+
+// ParseEinsum returns the input and output subscripts of an Einsum expression with n operands.
+// If subscripts has no "->", the output is the labels that appear exactly once, in alphabetical order.
+func ParseEinsum(subscripts string, n int) ([]string, string) {
+	s := strings.ReplaceAll(subscripts, " ", "")
+	lhs, rhs, explicit := strings.Cut(s, "->")
+
+	in := strings.Split(lhs, ",")
+	if len(in) != n {
+		panic(fmt.Sprintf("subscripts %q have %d operands, but %d were given", subscripts, len(in), n))
+	}
+
+	for _, sub := range append(in, rhs) {
+		for i := range len(sub) {
+			if !isLabel(sub[i]) {
+				panic(fmt.Sprintf("invalid label %q in subscripts %q", sub[i], subscripts))
+			}
+		}
+	}
+
+	if explicit {
+		return in, rhs
+	}
+
+	// implicit output
+	count := make(map[byte]int)
+	for _, sub := range in {
+		for i := range len(sub) {
+			count[sub[i]]++
+		}
+	}
+
+	var out []byte
+	for l, c := range count {
+		if c == 1 {
+			out = append(out, l)
+		}
+	}
+	slices.Sort(out)
+
+	return in, string(out)
+}
+
+// isLabel returns true if c is an ASCII letter.
+func isLabel(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
@@ -0,0 +1,186 @@
+package tensor_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+func ExampleEinsum() {
+	a := tensor.New([]int{2, 3}, []float64{
+		1, 2, 3,
+		4, 5, 6,
+	})
+	b := tensor.New([]int{3, 2}, []float64{
+		7, 8,
+		9, 10,
+		11, 12,
+	})
+
+	c := tensor.Einsum("ij,jk->ik", a, b)
+	for _, row := range c.Seq2() {
+		fmt.Println(row)
+	}
+
+	// Output:
+	// [58 64]
+	// [139 154]
+}
+
+func ExampleEinsum_batch() {
+	a := tensor.New([]int{2, 2, 2}, []float64{
+		1, 2,
+		3, 4,
+
+		5, 6,
+		7, 8,
+	})
+	b := tensor.New([]int{2, 2, 1}, []float64{
+		1,
+		1,
+
+		1,
+		0,
+	})
+
+	c := tensor.Einsum("bij,bjk->bik", a, b)
+	fmt.Println(c.Shape)
+	fmt.Println(c.Data)
+
+	// Output:
+	// [2 2 1]
+	// [3 7 5 7]
+}
+
+func ExampleEinsum_implicit() {
+	a := tensor.New([]int{2, 3}, []int{
+		1, 2, 3,
+		4, 5, 6,
+	})
+
+	fmt.Println(tensor.Einsum("ij", a).Shape)
+	fmt.Println(tensor.Einsum("ji", a).Shape)
+	fmt.Println(tensor.Einsum("ij,j", a, tensor.New([]int{3}, []int{1, 0, 1})).Data)
+
+	// Output:
+	// [2 3]
+	// [3 2]
+	// [4 10]
+}
+
+func ExampleEinsum_trace() {
+	a := tensor.New([]int{3, 3}, []int{
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 9,
+	})
+
+	fmt.Println(tensor.Einsum("ii->", a).Data)
+	fmt.Println(tensor.Einsum("ii->i", a).Data)
+	fmt.Println(tensor.Einsum("i->ii", tensor.New([]int{2}, []int{1, 2})).Data)
+
+	// Output:
+	// [15]
+	// [1 5 9]
+	// [1 0 0 2]
+}
+
+func ExampleEinsum_view() {
+	a := tensor.New([]int{2, 3}, []float64{
+		1, 2, 3,
+		4, 5, 6,
+	})
+
+	// (3, 2) view
+	at := tensor.Transpose(a)
+	fmt.Println(tensor.Einsum("ij->ji", at).Data)
+	fmt.Println(tensor.Einsum("i,j->ij", tensor.New([]int{2}, []float64{1, 2}), tensor.New([]int{2}, []float64{3, 4})).Data)
+
+	// Output:
+	// [1 2 3 4 5 6]
+	// [3 4 6 8]
+}
+
+func ExampleEinsum_invalid() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println(r)
+			return
+		}
+
+		panic("unexpected panic for index")
+	}()
+
+	a := tensor.Zeros[float64](2, 3)
+	b := tensor.Zeros[float64](2, 3)
+
+	_ = tensor.Einsum("ij,jk->ik", a, b)
+	panic("unreachable")
+
+	// Output:
+	// label 'j' has inconsistent sizes 3 and 2
+}
+
+func ExampleParseEinsum() {
+	fmt.Println(tensor.ParseEinsum("ij,jk->ik", 2))
+	fmt.Println(tensor.ParseEinsum("ij,jk", 2))
+	fmt.Println(tensor.ParseEinsum("ba", 1))
+	fmt.Println(tensor.ParseEinsum(" bij , bjk -> bik ", 2))
+
+	// Output:
+	// [ij jk] ik
+	// [ij jk] ik
+	// [ba] ab
+	// [bij bjk] bik
+}
+
+func TestEinsum_matmul(t *testing.T) {
+	cases := []struct {
+		subscripts string
+		a, b       *tensor.Tensor[float64]
+	}{
+		{
+			subscripts: "ij,jk->ik",
+			a:          tensor.Rand([]int{3, 4}),
+			b:          tensor.Rand([]int{4, 5}),
+		},
+		{
+			subscripts: "bij,bjk->bik",
+			a:          tensor.Rand([]int{2, 3, 4}),
+			b:          tensor.Rand([]int{2, 4, 5}),
+		},
+	}
+
+	for _, c := range cases {
+		got := tensor.Einsum(c.subscripts, c.a, c.b)
+		want := tensor.MatMul(c.a, c.b)
+		if !tensor.IsCloseAll(got, want) {
+			t.Errorf("%v: got=%v, want=%v", c.subscripts, got.Data, want.Data)
+		}
+	}
+}
+
+func TestEinsum_invalid(t *testing.T) {
+	cases := []struct {
+		subscripts string
+		operands   []*tensor.Tensor[float64]
+	}{
+		{"ij,jk->ik", []*tensor.Tensor[float64]{tensor.Zeros[float64](2, 3)}},
+		{"ij->ik", []*tensor.Tensor[float64]{tensor.Zeros[float64](2, 3)}},
+		{"ijk->i", []*tensor.Tensor[float64]{tensor.Zeros[float64](2, 3)}},
+		{"i1->i", []*tensor.Tensor[float64]{tensor.Zeros[float64](2, 3)}},
+	}
+
+	for _, c := range cases {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%v: expected panic", c.subscripts)
+				}
+			}()
+
+			_ = tensor.Einsum(c.subscripts, c.operands...)
+		}()
+	}
+}
@@ -0,0 +1,5 @@
+//go:build !darwin
+
+package tensor
+
+var MatMul2DNaive = matmulNaive[float64]
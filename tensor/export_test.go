@@ -4,5 +4,5 @@ var (
 	BroadcastShape = broadcast
 	Stride         = stride
 	Size           = size
-	MatMul2D       = matmul
+	MatMul2D       = matmul[float64]
 )
@@ -0,0 +1,114 @@
+package tensor
+
+import "fmt"
+
+// ConvSize returns the spatial shape of the output of a convolution over the spatial shape in.
+// Each output size is (in + 2*padding - dilation*(kernel-1) - 1) / stride + 1.
+func ConvSize(in, kernel, stride, padding, dilation []int) []int {
+	out := make([]int, len(in))
+	for i := range in {
+		out[i] = (in[i]+2*padding[i]-dilation[i]*(kernel[i]-1)-1)/stride[i] + 1
+		if out[i] < 1 {
+			panic(fmt.Sprintf("kernel %v with dilation %v does not fit input %v with padding %v", kernel, dilation, in, padding))
+		}
+	}
+
+	return out
+}
+
+// Im2col returns the sliding windows of v with shape (N, C, *spatial) as the columns of a tensor with shape (N, C*K, L),
+// where K is the number of elements of the kernel and L is the number of windows, both in row-major order.
+// The spatial axes are padded with zeros.
+func Im2col[T Number](v *Tensor[T], kernel, stride, padding, dilation []int) *Tensor[T] {
+	v = Contiguous(v)
+	n, c, in := v.Shape[0], v.Shape[1], v.Shape[2:]
+	index, k, l := window(in, kernel, stride, padding, dilation)
+
+	s := size(in)
+	out := Zeros[T](n, c*k, l)
+	for b := range n * c {
+		src, dst := v.Data[b*s:(b+1)*s], out.Data[b*k*l:(b+1)*k*l]
+		for i, j := range index {
+			if j < 0 {
+				// padding
+				continue
+			}
+
+			dst[i] = src[j]
+		}
+	}
+
+	return out
+}
+
+// Col2im returns the tensor with the given shape (N, C, *spatial) whose sliding windows are the columns of v with shape (N, C*K, L),
+// adding up the elements of overlapping windows. It is the adjoint of Im2col.
+func Col2im[T Number](v *Tensor[T], shape, kernel, stride, padding, dilation []int) *Tensor[T] {
+	v = Contiguous(v)
+	n, c, in := shape[0], shape[1], shape[2:]
+	index, k, l := window(in, kernel, stride, padding, dilation)
+	if !SliceEqual(v.Shape, []int{n, c * k, l}) {
+		panic(fmt.Sprintf("shape %v does not match %v", v.Shape, []int{n, c * k, l}))
+	}
+
+	s := size(in)
+	out := Zeros[T](shape...)
+	for b := range n * c {
+		src, dst := v.Data[b*k*l:(b+1)*k*l], out.Data[b*s:(b+1)*s]
+		for i, j := range index {
+			if j < 0 {
+				// padding
+				continue
+			}
+
+			dst[j] += src[i]
+		}
+	}
+
+	return out
+}
+
+// window returns the offsets in the spatial axes of the elements of the sliding windows in (K, L) order,
+// where the elements in the padding are -1, and the number of elements of the kernel K and of windows L.
+func window(in, kernel, stride, padding, dilation []int) ([]int, int, int) {
+	for _, s := range [][]int{kernel, stride, padding, dilation} {
+		if len(s) != len(in) {
+			panic(fmt.Sprintf("len(%v) does not match %d spatial axes", s, len(in)))
+		}
+	}
+
+	out := ConvSize(in, kernel, stride, padding, dilation)
+	k, l := size(kernel), size(out)
+
+	index := make([]int, k*l)
+	ki, li := make([]int, len(in)), make([]int, len(in))
+	for i := range k {
+		unravel(i, kernel, ki)
+		for j := range l {
+			unravel(j, out, li)
+
+			offset := 0
+			for d := range in {
+				p := li[d]*stride[d] - padding[d] + ki[d]*dilation[d]
+				if p < 0 || p >= in[d] {
+					offset = -1
+					break
+				}
+
+				offset = offset*in[d] + p
+			}
+
+			index[i*l+j] = offset
+		}
+	}
+
+	return index, k, l
+}
+
+// unravel sets the multi-index of the flat index i in row-major order of shape to idx.
+func unravel(i int, shape, idx []int) {
+	for d := len(shape) - 1; d >= 0; d-- {
+		idx[d] = i % shape[d]
+		i /= shape[d]
+	}
+}
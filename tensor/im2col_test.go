@@ -0,0 +1,178 @@
+package tensor_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+func ExampleIm2col() {
+	v := tensor.New([]int{1, 1, 3, 3}, []int{
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 9,
+	})
+
+	// 2x2 windows
+	one := []int{1, 1}
+	col := tensor.Im2col(v, []int{2, 2}, one, []int{0, 0}, one)
+	fmt.Println(col.Shape)
+	for _, row := range tensor.Reshape(col, 4, 4).Seq2() {
+		fmt.Println(row)
+	}
+
+	// Output:
+	// [1 4 4]
+	// [1 2 4 5]
+	// [2 3 5 6]
+	// [4 5 7 8]
+	// [5 6 8 9]
+}
+
+func ExampleCol2im() {
+	col := tensor.Ones[int](1, 4, 4)
+
+	// the number of windows that contain each element
+	one := []int{1, 1}
+	v := tensor.Col2im(col, []int{1, 1, 3, 3}, []int{2, 2}, one, []int{0, 0}, one)
+	for _, row := range tensor.Reshape(v, 3, 3).Seq2() {
+		fmt.Println(row)
+	}
+
+	// Output:
+	// [1 2 1]
+	// [2 4 2]
+	// [1 2 1]
+}
+
+func ExampleConvSize() {
+	fmt.Println(tensor.ConvSize([]int{32, 32}, []int{3, 3}, []int{2, 1}, []int{1, 0}, []int{1, 2}))
+
+	// Output:
+	// [16 28]
+}
+
+func TestIm2col(t *testing.T) {
+	s := rand.NewPCG(1, 2)
+
+	cases := []struct {
+		shape, kernel, stride, padding, dilation []int
+	}{
+		{[]int{2, 3, 7}, []int{3}, []int{1}, []int{0}, []int{1}},
+		{[]int{2, 3, 7}, []int{3}, []int{2}, []int{2}, []int{2}},
+		{[]int{1, 2, 5, 6}, []int{3, 2}, []int{1, 2}, []int{1, 0}, []int{1, 1}},
+		{[]int{2, 1, 6, 6}, []int{2, 3}, []int{3, 1}, []int{2, 1}, []int{2, 2}},
+		{[]int{1, 2, 3, 4, 5}, []int{2, 2, 3}, []int{1, 2, 1}, []int{1, 1, 0}, []int{2, 1, 1}},
+	}
+
+	for _, c := range cases {
+		v := tensor.Randn(c.shape, s)
+		got := tensor.Im2col(v, c.kernel, c.stride, c.padding, c.dilation)
+		want := im2col(v, c.kernel, c.stride, c.padding, c.dilation)
+		if !tensor.SliceEqual(got.Shape, want.Shape) {
+			t.Fatalf("shape=%v, want=%v", got.Shape, want.Shape)
+		}
+
+		if !tensor.IsCloseAll(got, want, 1e-12, 1e-12) {
+			t.Errorf("got=%v, want=%v", got.Data, want.Data)
+		}
+
+		// adjoint: <im2col(v), w> = <v, col2im(w)>
+		w := tensor.Randn(got.Shape, s)
+		vw := tensor.Col2im(w, c.shape, c.kernel, c.stride, c.padding, c.dilation)
+		lhs := tensor.Sum(tensor.Mul(got, w)).At()
+		rhs := tensor.Sum(tensor.Mul(v, vw)).At()
+		if !tensor.IsCloseAll(tensor.Scalar(lhs), tensor.Scalar(rhs), 1e-10, 1e-10) {
+			t.Errorf("<im2col(v), w>=%v, <v, col2im(w)>=%v", lhs, rhs)
+		}
+	}
+}
+
+func TestIm2col_panic(t *testing.T) {
+	one := []int{1, 1}
+	cases := []struct {
+		name string
+		run  func()
+		want string
+	}{
+		{"kernel", func() {
+			tensor.Im2col(tensor.Zeros[float64](1, 1, 2, 2), []int{3, 3}, one, []int{0, 0}, one)
+		}, "kernel [3 3] with dilation [1 1] does not fit input [2 2] with padding [0 0]"},
+		{"axes", func() {
+			tensor.Im2col(tensor.Zeros[float64](1, 1, 2, 2), []int{1}, one, []int{0, 0}, one)
+		}, "len([1]) does not match 2 spatial axes"},
+		{"col2im", func() {
+			tensor.Col2im(tensor.Zeros[float64](1, 4, 3), []int{1, 1, 3, 3}, []int{2, 2}, one, []int{0, 0}, one)
+		}, "shape [1 4 3] does not match [1 4 4]"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != c.want {
+					t.Errorf("got=%v, want=%v", r, c.want)
+				}
+			}()
+
+			c.run()
+			t.Fail()
+		})
+	}
+}
+
+// im2col is a reference implementation of Im2col with At.
+func im2col(v *tensor.Tensor[float64], kernel, stride, padding, dilation []int) *tensor.Tensor[float64] {
+	n, c, in := v.Shape[0], v.Shape[1], v.Shape[2:]
+	out := tensor.ConvSize(in, kernel, stride, padding, dilation)
+	k, l := prod(kernel), prod(out)
+
+	col := tensor.Zeros[float64](n, c*k, l)
+	for b := range n {
+		for ch := range c {
+			for i := range k {
+				ki := index(i, kernel)
+				for j := range l {
+					oj := index(j, out)
+
+					at := []int{b, ch}
+					for d := range in {
+						p := oj[d]*stride[d] - padding[d] + ki[d]*dilation[d]
+						if p < 0 || p >= in[d] {
+							at = nil
+							break
+						}
+
+						at = append(at, p)
+					}
+
+					if at != nil {
+						col.Set([]int{b, ch*k + i, j}, v.At(at...))
+					}
+				}
+			}
+		}
+	}
+
+	return col
+}
+
+func prod(shape []int) int {
+	p := 1
+	for _, s := range shape {
+		p *= s
+	}
+
+	return p
+}
+
+func index(i int, shape []int) []int {
+	idx := make([]int, len(shape))
+	for d := len(shape) - 1; d >= 0; d-- {
+		idx[d] = i % shape[d]
+		i /= shape[d]
+	}
+
+	return idx
+}
@@ -5,6 +5,7 @@ import "slices"
 type Layout struct {
 	Shape  []int
 	Stride []int
+	Offset int
 }
 
 type Iterator struct {
@@ -20,19 +21,20 @@ func NewIterator(layouts ...*Layout) *Iterator {
 	shape := append([]int{}, layouts[0].Shape...)
 	ndim := len(layouts[0].Shape)
 
-	strides := make([][]int, len(layouts))
+	strides, offsets := make([][]int, len(layouts)), make([]int, len(layouts))
 	for i, layout := range layouts {
 		if !SliceEqual(layout.Shape, shape) {
 			panic("layouts have incompatible shapes")
 		}
 
 		strides[i] = append([]int{}, layout.Stride...)
+		offsets[i] = layout.Offset
 	}
 
 	return &Iterator{
 		shape:   shape,
 		coord:   make([]int, ndim),
-		offsets: make([]int, len(layouts)),
+		offsets: offsets,
 		strides: strides,
 		done:    slices.Contains(shape, 0),
 	}
@@ -2,7 +2,95 @@
 
 package tensor
 
-func matmul(a, b, o []float64, m, k, n int) {
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// tileM, tileN and tileK are the block sizes of the cache-blocked kernel.
+	// A tileK x tileN block of b (256KB for float64) is reused for tileM rows of a.
+	tileM = 32
+	tileN = 256
+	tileK = 128
+
+	// serialThreshold is the number of multiply-adds (m*k*n) below which
+	// the product is computed on the calling goroutine.
+	serialThreshold = 1 << 18
+)
+
+// matmul computes o += a * b for row-major a (m x k), b (k x n) and o (m x n).
+// Large products are split into (tileM x tileN) tiles of o that are
+// distributed across runtime.NumCPU() workers. Each tile is written by
+// exactly one worker, so no synchronization is required on o.
+func matmul[T Float](a, b, o []T, m, k, n int) {
+	if m*k*n < serialThreshold {
+		gemm(a, b, o, k, n, 0, m, 0, n)
+		return
+	}
+
+	// Example:
+	//   m = 100, n = 600, tileM = 32, tileN = 256
+	//   rows = 4, cols = 3, tiles = 12
+	//
+	//   tile 0: rows [0, 32),   cols [0, 256)
+	//   tile 1: rows [0, 32),   cols [256, 512)
+	//   tile 2: rows [0, 32),   cols [512, 600)
+	//   tile 3: rows [32, 64),  cols [0, 256)
+	//   ...
+	rows := (m + tileM - 1) / tileM
+	cols := (n + tileN - 1) / tileN
+	tiles := rows * cols
+	workers := min(runtime.NumCPU(), tiles)
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				t := int(next.Add(1) - 1)
+				if t >= tiles {
+					return
+				}
+
+				i0, j0 := (t/cols)*tileM, (t%cols)*tileN
+				gemm(a, b, o, k, n, i0, min(i0+tileM, m), j0, min(j0+tileN, n))
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// gemm computes o[i0:i1, j0:j1] += a[i0:i1, :] * b[:, j0:j1].
+// The k dimension is blocked by tileK so that the block of b stays in cache
+// while it is reused for every row in [i0, i1).
+func gemm[T Float](a, b, o []T, k, n, i0, i1, j0, j1 int) {
+	for p0 := 0; p0 < k; p0 += tileK {
+		p1 := min(p0+tileK, k)
+
+		for i := i0; i < i1; i++ {
+			ai := i * k
+			oi := o[i*n+j0 : i*n+j1]
+
+			for p := p0; p < p1; p++ {
+				aip := a[ai+p]
+				bp := b[p*n+j0 : p*n+j1]
+				for j, bpj := range bp {
+					oi[j] += aip * bpj
+				}
+			}
+		}
+	}
+}
+
+// matmulNaive computes o += a * b with a plain triple loop.
+// It is kept as a reference for the blocked kernel.
+func matmulNaive[T Float](a, b, o []T, m, k, n int) {
 	for i := range m {
 		ai := i * k
 		oi := i * n
@@ -11,7 +11,27 @@ import "C"
 
 import "unsafe"
 
-func matmul(a, b, c []float64, m, k, n int) {
+func matmul[T Float](a, b, c []T, m, k, n int) {
+	var zero T
+	if unsafe.Sizeof(zero) == 4 {
+		sgemm(
+			unsafe.Slice((*float32)(unsafe.Pointer(&a[0])), len(a)),
+			unsafe.Slice((*float32)(unsafe.Pointer(&b[0])), len(b)),
+			unsafe.Slice((*float32)(unsafe.Pointer(&c[0])), len(c)),
+			m, k, n,
+		)
+		return
+	}
+
+	dgemm(
+		unsafe.Slice((*float64)(unsafe.Pointer(&a[0])), len(a)),
+		unsafe.Slice((*float64)(unsafe.Pointer(&b[0])), len(b)),
+		unsafe.Slice((*float64)(unsafe.Pointer(&c[0])), len(c)),
+		m, k, n,
+	)
+}
+
+func dgemm(a, b, c []float64, m, k, n int) {
 	C.cblas_dgemm(
 		C.CblasRowMajor,
 		C.CblasNoTrans,
@@ -29,3 +49,22 @@ func matmul(a, b, c []float64, m, k, n int) {
 		C.int(n),
 	)
 }
+
+func sgemm(a, b, c []float32, m, k, n int) {
+	C.cblas_sgemm(
+		C.CblasRowMajor,
+		C.CblasNoTrans,
+		C.CblasNoTrans,
+		C.int(m),
+		C.int(n),
+		C.int(k),
+		C.float(1.0), // alpha
+		(*C.float)(unsafe.Pointer(&a[0])),
+		C.int(k),
+		(*C.float)(unsafe.Pointer(&b[0])),
+		C.int(n),
+		C.float(0.0), // beta
+		(*C.float)(unsafe.Pointer(&c[0])),
+		C.int(n),
+	)
+}
@@ -32,6 +32,54 @@ func ExampleMatMul2D() {
 	// 43 50
 }
 
+func ExampleMatMul2D_tiled() {
+	// larger than the serial threshold and not a multiple of the tile sizes
+	m, k, n := 67, 131, 300
+	a := tensor.Rand([]int{m, k}, rand.NewPCG(1, 2))
+	b := tensor.Rand([]int{k, n}, rand.NewPCG(3, 4))
+
+	o0 := make([]float64, m*n)
+	o1 := make([]float64, m*n)
+	tensor.MatMul2D(a.Data, b.Data, o0, m, k, n)
+	tensor.MatMul2DNaive(a.Data, b.Data, o1, m, k, n)
+
+	fmt.Println(tensor.IsCloseAll(
+		tensor.New([]int{m, n}, o0),
+		tensor.New([]int{m, n}, o1),
+	))
+
+	// Output:
+	// true
+}
+
+func TestMatMul2D(t *testing.T) {
+	cases := []struct {
+		m, k, n int
+	}{
+		{1, 1, 1},
+		{3, 5, 7},
+		{32, 128, 256},
+		{33, 129, 257},
+		{64, 64, 64},
+		{100, 200, 600},
+	}
+
+	for _, c := range cases {
+		a := tensor.Rand([]int{c.m, c.k}, rand.NewPCG(1, 2))
+		b := tensor.Rand([]int{c.k, c.n}, rand.NewPCG(3, 4))
+
+		want := make([]float64, c.m*c.n)
+		tensor.MatMul2DNaive(a.Data, b.Data, want, c.m, c.k, c.n)
+
+		got := make([]float64, c.m*c.n)
+		tensor.MatMul2D(a.Data, b.Data, got, c.m, c.k, c.n)
+
+		if !tensor.IsCloseAll(tensor.New([]int{c.m, c.n}, got), tensor.New([]int{c.m, c.n}, want)) {
+			t.Errorf("m=%v, k=%v, n=%v: got=%v, want=%v", c.m, c.k, c.n, got, want)
+		}
+	}
+}
+
 func benchmarkMatMul2D(b *testing.B, m, n, k int) {
 	a := make([]float64, m*n)
 	c := make([]float64, n*k)
@@ -59,3 +107,50 @@ func BenchmarkMatMul2D_1024(b *testing.B) {
 func BenchmarkMatMul2D_2048(b *testing.B) {
 	benchmarkMatMul2D(b, 2048, 2048, 2048)
 }
+
+func benchmarkMatMul2DNaive(b *testing.B, m, n, k int) {
+	a := make([]float64, m*n)
+	c := make([]float64, n*k)
+	o := make([]float64, m*k)
+
+	for i := range a {
+		a[i] = rand.Float64()
+	}
+
+	for i := range c {
+		c[i] = rand.Float64()
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		tensor.MatMul2DNaive(a, c, o, m, n, k)
+	}
+}
+
+func BenchmarkMatMul2DNaive_1024(b *testing.B) {
+	benchmarkMatMul2DNaive(b, 1024, 1024, 1024)
+}
+
+func BenchmarkMatMul2DNaive_2048(b *testing.B) {
+	benchmarkMatMul2DNaive(b, 2048, 2048, 2048)
+}
+
+func benchmarkMatMul(b *testing.B, m, n, k int) {
+	x := tensor.Rand([]int{m, n})
+	y := tensor.Rand([]int{n, k})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		_ = tensor.MatMul(x, y)
+	}
+}
+
+func BenchmarkMatMul_16(b *testing.B) {
+	benchmarkMatMul(b, 16, 16, 16)
+}
+
+func BenchmarkMatMul_512(b *testing.B) {
+	benchmarkMatMul(b, 512, 512, 512)
+}
@@ -0,0 +1,349 @@
+package tensor
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// npyMagic is the magic string at the beginning of a .npy file.
+	npyMagic = "\x93NUMPY"
+
+	// npyAlign is the alignment of the data in a .npy file.
+	npyAlign = 64
+
+	// npyGrowth is the number of digits reserved in the header for the first axis, as numpy does.
+	npyGrowth = 21
+)
+
+var (
+	npyDescr   = regexp.MustCompile(`'descr'\s*:\s*'([^']*)'`)
+	npyFortran = regexp.MustCompile(`'fortran_order'\s*:\s*(True|False)`)
+	npyShape   = regexp.MustCompile(`'shape'\s*:\s*\(([^)]*)\)`)
+)
+
+// WriteNPY writes v to w in the .npy format.
+// float64, float32 and int tensors are written as '<f8', '<f4' and '<i8'.
+// The data is written in C order. Non-contiguous views are made contiguous before writing.
+func WriteNPY[T Number](w io.Writer, v *Tensor[T]) error {
+	descr, err := npyDtype[T]()
+	if err != nil {
+		return err
+	}
+
+	// shape
+	dims := make([]string, len(v.Shape))
+	for i, s := range v.Shape {
+		dims[i] = strconv.Itoa(s)
+	}
+
+	shape := "(" + strings.Join(dims, ", ") + ")"
+	if len(dims) == 1 {
+		shape = "(" + dims[0] + ",)"
+	}
+
+	// header
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': %s, }", descr, shape)
+	if len(dims) > 0 {
+		header += strings.Repeat(" ", max(npyGrowth-len(dims[0]), 0))
+	}
+
+	// version 1.0 has a 2-byte header length, version 2.0 has a 4-byte header length
+	major, prefix := byte(1), len(npyMagic)+2+2
+	if len(header)+1+prefix+npyAlign > math.MaxUint16 {
+		major, prefix = 2, len(npyMagic)+2+4
+	}
+
+	pad := npyAlign - (prefix+len(header)+1)%npyAlign
+	header += strings.Repeat(" ", pad) + "\n"
+
+	var buf bytes.Buffer
+	buf.WriteString(npyMagic)
+	buf.Write([]byte{major, 0})
+	if major == 1 {
+		buf.Write(binary.LittleEndian.AppendUint16(nil, uint16(len(header))))
+	} else {
+		buf.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(header))))
+	}
+	buf.WriteString(header)
+
+	// data
+	c := Contiguous(v)
+	for _, a := range c.Data {
+		switch descr {
+		case "<f8":
+			buf.Write(binary.LittleEndian.AppendUint64(nil, math.Float64bits(float64(a))))
+		case "<f4":
+			buf.Write(binary.LittleEndian.AppendUint32(nil, math.Float32bits(float32(a))))
+		case "<i8":
+			buf.Write(binary.LittleEndian.AppendUint64(nil, uint64(int64(a))))
+		}
+	}
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// ReadNPY reads a tensor in the .npy format from r.
+// Floating-point ('f4', 'f8'), signed integer ('i1' to 'i8'), unsigned integer ('u1' to 'u8') and bool ('b1') dtypes
+// in either byte order are supported, and the elements are converted to T.
+// Data in Fortran order is converted to C order.
+func ReadNPY[T Number](r io.Reader) (*Tensor[T], error) {
+	// magic and version
+	prefix := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, fmt.Errorf("read magic: %w", err)
+	}
+
+	if string(prefix[:len(npyMagic)]) != npyMagic {
+		return nil, fmt.Errorf("invalid magic %q", prefix[:len(npyMagic)])
+	}
+
+	// header length
+	var hlen int
+	switch major := prefix[len(npyMagic)]; major {
+	case 1:
+		b := make([]byte, 2)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, fmt.Errorf("read header length: %w", err)
+		}
+
+		hlen = int(binary.LittleEndian.Uint16(b))
+	case 2, 3:
+		b := make([]byte, 4)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, fmt.Errorf("read header length: %w", err)
+		}
+
+		hlen = int(binary.LittleEndian.Uint32(b))
+	default:
+		return nil, fmt.Errorf("unsupported version %d.%d", major, prefix[len(npyMagic)+1])
+	}
+
+	header := make([]byte, hlen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	descr, fortran, shape, err := parseNPYHeader(string(header))
+	if err != nil {
+		return nil, err
+	}
+
+	decode, itemsize, err := npyDecoder[T](descr)
+	if err != nil {
+		return nil, err
+	}
+
+	// data
+	n, err := npySize(shape, itemsize)
+	if err != nil {
+		return nil, err
+	}
+
+	// the buffer grows with the data read, so a shape larger than the data does not allocate it
+	data, err := io.ReadAll(io.LimitReader(r, int64(n*itemsize)))
+	if err != nil {
+		return nil, fmt.Errorf("read data: %w", err)
+	}
+
+	if len(data) != n*itemsize {
+		return nil, fmt.Errorf("read data: %w", io.ErrUnexpectedEOF)
+	}
+
+	out := Zeros[T](shape...)
+	for i := range n {
+		out.Data[i] = decode(data[i*itemsize : (i+1)*itemsize])
+	}
+
+	if !fortran || len(shape) < 2 {
+		return out, nil
+	}
+
+	// Fortran order is C order with the axes reversed
+	rshape := slices.Clone(shape)
+	slices.Reverse(rshape)
+	axes := make([]int, len(shape))
+	for i := range axes {
+		axes[i] = len(shape) - 1 - i
+	}
+
+	return Clone(Transpose(Reshape(out, rshape...), axes...)), nil
+}
+
+// npySize returns the number of elements of the shape,
+// or an error if the number of bytes of the elements of itemsize overflows int.
+func npySize(shape []int, itemsize int) (int, error) {
+	n := 1
+	for _, d := range shape {
+		if d != 0 && n > math.MaxInt/itemsize/d {
+			return 0, fmt.Errorf("shape %v is too large", shape)
+		}
+
+		n *= d
+	}
+
+	return n, nil
+}
+
+// WriteNPZ writes the named tensors to w in the .npz format, an uncompressed zip archive of .npy files.
+// The entries are written in the sorted order of the names.
+func WriteNPZ[T Number](w io.Writer, tensors map[string]*Tensor[T]) error {
+	names := make([]string, 0, len(tensors))
+	for name := range tensors {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		f, err := zw.CreateHeader(&zip.FileHeader{
+			Name:   name + ".npy",
+			Method: zip.Store,
+		})
+		if err != nil {
+			return fmt.Errorf("create %q: %w", name, err)
+		}
+
+		if err := WriteNPY(f, tensors[name]); err != nil {
+			return fmt.Errorf("write %q: %w", name, err)
+		}
+	}
+
+	return zw.Close()
+}
+
+// ReadNPZ reads the named tensors in the .npz format from r.
+// Both uncompressed and compressed archives, as written by numpy.savez and numpy.savez_compressed, are supported.
+func ReadNPZ[T Number](r io.Reader) (map[string]*Tensor[T], error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read archive: %w", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, fmt.Errorf("open archive: %w", err)
+	}
+
+	out := make(map[string]*Tensor[T])
+	for _, f := range zr.File {
+		name := strings.TrimSuffix(f.Name, ".npy")
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("open %q: %w", f.Name, err)
+		}
+
+		v, err := ReadNPY[T](rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read %q: %w", f.Name, err)
+		}
+
+		out[name] = v
+	}
+
+	return out, nil
+}
+
+// parseNPYHeader returns the dtype, the fortran order and the shape in the header of a .npy file.
+func parseNPYHeader(header string) (string, bool, []int, error) {
+	descr := npyDescr.FindStringSubmatch(header)
+	if descr == nil {
+		return "", false, nil, fmt.Errorf("descr not found in header %q", header)
+	}
+
+	fortran := npyFortran.FindStringSubmatch(header)
+	if fortran == nil {
+		return "", false, nil, fmt.Errorf("fortran_order not found in header %q", header)
+	}
+
+	match := npyShape.FindStringSubmatch(header)
+	if match == nil {
+		return "", false, nil, fmt.Errorf("shape not found in header %q", header)
+	}
+
+	shape := make([]int, 0)
+	for _, s := range strings.Split(match[1], ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		// numpy may write the dimensions of a large array as 10L
+		d, err := strconv.Atoi(strings.TrimSuffix(s, "L"))
+		if err != nil || d < 0 {
+			return "", false, nil, fmt.Errorf("invalid shape %q", match[1])
+		}
+
+		shape = append(shape, d)
+	}
+
+	return descr[1], fortran[1] == "True", shape, nil
+}
+
+// npyDtype returns the .npy dtype of T.
+func npyDtype[T Number]() (string, error) {
+	switch k := reflect.TypeFor[T]().Kind(); k {
+	case reflect.Float64:
+		return "<f8", nil
+	case reflect.Float32:
+		return "<f4", nil
+	case reflect.Int:
+		return "<i8", nil
+	default:
+		return "", fmt.Errorf("unsupported kind %v", k)
+	}
+}
+
+// npyDecoder returns a function that decodes an element of the .npy dtype descr to T, and the size of the element in bytes.
+func npyDecoder[T Number](descr string) (func(b []byte) T, int, error) {
+	if len(descr) < 3 {
+		return nil, 0, fmt.Errorf("unsupported dtype %q", descr)
+	}
+
+	var order binary.ByteOrder
+	switch descr[0] {
+	case '<', '|', '=':
+		order = binary.LittleEndian
+	case '>':
+		order = binary.BigEndian
+	default:
+		return nil, 0, fmt.Errorf("unsupported byte order in dtype %q", descr)
+	}
+
+	switch descr[1:] {
+	case "f8":
+		return func(b []byte) T { return T(math.Float64frombits(order.Uint64(b))) }, 8, nil
+	case "f4":
+		return func(b []byte) T { return T(math.Float32frombits(order.Uint32(b))) }, 4, nil
+	case "i8":
+		return func(b []byte) T { return T(int64(order.Uint64(b))) }, 8, nil
+	case "i4":
+		return func(b []byte) T { return T(int32(order.Uint32(b))) }, 4, nil
+	case "i2":
+		return func(b []byte) T { return T(int16(order.Uint16(b))) }, 2, nil
+	case "i1":
+		return func(b []byte) T { return T(int8(b[0])) }, 1, nil
+	case "u8":
+		return func(b []byte) T { return T(order.Uint64(b)) }, 8, nil
+	case "u4":
+		return func(b []byte) T { return T(order.Uint32(b)) }, 4, nil
+	case "u2":
+		return func(b []byte) T { return T(order.Uint16(b)) }, 2, nil
+	case "u1", "b1":
+		return func(b []byte) T { return T(b[0]) }, 1, nil
+	default:
+		return nil, 0, fmt.Errorf("unsupported dtype %q", descr)
+	}
+}
@@ -0,0 +1,292 @@
+package tensor_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// The files in testdata follow the format written by numpy.save and numpy.savez:
+//
+//	np.save("float64.npy", np.arange(6, dtype="<f8").reshape(2, 3) / 2)
+//	np.save("float32.npy", np.array([[1, 2], [3, 4]], dtype="<f4"))
+//	np.save("int64.npy", np.arange(-3, 3, dtype="<i8").reshape(2, 3))
+//	np.save("int32.npy", np.array([1, 2, 3], dtype="<i4"))
+//	np.save("scalar.npy", np.float64(3.5))
+//	np.save("bigendian.npy", np.array([1.25, -2.5], dtype=">f8"))
+//	np.save("bool.npy", np.array([True, False, True]))
+//	np.save("fortran.npy", np.asfortranarray([[1.0, 2, 3], [4, 5, 6]]))
+//	np.savez("arrays.npz", x=np.load("float64.npy"), y=np.load("int64.npy"))
+//	np.savez_compressed("compressed.npz", x=np.load("float64.npy"), y=np.load("int64.npy"))
+
+func ExampleWriteNPY() {
+	v := tensor.New([]int{2, 3}, []float64{
+		1, 2, 3,
+		4, 5, 6,
+	})
+
+	var buf bytes.Buffer
+	if err := tensor.WriteNPY(&buf, v); err != nil {
+		panic(err)
+	}
+
+	w, err := tensor.ReadNPY[float64](&buf)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(w.Shape)
+	fmt.Println(w.Data)
+
+	// Output:
+	// [2 3]
+	// [1 2 3 4 5 6]
+}
+
+func ExampleWriteNPY_view() {
+	v := tensor.New([]int{2, 3}, []int{
+		1, 2, 3,
+		4, 5, 6,
+	})
+
+	var buf bytes.Buffer
+	if err := tensor.WriteNPY(&buf, tensor.Transpose(v)); err != nil {
+		panic(err)
+	}
+
+	w, err := tensor.ReadNPY[int](&buf)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(w.Shape)
+	fmt.Println(w.Data)
+
+	// Output:
+	// [3 2]
+	// [1 4 2 5 3 6]
+}
+
+func ExampleReadNPY() {
+	f, err := os.Open("testdata/fortran.npy")
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	v, err := tensor.ReadNPY[float64](f)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, row := range v.Seq2() {
+		fmt.Println(row)
+	}
+
+	// Output:
+	// [1 2 3]
+	// [4 5 6]
+}
+
+func ExampleWriteNPZ() {
+	tensors := map[string]*tensor.Tensor[float64]{
+		"w": tensor.New([]int{2, 2}, []float64{1, 2, 3, 4}),
+		"b": tensor.New([]int{2}, []float64{5, 6}),
+	}
+
+	var buf bytes.Buffer
+	if err := tensor.WriteNPZ(&buf, tensors); err != nil {
+		panic(err)
+	}
+
+	got, err := tensor.ReadNPZ[float64](&buf)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(len(got))
+	fmt.Println(got["w"].Shape, got["w"].Data)
+	fmt.Println(got["b"].Shape, got["b"].Data)
+
+	// Output:
+	// 2
+	// [2 2] [1 2 3 4]
+	// [2] [5 6]
+}
+
+func TestReadNPY(t *testing.T) {
+	cases := []struct {
+		name  string
+		shape []int
+		data  []float64
+	}{
+		{"float64.npy", []int{2, 3}, []float64{0, 0.5, 1, 1.5, 2, 2.5}},
+		{"float32.npy", []int{2, 2}, []float64{1, 2, 3, 4}},
+		{"int64.npy", []int{2, 3}, []float64{-3, -2, -1, 0, 1, 2}},
+		{"int32.npy", []int{3}, []float64{1, 2, 3}},
+		{"scalar.npy", []int{}, []float64{3.5}},
+		{"bigendian.npy", []int{2}, []float64{1.25, -2.5}},
+		{"bool.npy", []int{3}, []float64{1, 0, 1}},
+		{"fortran.npy", []int{2, 3}, []float64{1, 2, 3, 4, 5, 6}},
+	}
+
+	for _, c := range cases {
+		f, err := os.Open(filepath.Join("testdata", c.name))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		v, err := tensor.ReadNPY[float64](f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%v: %v", c.name, err)
+		}
+
+		if !tensor.SliceEqual(v.Shape, c.shape) {
+			t.Errorf("%v: shape=%v, want=%v", c.name, v.Shape, c.shape)
+		}
+
+		if !slices.Equal(v.Data, c.data) {
+			t.Errorf("%v: data=%v, want=%v", c.name, v.Data, c.data)
+		}
+	}
+}
+
+func TestWriteNPY(t *testing.T) {
+	cases := []struct {
+		name  string
+		write func(buf *bytes.Buffer) error
+	}{
+		{
+			name: "float64.npy",
+			write: func(buf *bytes.Buffer) error {
+				return tensor.WriteNPY(buf, tensor.New([]int{2, 3}, []float64{0, 0.5, 1, 1.5, 2, 2.5}))
+			},
+		},
+		{
+			name: "float32.npy",
+			write: func(buf *bytes.Buffer) error {
+				return tensor.WriteNPY(buf, tensor.New([]int{2, 2}, []float32{1, 2, 3, 4}))
+			},
+		},
+		{
+			name: "int64.npy",
+			write: func(buf *bytes.Buffer) error {
+				return tensor.WriteNPY(buf, tensor.New([]int{2, 3}, []int{-3, -2, -1, 0, 1, 2}))
+			},
+		},
+		{
+			name: "scalar.npy",
+			write: func(buf *bytes.Buffer) error {
+				return tensor.WriteNPY(buf, tensor.Scalar(3.5))
+			},
+		},
+	}
+
+	for _, c := range cases {
+		want, err := os.ReadFile(filepath.Join("testdata", c.name))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		if err := c.write(&buf); err != nil {
+			t.Fatalf("%v: %v", c.name, err)
+		}
+
+		if !bytes.Equal(buf.Bytes(), want) {
+			t.Errorf("%v: got=%q, want=%q", c.name, buf.Bytes(), want)
+		}
+	}
+}
+
+func TestReadNPZ(t *testing.T) {
+	for _, name := range []string{"arrays.npz", "compressed.npz"} {
+		f, err := os.Open(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := tensor.ReadNPZ[float64](f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+
+		if len(got) != 2 {
+			t.Errorf("%v: len=%v", name, len(got))
+		}
+
+		if !slices.Equal(got["x"].Data, []float64{0, 0.5, 1, 1.5, 2, 2.5}) {
+			t.Errorf("%v: x=%v", name, got["x"].Data)
+		}
+
+		if !slices.Equal(got["y"].Data, []float64{-3, -2, -1, 0, 1, 2}) {
+			t.Errorf("%v: y=%v", name, got["y"].Data)
+		}
+	}
+}
+
+func TestWriteNPZ(t *testing.T) {
+	f, err := os.Open("testdata/arrays.npz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	want, err := tensor.ReadNPZ[int](f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := tensor.WriteNPZ(&buf, want); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := tensor.ReadNPZ[int](&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, v := range want {
+		if !tensor.SliceEqual(got[name].Shape, v.Shape) || !tensor.SliceEqual(got[name].Data, v.Data) {
+			t.Errorf("%v: got=%v, want=%v", name, got[name], v)
+		}
+	}
+}
+
+func TestReadNPY_invalid(t *testing.T) {
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", []byte{}},
+		{"magic", []byte("\x93NUMPZ\x01\x00")},
+		{"version", []byte("\x93NUMPY\x04\x00")},
+		{"header", []byte("\x93NUMPY\x01\x00\x10\x00{}")},
+		{"dtype", append([]byte("\x93NUMPY\x01\x00\x3c\x00"), []byte("{'descr': '<c16', 'fortran_order': False, 'shape': (1,), }\n")...)},
+		{"data", append([]byte("\x93NUMPY\x01\x00\x3b\x00"), []byte("{'descr': '<f8', 'fortran_order': False, 'shape': (1,), }\n")...)},
+		{"overflow", npy("{'descr': '<f8', 'fortran_order': False, 'shape': (4611686018427387904, 4), }\n")},
+		{"large", npy("{'descr': '<f8', 'fortran_order': False, 'shape': (1099511627776,), }\n")},
+	}
+
+	for _, c := range cases {
+		if _, err := tensor.ReadNPY[float64](bytes.NewReader(c.data)); err == nil {
+			t.Errorf("%v: expected error", c.name)
+		}
+	}
+}
+
+// npy returns the .npy version 1.0 data of the header without the data.
+func npy(header string) []byte {
+	b := []byte("\x93NUMPY\x01\x00")
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(header)))
+	return append(b, header...)
+}
@@ -0,0 +1,188 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+)
+
+// Range selects the elements start, start+step, ... before stop along an axis, like Python's start:stop:step.
+// Negative Start and Stop count from the end of the axis, and out-of-range values are clipped.
+// A zero Step is treated as 1.
+type Range struct {
+	Start, Stop, Step int
+	index             bool
+}
+
+// All returns a Range that selects every element along an axis, like ":".
+func All() Range {
+	return Range{Start: 0, Stop: math.MaxInt, Step: 1}
+}
+
+// Reverse returns a Range that selects every element along an axis in reverse order, like "::-1".
+func Reverse() Range {
+	return Range{Start: math.MaxInt, Stop: math.MinInt, Step: -1}
+}
+
+// From returns a Range that selects the elements from start to the end of an axis, like "start::step".
+// If step is negative, the elements are selected toward the beginning of the axis.
+func From(start int, step ...int) Range {
+	if len(step) > 0 && step[0] < 0 {
+		return R(start, math.MinInt, step...)
+	}
+
+	return R(start, math.MaxInt, step...)
+}
+
+// R returns a Range that selects the elements from start to stop, like "start:stop:step".
+func R(start, stop int, step ...int) Range {
+	s := 1
+	if len(step) > 0 {
+		s = step[0]
+	}
+
+	return Range{Start: start, Stop: stop, Step: s}
+}
+
+// Index returns a Range that selects the element at i and removes the axis, like "i".
+func Index(i int) Range {
+	return Range{Start: i, Stop: i + 1, Step: 1, index: true}
+}
+
+// Slice returns a view of v sliced by the given ranges, one per leading axis.
+// Axes without a range are selected entirely.
+// The view shares the underlying data with v. A negative step gives a negative stride,
+// and the Offset of the view is the index in Data of its first element.
+func Slice[T Number](v *Tensor[T], ranges ...Range) *Tensor[T] {
+	shape, stride, offset, err := slice(v.Shape, v.Stride, ranges...)
+	if err != nil {
+		panic(err)
+	}
+
+	if size(shape) == 0 {
+		return &Tensor[T]{
+			Shape:    shape,
+			Stride:   stride,
+			Data:     v.Data[:0],
+			ReadOnly: v.ReadOnly,
+		}
+	}
+
+	// the first and the last element of the view in memory
+	first, last := v.Offset+offset, v.Offset+offset
+	for i := range shape {
+		if stride[i] < 0 {
+			first += (shape[i] - 1) * stride[i]
+			continue
+		}
+
+		last += (shape[i] - 1) * stride[i]
+	}
+
+	return &Tensor[T]{
+		Shape:    shape,
+		Stride:   stride,
+		Data:     v.Data[first : last+1],
+		Offset:   v.Offset + offset - first,
+		ReadOnly: v.ReadOnly,
+	}
+}
+
+// SliceCopy returns a new tensor with the elements of v selected by the given ranges, one per leading axis.
+// Unlike Slice, the result does not share the underlying data with v.
+func SliceCopy[T Number](v *Tensor[T], ranges ...Range) *Tensor[T] {
+	return Clone(Slice(v, ranges...))
+}
+
+// SliceAdd returns a new tensor with w added to the elements of v selected by the given ranges.
+// w must have the shape of Slice(v, ranges...).
+func SliceAdd[T Number](v, w *Tensor[T], ranges ...Range) *Tensor[T] {
+	out := Clone(v)
+	view := Slice(out, ranges...)
+	if !SliceEqual(view.Shape, w.Shape) {
+		panic(fmt.Sprintf("shape %v is not equal to the sliced shape %v", w.Shape, view.Shape))
+	}
+
+	it := NewIterator(w.Layout(), view.Layout())
+	for it.Next() {
+		view.Data[it.Offset(1)] += w.Data[it.Offset(0)]
+	}
+
+	return out
+}
+
+// slice returns the shape, stride and data offset of a tensor with the given shape and stride sliced by ranges.
+// The returned stride is relative to the offset and may be negative.
+func slice(shape, stride []int, ranges ...Range) ([]int, []int, int, error) {
+	ndim := len(shape)
+	if len(ranges) > ndim {
+		return nil, nil, 0, fmt.Errorf("too many ranges=%d for ndim=%d", len(ranges), ndim)
+	}
+
+	var offset int
+	oshape := make([]int, 0, ndim)
+	ostride := make([]int, 0, ndim)
+	for i := range ndim {
+		r := All()
+		if i < len(ranges) {
+			r = ranges[i]
+		}
+
+		n := shape[i]
+		if r.index {
+			idx := r.Start
+			if idx < 0 {
+				idx += n
+			}
+
+			if idx < 0 || idx >= n {
+				return nil, nil, 0, fmt.Errorf("index %d out of range for axis=%d (shape=%v)", r.Start, i, shape)
+			}
+
+			offset += idx * stride[i]
+			continue
+		}
+
+		start, count, step := adjRange(r, n)
+		if count > 0 {
+			offset += start * stride[i]
+		}
+
+		oshape = append(oshape, count)
+		ostride = append(ostride, stride[i]*step)
+	}
+
+	return oshape, ostride, offset, nil
+}
+
+// adjRange returns the first index, the number of elements and the step selected by r along an axis of length n.
+// It follows the semantics of Python's slice.indices.
+func adjRange(r Range, n int) (int, int, int) {
+	step := r.Step
+	if step == 0 {
+		step = 1
+	}
+
+	clip := func(x, lo, hi int) int {
+		if x < 0 {
+			x += n
+		}
+
+		return min(max(x, lo), hi)
+	}
+
+	if step > 0 {
+		start, stop := clip(r.Start, 0, n), clip(r.Stop, 0, n)
+		if stop <= start {
+			return start, 0, step
+		}
+
+		return start, (stop - start + step - 1) / step, step
+	}
+
+	start, stop := clip(r.Start, -1, n-1), clip(r.Stop, -1, n-1)
+	if start <= stop {
+		return start, 0, step
+	}
+
+	return start, (start - stop - step - 1) / -step, step
+}
@@ -0,0 +1,259 @@
+package tensor_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+func ExampleSlice() {
+	v := tensor.New([]int{3, 4}, []int{
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+	})
+
+	w := tensor.Slice(v, tensor.R(1, 3), tensor.From(0, 2))
+	fmt.Println(w.Shape, w.Stride)
+	for _, row := range w.Seq2() {
+		fmt.Println(row)
+	}
+
+	// Output:
+	// [2 2] [4 2]
+	// [5 7]
+	// [9 11]
+}
+
+func ExampleSlice_view() {
+	v := tensor.New([]int{2, 3}, []int{
+		1, 2, 3,
+		4, 5, 6,
+	})
+
+	w := tensor.Slice(v, tensor.All(), tensor.R(1, 3))
+	w.Set([]int{0, 0}, 100)
+	fmt.Println(v.Data)
+	fmt.Println(tensor.IsContiguous(w))
+
+	// Output:
+	// [1 100 3 4 5 6]
+	// false
+}
+
+func ExampleSlice_negative() {
+	v := tensor.New([]int{2, 3}, []int{
+		1, 2, 3,
+		4, 5, 6,
+	})
+
+	fmt.Println(tensor.Slice(v, tensor.R(-1, 2)).Data)
+	fmt.Println(tensor.Slice(v, tensor.All(), tensor.R(-2, 3)).Data)
+
+	// Output:
+	// [4 5 6]
+	// [2 3 4 5 6]
+}
+
+func ExampleSliceCopy() {
+	v := tensor.New([]int{2, 3}, []int{
+		1, 2, 3,
+		4, 5, 6,
+	})
+
+	fmt.Println(tensor.SliceCopy(v, tensor.All(), tensor.Reverse()).Data)
+	fmt.Println(tensor.SliceCopy(v, tensor.Reverse(), tensor.R(-1, -4, -2)).Data)
+
+	w := tensor.SliceCopy(v, tensor.All(), tensor.R(1, 3))
+	w.Set([]int{0, 0}, 100)
+	fmt.Println(v.Data)
+
+	// Output:
+	// [3 2 1 6 5 4]
+	// [6 4 3 1]
+	// [1 2 3 4 5 6]
+}
+
+func ExampleSlice_index() {
+	// (N, T, H)
+	v := tensor.New([]int{2, 3, 2}, []int{
+		1, 2,
+		3, 4,
+		5, 6,
+
+		7, 8,
+		9, 10,
+		11, 12,
+	})
+
+	// last timestep
+	w := tensor.Slice(v, tensor.All(), tensor.Index(-1))
+	fmt.Println(w.Shape)
+	fmt.Println(w.Data)
+
+	// Output:
+	// [2 2]
+	// [5 6 7 8 9 10 11 12]
+}
+
+func ExampleSlice_contiguous() {
+	v := tensor.New([]int{3, 2}, []int{
+		1, 2,
+		3, 4,
+		5, 6,
+	})
+
+	w := tensor.Slice(v, tensor.R(1, 3))
+	fmt.Println(tensor.IsContiguous(w), w.Data)
+	fmt.Println(tensor.AddC(10, w).Data)
+
+	// Output:
+	// true [3 4 5 6]
+	// [13 14 15 16]
+}
+
+func ExampleSliceAdd() {
+	v := tensor.Zeros[int](2, 3)
+	w := tensor.New([]int{2, 2}, []int{
+		1, 2,
+		3, 4,
+	})
+
+	fmt.Println(tensor.SliceAdd(v, w, tensor.All(), tensor.R(0, 3, 2)).Data)
+	fmt.Println(tensor.SliceAdd(v, w, tensor.All(), tensor.R(2, 0, -1)).Data)
+	fmt.Println(tensor.SliceAdd(v, tensor.New([]int{3}, []int{1, 2, 3}), tensor.Index(-1)).Data)
+
+	// Output:
+	// [1 0 2 3 0 4]
+	// [0 2 1 0 4 3]
+	// [0 0 0 1 2 3]
+}
+
+func ExampleSlice_invalid() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println(r)
+			return
+		}
+
+		panic("unexpected panic for index")
+	}()
+
+	v := tensor.Zeros[int](2, 3)
+	_ = tensor.Slice(v, tensor.Index(2))
+	panic("unreachable")
+
+	// Output:
+	// index 2 out of range for axis=0 (shape=[2 3])
+}
+
+func ExampleSlice_reverse() {
+	v := tensor.New([]int{2, 3}, []int{
+		1, 2, 3,
+		4, 5, 6,
+	})
+
+	w := tensor.Slice(v, tensor.Reverse(), tensor.R(-1, -4, -2))
+	fmt.Println(w.Shape, w.Stride, w.Offset)
+	for _, row := range w.Seq2() {
+		fmt.Println(row)
+	}
+
+	// the view shares the data with v
+	w.Set([]int{0, 0}, 100)
+	fmt.Println(v.Data)
+
+	// Output:
+	// [2 2] [-3 -2] 5
+	// [6 4]
+	// [3 1]
+	// [1 2 3 4 5 100]
+}
+
+func ExampleSlice_reverseTwice() {
+	v := tensor.Arange(0, 6)
+
+	w := tensor.Slice(tensor.Slice(v, tensor.R(1, 5)), tensor.Reverse())
+	fmt.Println(w.Offset, w.At(), tensor.Clone(w).Data)
+
+	u := tensor.Slice(w, tensor.From(-1, -2))
+	fmt.Println(u.Stride, u.Offset, tensor.Clone(u).Data)
+
+	// Output:
+	// 3 4 [4 3 2 1]
+	// [2] 0 [1 3]
+}
+
+func TestSlice_reverse(t *testing.T) {
+	v := tensor.New([]int{2, 3}, []float64{
+		1, 2, 3,
+		4, 5, 6,
+	})
+
+	// a reversed view gives the same results as its copy
+	w := tensor.Slice(v, tensor.Reverse(), tensor.Reverse())
+	c := tensor.SliceCopy(v, tensor.Reverse(), tensor.Reverse())
+	if tensor.IsContiguous(w) {
+		t.Errorf("reversed view is contiguous")
+	}
+
+	cases := []struct {
+		name      string
+		got, want *tensor.Tensor[float64]
+	}{
+		{"Clone", tensor.Clone(w), c},
+		{"AddC", tensor.AddC(1, w), tensor.AddC(1, c)},
+		{"Add", tensor.Add(w, v), tensor.Add(c, v)},
+		{"Sum", tensor.Sum(w, 1), tensor.Sum(c, 1)},
+		{"Max", tensor.Max(w, 0), tensor.Max(c, 0)},
+		{"Transpose", tensor.Clone(tensor.Transpose(w)), tensor.Transpose(c)},
+		{"BroadcastTo", tensor.Clone(tensor.BroadcastTo(w, 2, 2, 3)), tensor.BroadcastTo(c, 2, 2, 3)},
+		{"Reshape", tensor.Reshape(w, 3, 2), tensor.Reshape(c, 3, 2)},
+		{"Squeeze", tensor.Clone(tensor.Squeeze(tensor.Unsqueeze(w, 0))), c},
+		{"MatMul", tensor.MatMul(w, tensor.Transpose(v)), tensor.MatMul(c, tensor.Transpose(v))},
+		{"Einsum", tensor.Einsum("ij->ji", w), tensor.Einsum("ij->ji", c)},
+		{"SliceAdd", tensor.SliceAdd(w, v, tensor.All()), tensor.SliceAdd(c, v, tensor.All())},
+	}
+
+	for _, c := range cases {
+		if !tensor.IsCloseAll(c.got, c.want) {
+			t.Errorf("%v: got=%v, want=%v", c.name, c.got.Data, c.want.Data)
+		}
+	}
+}
+
+func TestSlice(t *testing.T) {
+	v := tensor.Arange(0, 10)
+
+	cases := []struct {
+		r    tensor.Range
+		want []int
+	}{
+		{tensor.All(), []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{tensor.Reverse(), []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}},
+		{tensor.R(2, 5), []int{2, 3, 4}},
+		{tensor.R(-3, 100), []int{7, 8, 9}},
+		{tensor.R(-100, 2), []int{0, 1}},
+		{tensor.R(1, 9, 3), []int{1, 4, 7}},
+		{tensor.R(8, 1, -3), []int{8, 5, 2}},
+		{tensor.R(-1, -100, -4), []int{9, 5, 1}},
+		{tensor.R(5, 5), []int{}},
+		{tensor.R(5, 2), []int{}},
+		{tensor.R(2, 5, -1), []int{}},
+		{tensor.From(7), []int{7, 8, 9}},
+		{tensor.From(-2, -1), []int{8, 7, 6, 5, 4, 3, 2, 1, 0}},
+		{tensor.Range{Start: 1, Stop: 3}, []int{1, 2}},
+	}
+
+	for _, c := range cases {
+		want := tensor.New([]int{len(c.want)}, c.want)
+		if got := tensor.SliceCopy(v, c.r); !tensor.EqualAll(got, want) {
+			t.Errorf("%+v: got=%v, want=%v", c.r, got.Data, c.want)
+		}
+
+		if got := tensor.Clone(tensor.Slice(v, c.r)); !tensor.EqualAll(got, want) {
+			t.Errorf("%+v: view=%v, want=%v", c.r, got.Data, c.want)
+		}
+	}
+}
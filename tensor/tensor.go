@@ -7,13 +7,19 @@ import (
 	randv2 "math/rand/v2"
 	"runtime"
 	"sync"
+	"unsafe"
 
 	"github.com/itsubaki/autograd/rand"
 )
 
 // Number is the set of numeric types supported by Tensor.
 type Number interface {
-	~int | ~float64
+	~int | ~float32 | ~float64
+}
+
+// Float is the set of floating-point types supported by Tensor.
+type Float interface {
+	~float32 | ~float64
 }
 
 // Tensor represents a multi-dimensional array.
@@ -21,10 +27,13 @@ type Number interface {
 // Non-contiguous views (created via Transpose, BroadcastTo, etc.) may share underlying data but have stride patterns that do not follow row-major order.
 // Such operations may create views without copying data, so the logical element order may differ from the physical memory layout.
 // ReadOnly is set on views when writes could alias the same underlying element through multiple logical indices.
+// Offset is the index in Data of the element at the origin, which is not 0 for views with negative strides,
+// such as a reversed Slice.
 type Tensor[T Number] struct {
 	Shape    []int
 	Stride   []int
 	Data     []T
+	Offset   int
 	ReadOnly bool
 }
 
@@ -148,14 +157,14 @@ func Eye[T Number](n int) *Tensor[T] {
 // FlatIndex returns the index in the flat data slice for the given multi-dimensional indices.
 func FlatIndex[T Number](v *Tensor[T], indices ...int) int {
 	if len(indices) == 0 {
-		return 0
+		return v.Offset
 	}
 
 	if len(indices) != v.NumDims() {
 		panic(fmt.Sprintf("indices length=%v are not equal to ndim=%v", len(indices), v.NumDims()))
 	}
 
-	var idx int
+	idx := v.Offset
 	for i, c := range indices {
 		if c < 0 || c >= v.Shape[i] {
 			panic(fmt.Sprintf("indices=%v out of range for axis=%v (shape=%v)", c, i, v.Shape))
@@ -177,11 +186,12 @@ func (v *Tensor[T]) Size() int {
 	return size(v.Shape)
 }
 
-// Layout returns the shape and stride of the tensor.
+// Layout returns the shape, stride and offset of the tensor.
 func (v *Tensor[T]) Layout() *Layout {
 	return &Layout{
 		Shape:  append([]int{}, v.Shape...),
 		Stride: append([]int{}, v.Stride...),
+		Offset: v.Offset,
 	}
 }
 
@@ -251,6 +261,26 @@ func Clone[T Number](v *Tensor[T]) *Tensor[T] {
 	return out
 }
 
+// Copy copies the elements of src to dst with the same shape, and returns dst.
+// dst must be contiguous.
+func Copy[T Number](dst, src *Tensor[T]) *Tensor[T] {
+	if !SliceEqual(dst.Shape, src.Shape) || !IsContiguous(dst) {
+		panic(fmt.Sprintf("shape %v does not match contiguous %v", src.Shape, dst.Shape))
+	}
+
+	if IsContiguous(src) {
+		copy(dst.Data, src.Data)
+		return dst
+	}
+
+	it := NewIterator(dst.Layout(), src.Layout())
+	for it.Next() {
+		dst.Data[it.Offset(0)] = src.Data[it.Offset(1)]
+	}
+
+	return dst
+}
+
 // Contiguous returns a contiguous tensor.
 // If the tensor is already contiguous, it returns the original tensor.
 // Otherwise, it returns a clone of the tensor.
@@ -268,8 +298,14 @@ func Int[T Number](v *Tensor[T]) *Tensor[int] {
 }
 
 // Float64 returns a new tensor with elements cast to float64.
+// It is a shorthand for Convert[float64].
 func Float64[T Number](v *Tensor[T]) *Tensor[float64] {
-	return F(v, func(a T) float64 { return float64(a) })
+	return Convert[float64](v)
+}
+
+// Convert returns a new tensor with elements cast to U.
+func Convert[U, T Number](v *Tensor[T]) *Tensor[U] {
+	return F(v, func(a T) U { return U(a) })
 }
 
 // AddC returns a new tensor with each element in v added to c.
@@ -288,38 +324,38 @@ func MulC[T Number](c T, v *Tensor[T]) *Tensor[T] {
 }
 
 // Pow returns a new tensor with each element in v raised to the power of p.
-func Pow(p float64, v *Tensor[float64]) *Tensor[float64] {
-	return F(v, func(a float64) float64 { return math.Pow(a, p) })
+func Pow[T Float](p T, v *Tensor[T]) *Tensor[T] {
+	return F(v, func(a T) T { return T(math.Pow(float64(a), float64(p))) })
 }
 
 // Sqrt returns a new tensor with the square root of each element in v.
-func Sqrt(v *Tensor[float64]) *Tensor[float64] {
-	return F(v, func(a float64) float64 { return math.Sqrt(a) })
+func Sqrt[T Float](v *Tensor[T]) *Tensor[T] {
+	return F(v, func(a T) T { return T(math.Sqrt(float64(a))) })
 }
 
 // Exp returns a new tensor with the exponential of each element in v.
-func Exp(v *Tensor[float64]) *Tensor[float64] {
-	return F(v, func(a float64) float64 { return math.Exp(a) })
+func Exp[T Float](v *Tensor[T]) *Tensor[T] {
+	return F(v, func(a T) T { return T(math.Exp(float64(a))) })
 }
 
 // Log returns a new tensor with the natural logarithm of each element in v.
-func Log(v *Tensor[float64]) *Tensor[float64] {
-	return F(v, func(a float64) float64 { return math.Log(a) })
+func Log[T Float](v *Tensor[T]) *Tensor[T] {
+	return F(v, func(a T) T { return T(math.Log(float64(a))) })
 }
 
 // Sin returns a new tensor with the sine of each element in v.
-func Sin(v *Tensor[float64]) *Tensor[float64] {
-	return F(v, func(a float64) float64 { return math.Sin(a) })
+func Sin[T Float](v *Tensor[T]) *Tensor[T] {
+	return F(v, func(a T) T { return T(math.Sin(float64(a))) })
 }
 
 // Cos returns a new tensor with the cosine of each element in v.
-func Cos(v *Tensor[float64]) *Tensor[float64] {
-	return F(v, func(a float64) float64 { return math.Cos(a) })
+func Cos[T Float](v *Tensor[T]) *Tensor[T] {
+	return F(v, func(a T) T { return T(math.Cos(float64(a))) })
 }
 
 // Tanh returns a new tensor with the hyperbolic tangent of each element in v.
-func Tanh(v *Tensor[float64]) *Tensor[float64] {
-	return F(v, func(a float64) float64 { return math.Tanh(a) })
+func Tanh[T Float](v *Tensor[T]) *Tensor[T] {
+	return F(v, func(a T) T { return T(math.Tanh(float64(a))) })
 }
 
 // Clip returns a new tensor with elements that are clipped to the interval [min, max].
@@ -391,9 +427,9 @@ func Equal(v, w *Tensor[int]) *Tensor[int] {
 }
 
 // IsClose returns a new tensor with elements that are 1 if v and w are close enough and 0 otherwise.
-func IsClose(v, w *Tensor[float64], tol ...float64) *Tensor[int] {
-	return F2(v, w, func(a, b float64) int {
-		if isClose(a, b, tol...) {
+func IsClose[T Float](v, w *Tensor[T], tol ...float64) *Tensor[int] {
+	return F2(v, w, func(a, b T) int {
+		if isClose(float64(a), float64(b), tol...) {
 			return 1
 		}
 
@@ -492,6 +528,7 @@ func Transpose[T Number](v *Tensor[T], axes ...int) *Tensor[T] {
 		Shape:    transpose(perm, v.Shape),
 		Stride:   transpose(perm, v.Stride),
 		Data:     v.Data,
+		Offset:   v.Offset,
 		ReadOnly: v.ReadOnly,
 	}
 }
@@ -547,6 +584,7 @@ func BroadcastTo[T Number](v *Tensor[T], shape ...int) *Tensor[T] {
 		Shape:    append([]int{}, shape...),
 		Stride:   stride,
 		Data:     v.Data,
+		Offset:   v.Offset,
 		ReadOnly: readOnly,
 	}
 }
@@ -620,6 +658,7 @@ func Squeeze[T Number](v *Tensor[T], axes ...int) *Tensor[T] {
 		Shape:    shape,
 		Stride:   stride,
 		Data:     v.Data,
+		Offset:   v.Offset,
 		ReadOnly: v.ReadOnly,
 	}
 }
@@ -655,6 +694,7 @@ func Unsqueeze[T Number](v *Tensor[T], axis int) *Tensor[T] {
 		Shape:    shape,
 		Stride:   stride,
 		Data:     v.Data,
+		Offset:   v.Offset,
 		ReadOnly: v.ReadOnly,
 	}
 }
@@ -720,6 +760,70 @@ func Take[T Number](v *Tensor[T], axis int, indices []int) *Tensor[T] {
 	return out
 }
 
+// Gather returns a new tensor with elements selected from v by the index tensor along the specified axis.
+// index must have the same number of dimensions as v, and the result has the shape of index.
+// For axis=1, out[i][j][k] = v[i][index[i][j][k]][k].
+func Gather[T Number](v *Tensor[T], axis int, index *Tensor[int]) *Tensor[T] {
+	ax, err := adjGather(v.Shape, index.Shape, axis)
+	if err != nil {
+		panic(err)
+	}
+
+	out := Zeros[T](index.Shape...)
+	it := NewIterator(index.Layout(), out.Layout())
+	for it.Next() {
+		vidx := append([]int{}, it.Coord()...)
+		vidx[ax], err = adjIndex(index.Data[it.Offset(0)], v.Shape, ax)
+		if err != nil {
+			panic(err)
+		}
+
+		out.Data[it.Offset(1)] = v.At(vidx...)
+	}
+
+	return out
+}
+
+// ScatterAddIndex returns a new tensor with elements of w added to v at the positions given by the index tensor along the specified axis.
+// It is the adjoint of Gather. index must have the same number of dimensions as v and w.
+// For axis=1, out[i][index[i][j][k]][k] += w[i][j][k].
+func ScatterAddIndex[T Number](v, w *Tensor[T], axis int, index *Tensor[int]) *Tensor[T] {
+	ax, err := adjGather(v.Shape, index.Shape, axis)
+	if err != nil {
+		panic(err)
+	}
+
+	if w.NumDims() != index.NumDims() {
+		panic(fmt.Sprintf("index ndim=%v is not equal to ndim=%v", index.NumDims(), w.NumDims()))
+	}
+
+	for i := range index.Shape {
+		if index.Shape[i] > w.Shape[i] {
+			panic(fmt.Sprintf("index shape %v is larger than shape %v at axis=%v", index.Shape, w.Shape, i))
+		}
+	}
+
+	wv := &Layout{
+		Shape:  index.Shape,
+		Stride: w.Stride,
+		Offset: w.Offset,
+	}
+
+	out := Clone(v)
+	it := NewIterator(index.Layout(), wv)
+	for it.Next() {
+		oidx := append([]int{}, it.Coord()...)
+		oidx[ax], err = adjIndex(index.Data[it.Offset(0)], v.Shape, ax)
+		if err != nil {
+			panic(err)
+		}
+
+		out.AddAt(oidx, w.Data[it.Offset(1)])
+	}
+
+	return out
+}
+
 // Concat returns a new tensor by concatenating the tensors along the given axis.
 func Concat[T Number](v []*Tensor[T], axis int) *Tensor[T] {
 	ndim := v[0].NumDims()
@@ -888,7 +992,7 @@ func Tile[T Number](v *Tensor[T], n, axis int) *Tensor[T] {
 	if ndim == 0 {
 		data := make([]T, n)
 		for i := range n {
-			data[i] = v.Data[0]
+			data[i] = v.At()
 		}
 
 		return New([]int{n}, data)
@@ -927,7 +1031,7 @@ func Repeat[T Number](v *Tensor[T], n, axis int) *Tensor[T] {
 	if ndim == 0 {
 		data := make([]T, n)
 		for i := range n {
-			data[i] = v.Data[0]
+			data[i] = v.At()
 		}
 
 		return New([]int{n}, data)
@@ -1096,8 +1200,8 @@ func Sum[T Number](v *Tensor[T], axes ...int) *Tensor[T] {
 
 // Max returns a new tensor with the maximum value among all elements in v.
 // If axes is specified, it reduces along the given axes.
-func Max(v *Tensor[float64], axes ...int) *Tensor[float64] {
-	return Reduce(v, -math.MaxFloat64, func(acc, x float64) float64 {
+func Max[T Float](v *Tensor[T], axes ...int) *Tensor[T] {
+	return Reduce(v, -maxValue[T](), func(acc, x T) T {
 		if x > acc {
 			return x
 		}
@@ -1108,8 +1212,8 @@ func Max(v *Tensor[float64], axes ...int) *Tensor[float64] {
 
 // Min returns a new tensor with the minimum value among all elements in v.
 // If axes is specified, it reduces along the given axes.
-func Min(v *Tensor[float64], axes ...int) *Tensor[float64] {
-	return Reduce(v, math.MaxFloat64, func(acc, x float64) float64 {
+func Min[T Float](v *Tensor[T], axes ...int) *Tensor[T] {
+	return Reduce(v, maxValue[T](), func(acc, x T) T {
 		if x < acc {
 			return x
 		}
@@ -1120,15 +1224,15 @@ func Min(v *Tensor[float64], axes ...int) *Tensor[float64] {
 
 // Mean returns a new tensor with the mean of elements in v.
 // If axes is specified, it reduces along the given axes.
-func Mean[T Number](v *Tensor[T], axes ...int) *Tensor[float64] {
+func Mean[T Float](v *Tensor[T], axes ...int) *Tensor[T] {
 	ndim := v.NumDims()
 	if ndim == 0 {
-		return Float64(Clone(v))
+		return Clone(v)
 	}
 
 	if len(axes) == 0 {
 		// mean all
-		return MulC(1/float64(v.Size()), Float64(Sum(v)))
+		return MulC(1/T(v.Size()), Sum(v))
 	}
 
 	ax, _, err := adjAxes(ndim, axes...)
@@ -1143,14 +1247,14 @@ func Mean[T Number](v *Tensor[T], axes ...int) *Tensor[float64] {
 	}
 
 	// mean
-	return MulC(1/float64(size), Float64(Sum(v, ax...)))
+	return MulC(1/T(size), Sum(v, ax...))
 }
 
 // Variance returns a new tensor with the variance of elements in v.
-func Variance(v *Tensor[float64], axes ...int) *Tensor[float64] {
+func Variance[T Float](v *Tensor[T], axes ...int) *Tensor[T] {
 	ndim := v.NumDims()
 	if ndim == 0 {
-		return Scalar(0.0)
+		return Scalar[T](0)
 	}
 
 	if len(axes) == 0 {
@@ -1168,12 +1272,15 @@ func Variance(v *Tensor[float64], axes ...int) *Tensor[float64] {
 }
 
 // StdDev returns a new tensor with the standard deviation of elements in v.
-func StdDev(v *Tensor[float64], axes ...int) *Tensor[float64] {
+func StdDev[T Float](v *Tensor[T], axes ...int) *Tensor[T] {
 	return Sqrt(Variance(v, axes...))
 }
 
 // MatMul returns the matrix product of v and w.
-func MatMul(v, w *Tensor[float64]) *Tensor[float64] {
+// The leading batch dimensions are distributed across goroutines,
+// and each 2-D product is computed by the platform matmul kernel,
+// which splits large products into row and column tiles.
+func MatMul[T Float](v, w *Tensor[T]) *Tensor[T] {
 	a, b := Broadcast(v, w, 2)
 	a, b = Contiguous(a), Contiguous(b)
 	ndim := a.NumDims()
@@ -1192,7 +1299,7 @@ func MatMul(v, w *Tensor[float64]) *Tensor[float64] {
 	// batch
 	batch := a.Shape[:ndim-2]
 	shape := append(batch, arows, bcols)
-	o := Zeros[float64](shape...)
+	o := Zeros[T](shape...)
 
 	// Determine the number of batch elements each goroutine will handle.
 	// We use ceiling division to ensure all batch elements are assigned.
@@ -1243,6 +1350,23 @@ func MatMul(v, w *Tensor[float64]) *Tensor[float64] {
 	return o
 }
 
+// MatMulTo writes the matrix product of 2-D v and w to dst, and returns dst.
+// dst must be contiguous with the shape of the product, and must not share the data of v and w.
+func MatMulTo[T Float](dst, v, w *Tensor[T]) *Tensor[T] {
+	if v.NumDims() != 2 || w.NumDims() != 2 || v.Shape[1] != w.Shape[0] {
+		panic(fmt.Sprintf("shapes %v and %v are not aligned for matmul", v.Shape, w.Shape))
+	}
+
+	m, k, n := v.Shape[0], v.Shape[1], w.Shape[1]
+	if dst.NumDims() != 2 || dst.Shape[0] != m || dst.Shape[1] != n || !IsContiguous(dst) {
+		panic(fmt.Sprintf("shape %v does not match contiguous %v", []int{m, n}, dst.Shape))
+	}
+
+	clear(dst.Data)
+	matmul(Contiguous(v).Data, Contiguous(w).Data, dst.Data, m, k, n)
+	return dst
+}
+
 // SliceEqual returns true if the two slices are equal.
 func SliceEqual(a, b []int) bool {
 	if len(a) != len(b) {
@@ -1278,7 +1402,7 @@ func EqualAll(v, w *Tensor[int]) bool {
 }
 
 // IsCloseAll returns true if the two tensors are close enough.
-func IsCloseAll(v, w *Tensor[float64], tol ...float64) bool {
+func IsCloseAll[T Float](v, w *Tensor[T], tol ...float64) bool {
 	if !SliceEqual(v.Shape, w.Shape) {
 		return false
 	}
@@ -1288,7 +1412,7 @@ func IsCloseAll(v, w *Tensor[float64], tol ...float64) bool {
 		a := v.Data[it.Offset(0)]
 		b := w.Data[it.Offset(1)]
 
-		if !isClose(a, b, tol...) {
+		if !isClose(float64(a), float64(b), tol...) {
 			return false
 		}
 	}
@@ -1298,6 +1422,10 @@ func IsCloseAll(v, w *Tensor[float64], tol ...float64) bool {
 
 // IsContiguous returns true if the tensor is stored in contiguous memory.
 func IsContiguous[T Number](v *Tensor[T]) bool {
+	if v.Offset != 0 {
+		return false
+	}
+
 	size := 1
 	for i := v.NumDims() - 1; i >= 0; i-- {
 		if v.Shape[i] == 1 {
@@ -1389,6 +1517,17 @@ func KeepDims(shape []int, axes []int) []int {
 	return out
 }
 
+// maxValue returns the largest finite value of T.
+func maxValue[T Float]() T {
+	var zero T
+	if unsafe.Sizeof(zero) == 4 {
+		return T(float32(math.MaxFloat32))
+	}
+
+	v := math.MaxFloat64
+	return T(v)
+}
+
 // rnd returns a pseudo-random number generator.
 func rnd(s ...randv2.Source) *randv2.Rand {
 	if len(s) == 0 || s[0] == nil {
@@ -1503,6 +1642,40 @@ func adjIndices(indices, shape []int, axis int) ([]int, error) {
 	return adj, nil
 }
 
+// adjIndex adjusts a negative index and checks the range.
+func adjIndex(idx int, shape []int, axis int) (int, error) {
+	if idx < 0 {
+		idx += shape[axis]
+	}
+
+	if idx < 0 || idx >= shape[axis] {
+		return -1, fmt.Errorf("index %d out of range for axis=%d (shape=%v)", idx, axis, shape)
+	}
+
+	return idx, nil
+}
+
+// adjGather adjusts a negative axis and checks that an index tensor with the given shape can be used along the axis.
+func adjGather(shape, index []int, axis int) (int, error) {
+	ndim := len(shape)
+	ax, err := adjAxis(axis, ndim)
+	if err != nil {
+		return -1, err
+	}
+
+	if len(index) != ndim {
+		return -1, fmt.Errorf("index ndim=%v is not equal to ndim=%v", len(index), ndim)
+	}
+
+	for i := range ndim {
+		if i != ax && index[i] > shape[i] {
+			return -1, fmt.Errorf("index shape %v is larger than shape %v at axis=%v", index, shape, i)
+		}
+	}
+
+	return ax, nil
+}
+
 // adjAxes adjusts negative axes, checks the range, and checks for duplicates.
 func adjAxes(ndim int, axes ...int) ([]int, map[int]bool, error) {
 	adj, seen := make([]int, len(axes)), make(map[int]bool, len(axes))
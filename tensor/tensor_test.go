@@ -315,6 +315,20 @@ func ExampleClone() {
 	// [10 2 3 4]
 }
 
+func ExampleCopy() {
+	v := tensor.New([]int{2, 3}, []int{
+		1, 2, 3,
+		4, 5, 6,
+	})
+
+	w := tensor.Zeros[int](3, 2)
+	tensor.Copy(w, tensor.Transpose(v))
+	fmt.Println(w.Data)
+
+	// Output:
+	// [1 4 2 5 3 6]
+}
+
 func ExampleInt() {
 	v := tensor.New([]int{2, 2}, []float64{
 		1.5, 2.5,
@@ -347,6 +361,20 @@ func ExampleFloat64() {
 	// []float64
 }
 
+func ExampleConvert() {
+	v := tensor.New([]int{2}, []float64{1.5, 2.5})
+
+	w := tensor.Convert[float32](v)
+	fmt.Printf("%T %v\n", w.Data, w.Data)
+
+	x := tensor.Convert[float64](w)
+	fmt.Printf("%T %v\n", x.Data, x.Data)
+
+	// Output:
+	// []float32 [1.5 2.5]
+	// []float64 [1.5 2.5]
+}
+
 func ExampleF() {
 	v := tensor.New([]int{2, 2}, []int{
 		1, 2,
@@ -425,6 +453,69 @@ func ExampleScatterAdd_axis1() {
 	// [39 31]
 }
 
+func ExampleGather() {
+	v := tensor.New([]int{2, 3}, []int{
+		10, 11, 12,
+		20, 21, 22,
+	})
+
+	// per-row label lookup
+	label := tensor.New([]int{2, 1}, []int{2, 0})
+	fmt.Println(tensor.Gather(v, 1, label).Data)
+
+	index := tensor.New([]int{2, 3}, []int{
+		1, 0, 1,
+		0, 0, -1,
+	})
+	for _, row := range tensor.Gather(v, 0, index).Seq2() {
+		fmt.Println(row)
+	}
+
+	// Output:
+	// [12 20]
+	// [20 11 22]
+	// [10 11 22]
+}
+
+func ExampleScatterAddIndex() {
+	v := tensor.Zeros[int](2, 3)
+	w := tensor.New([]int{2, 2}, []int{
+		1, 2,
+		3, 4,
+	})
+
+	index := tensor.New([]int{2, 2}, []int{
+		2, 2,
+		0, 1,
+	})
+	for _, row := range tensor.ScatterAddIndex(v, w, 1, index).Seq2() {
+		fmt.Println(row)
+	}
+
+	// Output:
+	// [0 0 3]
+	// [3 4 0]
+}
+
+func ExampleGather_invalid() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println(r)
+			return
+		}
+
+		panic("unexpected panic for index")
+	}()
+
+	v := tensor.Zeros[int](2, 3)
+	index := tensor.New([]int{2, 1}, []int{0, 3})
+	_ = tensor.Gather(v, 1, index)
+	panic("unreachable")
+
+	// Output:
+	// index 3 out of range for axis=1 (shape=[2 3])
+}
+
 func ExampleTensor_Seq2() {
 	v := tensor.New([]int{3, 2}, []int{
 		10, 11,
@@ -737,7 +828,7 @@ func ExampleMin() {
 }
 
 func ExampleMean() {
-	v := tensor.New([]int{2, 2}, []int{
+	v := tensor.New([]int{2, 2}, []float64{
 		1, 2,
 		3, 4,
 	})
@@ -749,6 +840,19 @@ func ExampleMean() {
 	// 2.5
 }
 
+func ExampleMean_float32() {
+	v := tensor.New([]int{2, 2}, []float32{
+		1, 2,
+		3, 4,
+	})
+
+	w := tensor.Mean(v, 0)
+	fmt.Printf("%T %v\n", w.Data, w.Data)
+
+	// Output:
+	// []float32 [2 3]
+}
+
 func ExampleArgmax() {
 	v := tensor.New([]int{2, 4}, []int{
 		1, 2, 3, 4,
@@ -934,6 +1038,29 @@ func ExampleMatMul() {
 	// [139 154]
 }
 
+func ExampleMatMul_float32() {
+	x := tensor.New([]int{2, 3}, []float32{
+		1, 2, 3,
+		4, 5, 6,
+	})
+	y := tensor.New([]int{3, 2}, []float32{
+		7, 8,
+		9, 10,
+		11, 12,
+	})
+
+	z := tensor.MatMul(x, y)
+	fmt.Printf("%T\n", z.Data)
+	for _, row := range z.Seq2() {
+		fmt.Println(row)
+	}
+
+	// Output:
+	// []float32
+	// [58 64]
+	// [139 154]
+}
+
 func ExampleMatMul_view() {
 	x := tensor.New([]int{1, 3}, []float64{
 		1, 2, 3,
@@ -955,6 +1082,24 @@ func ExampleMatMul_view() {
 	// [58 64]
 }
 
+func ExampleMatMulTo() {
+	x := tensor.New([]int{1, 3}, []float64{
+		1, 2, 3,
+	})
+	y := tensor.New([]int{3, 2}, []float64{
+		7, 8,
+		9, 10,
+		11, 12,
+	})
+
+	z := tensor.Full([]int{1, 2}, 100.0)
+	tensor.MatMulTo(z, x, y)
+	fmt.Println(z.Data)
+
+	// Output:
+	// [58 64]
+}
+
 func ExampleBroadcastTo() {
 	v := tensor.New([]int{1, 2, 2}, []float64{
 		1, 2,
@@ -1311,6 +1456,50 @@ func ExampleMatMul_invalid() {
 	// shapes [2 2 2] and [2 3 2] are not aligned for matmul
 }
 
+func ExampleExp_float32() {
+	v := tensor.New([]int{3}, []float32{0, 1, 2})
+	w := tensor.Exp(v)
+
+	fmt.Printf("%T\n", w.Data)
+	fmt.Println(w.Data)
+
+	// Output:
+	// []float32
+	// [1 2.7182817 7.389056]
+}
+
+func ExampleMax_float32() {
+	v := tensor.New([]int{2, 3}, []float32{
+		-1, -2, -3,
+		-4, -5, -6,
+	})
+
+	fmt.Println(tensor.Max(v).Data)
+	fmt.Println(tensor.Max(v, 0).Data)
+	fmt.Println(tensor.Min(v, 1).Data)
+
+	// Output:
+	// [-1]
+	// [-1 -2 -3]
+	// [-3 -6]
+}
+
+func ExampleVariance_float32() {
+	v := tensor.New([]int{2, 3}, []float32{
+		1, 2, 3,
+		4, 5, 6,
+	})
+
+	fmt.Printf("%.4f\n", tensor.Variance(v).Data)
+	fmt.Printf("%.4f\n", tensor.Variance(v, 1).Data)
+	fmt.Printf("%.4f\n", tensor.StdDev(v, 0).Data)
+
+	// Output:
+	// [2.9167]
+	// [0.6667 0.6667]
+	// [1.5000 1.5000 1.5000]
+}
+
 func TestArange(t *testing.T) {
 	cases := []struct {
 		start, stop, step int
@@ -4291,6 +4480,32 @@ func TestTranspose_invalid(t *testing.T) {
 	}
 }
 
+func TestMatMulTo_invalid(t *testing.T) {
+	cases := []struct {
+		dst, v, w *tensor.Tensor[float64]
+	}{
+		{dst: tensor.Zeros[float64](2, 2), v: tensor.Zeros[float64](2, 3), w: tensor.Zeros[float64](2, 2)},
+		{dst: tensor.Zeros[float64](2, 2), v: tensor.Zeros[float64](1, 2, 3), w: tensor.Zeros[float64](3, 2)},
+		{dst: tensor.Zeros[float64](2, 3), v: tensor.Zeros[float64](2, 3), w: tensor.Zeros[float64](3, 2)},
+		{dst: tensor.Transpose(tensor.Zeros[float64](2, 2)), v: tensor.Zeros[float64](2, 3), w: tensor.Zeros[float64](3, 2)},
+	}
+
+	for _, c := range cases {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					return
+				}
+
+				t.Errorf("unexpected panic for shapes %v, %v and %v", c.dst.Shape, c.v.Shape, c.w.Shape)
+			}()
+
+			_ = tensor.MatMulTo(c.dst, c.v, c.w)
+			t.Fail()
+		}()
+	}
+}
+
 func TestFlip_invalid(t *testing.T) {
 	cases := []struct {
 		v    *tensor.Tensor[int]
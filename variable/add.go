@@ -3,44 +3,57 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // AddC returns a variable representing c + x[0].
-func AddC(c float64, x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &AddT{},
-	}).First(New(c), x[0])
+// c is a constant that does not require gradients.
+func AddC[T tensor.Float](c T, x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &AddT[T]{},
+	}).First(constant(c), x[0])
 }
 
 // Add returns a variable representing x[0] + x[1].
-func Add(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &AddT{},
+func Add[T tensor.Float](x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &AddT[T]{},
 	}).First(x...)
 }
 
 // AddT is the differentiable addition operation.
-type AddT struct {
+type AddT[T tensor.Float] struct {
 	x0Shape []int
 	x1Shape []int
 }
 
-func (f *AddT) Forward(x ...*Variable) []*Variable {
+func (f *AddT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.x0Shape, f.x1Shape = x[0].Shape(), x[1].Shape()
 
 	y := tensor.Add(x[0].Data, x[1].Data)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *AddT) Backward(gy ...*Variable) []*Variable {
+func (f *AddT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
 	if tensor.SliceEqual(f.x0Shape, f.x1Shape) {
-		return []*Variable{
+		return []*Variable[T]{
 			gy[0],
 			gy[0],
 		}
 	}
 
-	return []*Variable{
-		SumTo(f.x0Shape...)(gy[0]),
-		SumTo(f.x1Shape...)(gy[0]),
+	return []*Variable[T]{
+		SumTo[T](f.x0Shape...)(gy[0]),
+		SumTo[T](f.x1Shape...)(gy[0]),
+	}
+}
+
+func (f *AddT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Add(tx[0], tx[1]),
+	}
+}
+
+func (f *AddT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Add(align(x, batched)...),
 	}
 }
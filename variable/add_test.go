@@ -9,7 +9,7 @@ import (
 func ExampleAddT() {
 	a := variable.New(2, 3)
 	b := variable.New(3, 4)
-	f := variable.AddT{}
+	f := variable.AddT[float64]{}
 
 	fmt.Println(a)
 	fmt.Println(b)
@@ -0,0 +1,114 @@
+package variable
+
+import (
+	"fmt"
+	"math"
+	"runtime/debug"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// AnomalyError describes a function that returned NaN or Inf while anomaly detection is enabled.
+type AnomalyError struct {
+	Func       string  // the type of the Forwarder
+	Backward   bool    // whether the gradients of Backward, or the outputs of Forward, contain NaN or Inf
+	Index      int     // the index of the output or gradient
+	Shapes     [][]int // the shapes of the inputs
+	Generation int     // the generation of the function
+	Stack      []byte  // the stack when the function was applied, or nil if anomaly detection was disabled then
+}
+
+func (e *AnomalyError) Error() string {
+	phase, value := "forward", "output"
+	if e.Backward {
+		phase, value = "backward", "gradient"
+	}
+
+	stack := "<not captured>"
+	if e.Stack != nil {
+		stack = string(e.Stack)
+	}
+
+	return fmt.Sprintf("%s of %s returned NaN or Inf in %s %d: input shapes=%v, generation=%d\ncreated at:\n%s",
+		phase, e.Func, value, e.Index, e.Shapes, e.Generation, stack)
+}
+
+// checkForward captures the stack, and panics with an *AnomalyError if an output y of the function contains NaN or Inf.
+func (f *Function[T]) checkForward(x, y []*Variable[T]) {
+	f.stack = debug.Stack()
+	if i := anomaly(y); i >= 0 {
+		panic(f.anomaly(false, i, x, maxgen(x...)))
+	}
+}
+
+// backward returns the gradients from the backward of the function.
+// It panics with an *AnomalyError if a gradient contains NaN or Inf.
+// While Backward is applied, gy is bound to the session without anomaly detection,
+// so the outputs of the functions applied to gy are not checked, and the gradients are checked instead.
+func (f *Function[T]) backward(gy ...*Variable[T]) []*Variable[T] {
+	sessions := make([]*Session, len(gy))
+	for i, v := range gy {
+		if v != nil {
+			sessions[i], v.session = v.session, quiet(v)
+		}
+	}
+
+	gx := f.Backward(gy...)
+	for i, v := range gy {
+		if v != nil {
+			v.session = sessions[i]
+		}
+	}
+
+	if i := anomaly(gx); i >= 0 {
+		panic(f.anomaly(true, i, f.Input, f.Generation))
+	}
+
+	return gx
+}
+
+// quiet returns the session of v without anomaly detection.
+func quiet[T tensor.Float](v *Variable[T]) *Session {
+	if !v.session.detectAnomaly() {
+		return v.session
+	}
+
+	s := SessionOf(v)
+	s.DetectAnomaly = false
+	return &s
+}
+
+func (f *Function[T]) anomaly(backward bool, index int, x []*Variable[T], generation int) *AnomalyError {
+	shapes := make([][]int, len(x))
+	for i, v := range x {
+		if v != nil {
+			shapes[i] = v.Shape()
+		}
+	}
+
+	return &AnomalyError{
+		Func:       fmt.Sprintf("%T", f.Forwarder),
+		Backward:   backward,
+		Index:      index,
+		Shapes:     shapes,
+		Generation: generation,
+		Stack:      f.stack,
+	}
+}
+
+// anomaly returns the index of the first variable that contains NaN or Inf, or -1 if there is none.
+func anomaly[T tensor.Float](x []*Variable[T]) int {
+	for i, v := range x {
+		if v == nil {
+			continue
+		}
+
+		for _, a := range tensor.Contiguous(v.Data).Data {
+			if math.IsNaN(float64(a)) || math.IsInf(float64(a), 0) {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
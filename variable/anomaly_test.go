@@ -0,0 +1,208 @@
+package variable_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleDetectAnomaly() {
+	defer variable.DetectAnomaly().End()
+	defer func() {
+		var err *variable.AnomalyError
+		if errors.As(recover().(error), &err) {
+			fmt.Println(err.Func, err.Backward, err.Index, err.Shapes, err.Generation)
+		}
+	}()
+
+	x := variable.New(1, -1)
+	y := variable.Exp(x)
+	variable.Log(variable.Neg(y))
+
+	// Output:
+	// *variable.LogT[float64] false 0 [[2]] 2
+}
+
+func ExampleDetectAnomaly_backward() {
+	defer variable.DetectAnomaly().End()
+	defer func() {
+		var err *variable.AnomalyError
+		if errors.As(recover().(error), &err) {
+			fmt.Println(err.Func, err.Backward, err.Index, err.Shapes, err.Generation)
+		}
+	}()
+
+	// the gradient 1/x of log(x) overflows for the smallest subnormal x
+	x := variable.New(5e-324, 1)
+	y := variable.Log(x)
+	y.Backward()
+
+	// Output:
+	// *variable.LogT[float64] true 0 [[2]] 0
+}
+
+func TestDetectAnomaly_view(t *testing.T) {
+	defer variable.DetectAnomaly().End()
+
+	// the view of Slice shares the data of x, and does not select NaN nor Inf
+	x := variable.New(1, math.NaN(), 3, math.Inf(1))
+	y := variable.Slice[float64](tensor.R(0, 4, 2))(x)
+	y.Backward()
+
+	if got := y.Data.Size(); got != 2 {
+		t.Errorf("got=%v, want=2", got)
+	}
+}
+
+func TestDetectAnomaly(t *testing.T) {
+	defer variable.DetectAnomaly().End()
+	defer func() {
+		err, ok := recover().(*variable.AnomalyError)
+		if !ok {
+			t.Fatalf("unexpected panic: %v", err)
+		}
+
+		// the stack when Div was applied
+		if !strings.Contains(string(err.Stack), "TestDetectAnomaly") {
+			t.Errorf("stack=%s", err.Stack)
+		}
+
+		if !strings.HasPrefix(err.Error(), "forward of *variable.DivT[float64] returned NaN or Inf in output 0: input shapes=[[1] [1]], generation=0\ncreated at:\n") {
+			t.Errorf("error=%v", err)
+		}
+	}()
+
+	variable.Div(variable.New(1), variable.New(0))
+	t.Fail()
+}
+
+func TestDetectAnomaly_stack(t *testing.T) {
+	// the anomaly detection is enabled only while backpropagating
+	x := variable.New(0)
+	y := variable.Log(x)
+
+	defer variable.DetectAnomaly().End()
+	defer func() {
+		err, ok := recover().(*variable.AnomalyError)
+		if !ok {
+			t.Fatalf("unexpected panic: %v", err)
+		}
+
+		if err.Stack != nil {
+			t.Errorf("stack=%s", err.Stack)
+		}
+
+		if !strings.HasSuffix(err.Error(), "created at:\n<not captured>") {
+			t.Errorf("error=%v", err)
+		}
+	}()
+
+	y.Backward()
+	t.Fail()
+}
+
+func TestDetectAnomaly_saved(t *testing.T) {
+	defer variable.DetectAnomaly().End()
+	defer func() {
+		err, ok := recover().(*variable.AnomalyError)
+		if !ok {
+			t.Fatalf("unexpected panic: %v", err)
+		}
+
+		// x^(-0.5) in the backward of Pow is applied to the saved input, and checked like in the forward
+		if err.Func != "*variable.PowT[float64]" || err.Backward {
+			t.Errorf("func=%v, backward=%v", err.Func, err.Backward)
+		}
+
+		if !strings.Contains(string(err.Stack), "(*PowT[...]).Backward") {
+			t.Errorf("stack=%s", err.Stack)
+		}
+	}()
+
+	x := variable.New(0, 1)
+	y := variable.Pow(0.5)(x)
+	y.Backward()
+	t.Fail()
+}
+
+func TestSession_detectAnomaly(t *testing.T) {
+	s := &variable.Session{EnableBackprop: true, Train: true, DetectAnomaly: true}
+	defer func() {
+		err, ok := recover().(*variable.AnomalyError)
+		if !ok {
+			t.Fatalf("unexpected panic: %v", err)
+		}
+
+		if !err.Backward || err.Func != "*variable.LogT[float64]" {
+			t.Errorf("func=%v, backward=%v", err.Func, err.Backward)
+		}
+	}()
+
+	// the anomalies of the other computations are not detected
+	variable.Div(variable.New(1), variable.New(0))
+	if variable.Config.DetectAnomaly {
+		t.Errorf("anomaly detection is enabled")
+	}
+
+	x := variable.New(5e-324).Bind(s)
+	y := variable.Log(x)
+	y.Backward()
+	t.Fail()
+}
+
+// blockT is a function whose Backward blocks until done is closed.
+type blockT struct {
+	backward, done chan struct{}
+}
+
+func (f *blockT) Forward(x ...*variable.Variable[float64]) []*variable.Variable[float64] {
+	return []*variable.Variable[float64]{variable.Neg(x[0])}
+}
+
+func (f *blockT) Backward(gy ...*variable.Variable[float64]) []*variable.Variable[float64] {
+	close(f.backward)
+	<-f.done
+	return []*variable.Variable[float64]{variable.Neg(gy[0])}
+}
+
+func TestDetectAnomaly_goroutine(t *testing.T) {
+	defer variable.DetectAnomaly().End()
+
+	f := &blockT{backward: make(chan struct{}), done: make(chan struct{})}
+	y := (&variable.Function[float64]{Forwarder: f}).First(variable.New(1))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		y.Backward()
+	}()
+	defer wg.Wait()
+	defer close(f.done)
+
+	// the forward in another goroutine is checked while Backward is called
+	<-f.backward
+	defer func() {
+		if _, ok := recover().(*variable.AnomalyError); !ok {
+			t.Errorf("anomaly is not detected")
+		}
+	}()
+
+	variable.Div(variable.New(1), variable.New(0))
+}
+
+func TestDetectAnomaly_disabled(t *testing.T) {
+	x := variable.New(0)
+	y := variable.Log(x)
+	y.Backward()
+
+	if variable.Config.DetectAnomaly {
+		t.Errorf("anomaly detection is enabled")
+	}
+}
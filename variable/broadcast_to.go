@@ -3,31 +3,44 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // BroadcastTo returns a function that broadcasts x[0] to the given shape.
-func BroadcastTo(shape ...int) func(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &BroadcastToT{
+func BroadcastTo[T tensor.Float](shape ...int) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &BroadcastToT[T]{
 			Shape: shape,
 		},
 	}).First
 }
 
 // BroadcastToT is the differentiable BroadcastTo operation.
-type BroadcastToT struct {
+type BroadcastToT[T tensor.Float] struct {
 	Shape  []int
 	xShape []int
 }
 
-func (f *BroadcastToT) Forward(x ...*Variable) []*Variable {
+func (f *BroadcastToT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.xShape = x[0].Shape()
 
 	y := tensor.BroadcastTo(x[0].Data, f.Shape...)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *BroadcastToT) Backward(gy ...*Variable) []*Variable {
-	return []*Variable{
-		SumTo(f.xShape...)(gy[0]),
+func (f *BroadcastToT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		SumTo[T](f.xShape...)(gy[0]),
+	}
+}
+
+func (f *BroadcastToT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		BroadcastTo[T](f.Shape...)(tx[0]),
+	}
+}
+
+func (f *BroadcastToT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	v := align([]*Variable[T]{x[0], Zeros[T](f.Shape...)}, []bool{true, false})[0]
+	return []*Variable[T]{
+		BroadcastTo[T](append([]int{v.Size(0)}, f.Shape...)...)(v),
 	}
 }
@@ -8,7 +8,7 @@ import (
 
 func ExampleBroadcastTo() {
 	x := variable.New(2)
-	y := variable.BroadcastTo(1, 3)(x)
+	y := variable.BroadcastTo[float64](1, 3)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -22,7 +22,7 @@ func ExampleBroadcastTo() {
 func ExampleBroadcastTo_double() {
 	x := variable.New(2)
 
-	y := variable.BroadcastTo(1, 3)(x)
+	y := variable.BroadcastTo[float64](1, 3)(x)
 	y.Backward(variable.Opts{CreateGraph: true})
 	fmt.Println(y)
 	fmt.Println(x.Grad)
@@ -0,0 +1,129 @@
+package variable
+
+import "github.com/itsubaki/autograd/tensor"
+
+// Checkpoint returns f(x...) without keeping the graph of f in memory.
+// f is applied once to find the variables it uses other than x, such as parameters, and the graph is dropped.
+// It is applied again during Backward to compute the gradients, which trades compute for memory,
+// for example in long unrolls of recurrent networks.
+// Both applications create the whole graph of f, so the memory of the intermediate variables of one segment
+// is needed while it is applied, and is released after the output or the gradients are returned.
+// f must be deterministic given x, and the variables it uses other than x must not have a creator.
+// They are inputs of the checkpoint as well, so their gradients are backpropagated like those of x.
+// The gradients of x are not differentiable with respect to x.
+func Checkpoint[T tensor.Float](f func(x ...*Variable[T]) *Variable[T], x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &CheckpointT[T]{
+			F: f,
+		},
+	}).First(x...)
+}
+
+// CheckpointT is the differentiable operation that recomputes f during Backward.
+type CheckpointT[T tensor.Float] struct {
+	F       func(x ...*Variable[T]) *Variable[T]
+	x       []*Variable[T]
+	params  []*Variable[T]
+	session Session
+}
+
+// capturer is the interface implemented by differentiable operations whose Forward uses variables other than its inputs.
+type capturer[T tensor.Float] interface {
+	captured() []*Variable[T]
+}
+
+func (f *CheckpointT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	s := SessionOf(x...)
+	f.x, f.session = x, s
+
+	// apply f, and drop the graph, which holds the intermediate variables of the segment, after finding the variables it uses
+	xs := bind(&s, x)
+	y := f.F(xs...)
+	f.params = leaves(y, xs)
+	return []*Variable[T]{
+		From(y.Data),
+	}
+}
+
+func (f *CheckpointT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	// apply f again to new variables with the same data as x, and backprop the segment to them and the parameters
+	s := f.session
+	s.EnableBackprop, s.Recompute = true, true
+	xs := bind(&s, f.x)
+	y := f.F(xs...)
+
+	gxs := VJP(y, gy[0], append(xs, f.params...)...)
+	if !SessionOf(gy[0]).EnableBackprop {
+		for i := range gxs {
+			gxs[i] = gxs[i].Bind(backprop)
+		}
+	}
+
+	return gxs
+}
+
+func (f *CheckpointT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	xs := make([]*Variable[T], len(f.x))
+	for i := range xs {
+		xs[i] = Dual(x[i], tx[i])
+	}
+
+	return []*Variable[T]{
+		f.F(xs...).Tangent(),
+	}
+}
+
+func (f *CheckpointT[T]) captured() []*Variable[T] {
+	return f.params
+}
+
+// bind returns the variables with the data of x bound to the session.
+func bind[T tensor.Float](s *Session, x []*Variable[T]) []*Variable[T] {
+	out := make([]*Variable[T], len(x))
+	for i := range x {
+		out[i] = x[i].Bind(s)
+	}
+
+	return out
+}
+
+// leaves returns the variables without a creator in the graph of y that require gradients, other than x.
+func leaves[T tensor.Float](y *Variable[T], x []*Variable[T]) []*Variable[T] {
+	if y.Creator == nil {
+		return nil
+	}
+
+	seen := make(map[*Variable[T]]bool)
+	for _, v := range x {
+		seen[v] = true
+	}
+
+	var out []*Variable[T]
+	visited := map[*Function[T]]bool{y.Creator: true}
+	fs := []*Function[T]{y.Creator}
+	for len(fs) > 0 {
+		// pop
+		f := fs[len(fs)-1]
+		fs = fs[:len(fs)-1]
+
+		for _, v := range f.Input {
+			if v == nil || seen[v] || v.nograd {
+				continue
+			}
+
+			if v.Creator != nil {
+				if !visited[v.Creator] {
+					visited[v.Creator] = true
+					fs = append(fs, v.Creator)
+				}
+
+				continue
+			}
+
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,150 @@
+package variable_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleCheckpoint() {
+	w := variable.New(2)
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return variable.Mul(variable.Sin(x[0]), w)
+	}
+
+	x := variable.New(1.0)
+	y := variable.Checkpoint(f, x)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(y.Creator)
+	fmt.Println(x.Grad, w.Grad)
+
+	// Output:
+	// variable(1.682941969615793)
+	// *variable.CheckpointT[float64][variable(1) variable(2)]
+	// variable(1.0806046117362795) variable(0.8414709848078965)
+}
+
+func ExampleCheckpoint_dual() {
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return variable.Mul(x[0], x[0])
+	}
+
+	x := variable.Dual(variable.New(3), variable.New(1))
+	y := variable.Checkpoint(f, x)
+	fmt.Println(y, y.Tangent())
+
+	// Output:
+	// variable(9) variable(6)
+}
+
+func TestCheckpoint(t *testing.T) {
+	s := rand.NewPCG(1, 2)
+	w, u, b := variable.Randn([]int{3, 3}, s), variable.Randn([]int{2, 3}, s), variable.Randn([]int{1, 3}, s)
+
+	// step is a step of a recurrent network
+	step := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return variable.Tanh(variable.Add(variable.Add(variable.MatMul(x[0], w), variable.MatMul(x[1], u)), b))
+	}
+
+	xs := make([]*variable.Variable[float64], 20)
+	for i := range xs {
+		xs[i] = variable.Randn([]int{1, 2}, s)
+	}
+
+	unroll := func(checkpoint bool) (*variable.Variable[float64], []*tensor.Tensor[float64], int) {
+		for _, p := range []*variable.Variable[float64]{w, u, b} {
+			p.Cleargrad()
+		}
+
+		h0 := variable.Zeros[float64](1, 3)
+		h := h0
+		for _, x := range xs {
+			if checkpoint {
+				h = variable.Checkpoint(step, h, x)
+				continue
+			}
+
+			h = step(h, x)
+		}
+
+		loss := variable.Sum[float64]()(h)
+		loss.Backward()
+		return loss, []*tensor.Tensor[float64]{h0.Grad.Data, w.Grad.Data, u.Grad.Data, b.Grad.Data}, count(loss)
+	}
+
+	want, wgrads, wn := unroll(false)
+	got, grads, n := unroll(true)
+	if !tensor.IsCloseAll(got.Data, want.Data, 1e-10, 1e-10) {
+		t.Errorf("got=%v, want=%v", got.Data.Data, want.Data.Data)
+	}
+
+	for i := range grads {
+		if !tensor.IsCloseAll(grads[i], wgrads[i], 1e-10, 1e-10) {
+			t.Errorf("grads[%d]=%v, want=%v", i, grads[i].Data, wgrads[i].Data)
+		}
+	}
+
+	// one function for each step and the sum
+	if n != len(xs)+1 {
+		t.Errorf("functions=%d, want=%d", n, len(xs)+1)
+	}
+
+	if n >= wn {
+		t.Errorf("functions=%d, without checkpoint=%d", n, wn)
+	}
+}
+
+func TestCheckpoint_unused(t *testing.T) {
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return variable.Exp(x[0])
+	}
+
+	x0, x1 := variable.New(0), variable.New(1, 2)
+	y := variable.Checkpoint(f, x0, x1)
+	y.Backward()
+
+	if !tensor.IsCloseAll(x0.Grad.Data, tensor.New([]int{1}, []float64{1}), 1e-10, 1e-10) {
+		t.Errorf("x0.Grad=%v", x0.Grad)
+	}
+
+	if !tensor.IsCloseAll(x1.Grad.Data, tensor.Zeros[float64](2), 1e-10, 1e-10) {
+		t.Errorf("x1.Grad=%v", x1.Grad)
+	}
+}
+
+func TestCheckpoint_nograd(t *testing.T) {
+	f := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		return variable.Sin(x[0])
+	}
+
+	s := &variable.Session{EnableBackprop: false, Train: true}
+	y := variable.Checkpoint(f, variable.New(1).Bind(s))
+	if y.Creator != nil {
+		t.Errorf("creator=%v", y.Creator)
+	}
+}
+
+// count returns the number of functions in the graph of y.
+func count(y *variable.Variable[float64]) int {
+	seen := make(map[*variable.Function[float64]]bool)
+	fs := []*variable.Function[float64]{y.Creator}
+	for len(fs) > 0 {
+		f := fs[len(fs)-1]
+		fs = fs[:len(fs)-1]
+		if f == nil || seen[f] {
+			continue
+		}
+
+		seen[f] = true
+		for _, x := range f.Input {
+			fs = append(fs, x.Creator)
+		}
+	}
+
+	return len(seen)
+}
@@ -0,0 +1,60 @@
+package variable
+
+import (
+	"github.com/itsubaki/autograd/linalg"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// Cholesky returns a variable representing the lower triangular matrix l such that x[0] = l * l^T.
+// The gradient is symmetric, as x[0] is assumed to be symmetric.
+func Cholesky[T tensor.Float](x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &CholeskyT[T]{},
+	}).First(x...)
+}
+
+// CholeskyT is the differentiable Cholesky decomposition.
+type CholeskyT[T tensor.Float] struct {
+	y *Variable[T]
+}
+
+func (f *CholeskyT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	f.y = From(linalg.Cholesky(x[0].Data))
+	return []*Variable[T]{
+		f.y,
+	}
+}
+
+func (f *CholeskyT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	// phi = tril(l^T * gl) with the diagonal halved
+	// s = l^-T * phi * l^-1
+	// gx = (s + s^T) / 2
+	l, linv := f.y, Inv(f.y)
+	ltgl := MatMul(mT(l), tril(gy[0], 0))
+	phi := Sub(tril(ltgl, 0), MulC(0.5, Mul(ltgl, eye[T](l.Size(-1)))))
+	s := MatMul(MatMul(mT(linv), phi), linv)
+
+	return []*Variable[T]{
+		MulC(0.5, Add(s, mT(s))),
+	}
+}
+
+func (f *CholeskyT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	// s = l^-1 * (tx + tx^T) / 2 * l^-T
+	// tl = l * tril(s) with the diagonal halved
+	l, linv := y[0], Inv(y[0])
+	sym := MulC(0.5, Add(tx[0], mT(tx[0])))
+	s := MatMul(MatMul(linv, sym), mT(linv))
+	phi := Sub(tril(s, 0), MulC(0.5, Mul(s, eye[T](l.Size(-1)))))
+
+	return []*Variable[T]{
+		MatMul(l, phi),
+	}
+}
+
+func (f *CholeskyT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Cholesky(x[0]),
+	}
+}
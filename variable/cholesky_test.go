@@ -0,0 +1,39 @@
+package variable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleCholesky() {
+	x := variable.New(
+		4, 2,
+		2, 5,
+	).Reshape(2, 2)
+
+	y := variable.Cholesky(x)
+	y.Backward()
+
+	fmt.Printf("%.4f\n", y.Data.Data)
+	fmt.Printf("%.4f\n", x.Grad.Data.Data)
+
+	// Output:
+	// [2.0000 0.0000 1.0000 2.0000]
+	// [0.1875 0.1250 0.1250 0.2500]
+}
+
+func TestCholesky(t *testing.T) {
+	cases := []struct {
+		x *variable.Variable[float64]
+	}{
+		{spd(1, 1)},
+		{spd(3, 3)},
+		{spd(2, 4, 4)},
+	}
+
+	for _, c := range cases {
+		gradcheck(t, sym(first(variable.Cholesky)), c.x)
+	}
+}
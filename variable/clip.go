@@ -3,9 +3,9 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // Clip returns a function that clips x[0] to the interval [min, max].
-func Clip(min, max float64) func(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &ClipT{
+func Clip[T tensor.Float](min, max T) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &ClipT[T]{
 			Min: min,
 			Max: max,
 		},
@@ -13,30 +13,68 @@ func Clip(min, max float64) func(x ...*Variable) *Variable {
 }
 
 // ClipT is the differentiable clipping operation.
-type ClipT struct {
-	Min, Max float64
-	x        *Variable
+type ClipT[T tensor.Float] struct {
+	Min, Max T
+	x        *Variable[T]
 }
 
-func (f *ClipT) Forward(x ...*Variable) []*Variable {
+func (f *ClipT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.x = x[0]
 
 	y := tensor.Clip(x[0].Data, f.Min, f.Max)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *ClipT) Backward(gy ...*Variable) []*Variable {
+func (f *ClipT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
 	mask := tensor.Mask(f.x.Data, clip(f.Min, f.Max))
-	return []*Variable{
+	return []*Variable[T]{
 		Mul(gy[0], From(mask)), // gy * mask
 	}
 }
 
+func (f *ClipT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	mask := tensor.Mask(x[0].Data, clip(f.Min, f.Max))
+	return []*Variable[T]{
+		Mul(tx[0], From(mask)), // tx * mask
+	}
+}
+
+func (f *ClipT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Clip[T](f.Min, f.Max)(x[0]),
+	}
+}
+
+func (f *ClipT[T]) Scalar() (func(x T) T, func(x T) T) {
+	in := clip(f.Min, f.Max)
+	y := func(x T) T {
+		if x < f.Min {
+			return f.Min
+		}
+
+		if x > f.Max {
+			return f.Max
+		}
+
+		return x
+	}
+
+	dy := func(x T) T {
+		if in(x) {
+			return 1
+		}
+
+		return 0
+	}
+
+	return y, dy
+}
+
 // clip returns a function that checks if a value v is within the interval [min, max].
-func clip(min, max float64) func(v float64) bool {
-	return func(v float64) bool {
+func clip[T tensor.Float](min, max T) func(v T) bool {
+	return func(v T) bool {
 		return min < v && v < max
 	}
 }
@@ -8,7 +8,7 @@ import (
 
 func ExampleClip() {
 	x := variable.New(-2, -1, 0, 1, 2, 4, 6, 8)
-	y := variable.Clip(0, 5)(x)
+	y := variable.Clip(0.0, 5.0)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -22,7 +22,7 @@ func ExampleClip() {
 func ExampleClip_double() {
 	x := variable.New(-2, -1, 0, 1, 2, 4, 6, 8)
 
-	y := variable.Clip(0, 5)(x)
+	y := variable.Clip(0.0, 5.0)(x)
 	y.Backward(variable.Opts{CreateGraph: true})
 	fmt.Println(y)
 	fmt.Println(x.Grad)
@@ -3,33 +3,51 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // Concat returns a function that concatenates variables along the given axis.
-func Concat(axis int) func(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &ConcatT{
+func Concat[T tensor.Float](axis int) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &ConcatT[T]{
 			Axis: axis,
 		},
 	}).First
 }
 
 // ConcatT is the differentiable concatenation operation.
-type ConcatT struct {
+type ConcatT[T tensor.Float] struct {
 	Axis int
 	size []int
 }
 
-func (f *ConcatT) Forward(x ...*Variable) []*Variable {
-	list := make([]*tensor.Tensor[float64], 0)
+func (f *ConcatT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	list := make([]*tensor.Tensor[T], 0)
+	f.size = make([]int, 0, len(x))
 	for _, v := range x {
 		list = append(list, v.Data)
 		f.size = append(f.size, v.Shape()[f.Axis])
 	}
 
 	y := tensor.Concat(list, f.Axis)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *ConcatT) Backward(gy ...*Variable) []*Variable {
-	return Split(f.size, f.Axis)(gy[0])
+func (f *ConcatT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return Split[T](f.size, f.Axis)(gy[0])
+}
+
+func (f *ConcatT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Concat[T](f.Axis)(tx...),
+	}
+}
+
+func (f *ConcatT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	axis := shiftAxis(f.Axis, x[0].NumDims())
+	if batched[0] {
+		axis = shiftAxis(f.Axis, rank(x[0], true))
+	}
+
+	return []*Variable[T]{
+		Concat[T](axis)(expand(batchSize(x, batched), x, batched)...),
+	}
 }
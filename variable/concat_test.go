@@ -18,7 +18,7 @@ func ExampleConcat() {
 	).Reshape(2, 2)
 
 	axis := 1
-	z := variable.Concat(axis)(x, y)
+	z := variable.Concat[float64](axis)(x, y)
 	z.Backward()
 
 	fmt.Println(z)
@@ -42,7 +42,7 @@ func ExampleConcat_axis0() {
 	).Reshape(1, 3)
 
 	axis := 0
-	z := variable.Concat(axis)(x, y)
+	z := variable.Concat[float64](axis)(x, y)
 	z.Backward()
 
 	fmt.Println(z)
@@ -67,7 +67,7 @@ func ExampleConcat_double() {
 	).Reshape(2, 2)
 
 	axis := 1
-	z := variable.Concat(axis)(x, y)
+	z := variable.Concat[float64](axis)(x, y)
 	z.Backward(variable.Opts{CreateGraph: true})
 	fmt.Println(z)
 	fmt.Println(x.Grad)
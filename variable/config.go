@@ -1,10 +1,13 @@
 package variable
 
+// config holds the process-wide backprop, training and anomaly detection flags.
 type config struct {
 	EnableBackprop bool
 	Train          bool
+	DetectAnomaly  bool
 }
 
+// Config is the process-wide configuration used by variables that are not bound to a Session.
 var Config = config{
 	EnableBackprop: true,
 	Train:          true,
@@ -16,6 +19,7 @@ type Span struct {
 }
 
 // Nograd disables backpropagation until End is called.
+// It changes Config for the whole process. Use a Session to disable backpropagation for one goroutine.
 func Nograd() *Span {
 	Config.EnableBackprop = false
 	return &Span{
@@ -26,6 +30,7 @@ func Nograd() *Span {
 }
 
 // TestMode disables training mode until End is called.
+// It changes Config for the whole process. Use a Session to disable training mode for one goroutine.
 func TestMode() *Span {
 	Config.Train = false
 	return &Span{
@@ -34,3 +39,18 @@ func TestMode() *Span {
 		},
 	}
 }
+
+// DetectAnomaly enables anomaly detection until End is called.
+// While it is enabled, functions panic with an *AnomalyError if their outputs, or the gradients from their Backward, contain NaN or Inf.
+// The outputs of the functions applied in Backward to the gradients are not checked, and the gradients they compute are checked instead.
+// The functions applied there to the saved inputs only, such as x^(c-1) in the backward of Pow, are checked like those in the forward.
+// It changes Config for the whole process, and slows down the computation to capture the stack of every function.
+// Use a Session with DetectAnomaly to enable anomaly detection for one goroutine.
+func DetectAnomaly() *Span {
+	Config.DetectAnomaly = true
+	return &Span{
+		End: func() {
+			Config.DetectAnomaly = false
+		},
+	}
+}
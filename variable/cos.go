@@ -1,30 +1,50 @@
 package variable
 
-import "github.com/itsubaki/autograd/tensor"
+import (
+	"math"
+
+	"github.com/itsubaki/autograd/tensor"
+)
 
 // Cos applies the cosine function.
-func Cos(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &CosT{},
+func Cos[T tensor.Float](x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &CosT[T]{},
 	}).First(x...)
 }
 
 // CosT is the differentiable cosine operation.
-type CosT struct {
-	x *Variable
+type CosT[T tensor.Float] struct {
+	x *Variable[T]
 }
 
-func (f *CosT) Forward(x ...*Variable) []*Variable {
+func (f *CosT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.x = x[0]
 
 	y := tensor.Cos(x[0].Data)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *CosT) Backward(gy ...*Variable) []*Variable {
-	return []*Variable{
+func (f *CosT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
 		Mul(Neg(Sin(f.x)), gy[0]), // -1.0 * sin(x) * gy
 	}
 }
+
+func (f *CosT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Mul(Neg(Sin(x[0])), tx[0]), // -1.0 * sin(x) * tx
+	}
+}
+
+func (f *CosT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Cos(x[0]),
+	}
+}
+
+func (f *CosT[T]) Scalar() (func(x T) T, func(x T) T) {
+	return scalar[T](math.Cos), func(x T) T { return -T(math.Sin(float64(x))) }
+}
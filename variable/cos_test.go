@@ -9,7 +9,7 @@ import (
 
 func ExampleCosT() {
 	x := variable.New(math.Pi / 4)
-	f := variable.CosT{}
+	f := variable.CosT[float64]{}
 
 	fmt.Println(x)
 	fmt.Println(f.Forward(x))
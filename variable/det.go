@@ -0,0 +1,47 @@
+package variable
+
+import (
+	"github.com/itsubaki/autograd/linalg"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// Det returns a variable representing the determinant of x[0].
+func Det[T tensor.Float](x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &DetT[T]{},
+	}).First(x...)
+}
+
+// DetT is the differentiable determinant operation.
+type DetT[T tensor.Float] struct {
+	x, y *Variable[T]
+}
+
+func (f *DetT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	f.x = x[0]
+	f.y = From(linalg.Det(x[0].Data))
+	return []*Variable[T]{
+		f.y,
+	}
+}
+
+func (f *DetT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	// gy * det(x) * inv(x)^T
+	gyy := Unsqueeze[T](-1)(Unsqueeze[T](-1)(Mul(gy[0], f.y)))
+	return []*Variable[T]{
+		Mul(gyy, mT(Inv(f.x))),
+	}
+}
+
+func (f *DetT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Mul(y[0], trace(Solve(x[0], tx[0]))), // det(x) * tr(x^-1 * tx)
+	}
+}
+
+func (f *DetT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Det(x[0]),
+	}
+}
@@ -0,0 +1,39 @@
+package variable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleDet() {
+	x := variable.New(
+		1, 2,
+		3, 4,
+	).Reshape(2, 2)
+
+	y := variable.Det(x)
+	y.Backward()
+
+	fmt.Printf("%.4f\n", y.Data.Data)
+	fmt.Printf("%.4f\n", x.Grad.Data.Data)
+
+	// Output:
+	// [-2.0000]
+	// [4.0000 -3.0000 -2.0000 1.0000]
+}
+
+func TestDet(t *testing.T) {
+	cases := []struct {
+		x *variable.Variable[float64]
+	}{
+		{spd(3, 3)},
+		{variable.Rand([]int{2, 3, 3}, rand.Const())},
+	}
+
+	for _, c := range cases {
+		gradcheck(t, first(variable.Det), c.x)
+	}
+}
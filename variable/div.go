@@ -3,48 +3,61 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // DivC returns a variable representing c / x[0].
-func DivC(c float64, x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &DivT{},
-	}).First(New(c), x[0])
+// c is a constant that does not require gradients.
+func DivC[T tensor.Float](c T, x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &DivT[T]{},
+	}).First(constant(c), x[0])
 }
 
 // Div returns a variable representing x[0] / x[1].
-func Div(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &DivT{},
+func Div[T tensor.Float](x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &DivT[T]{},
 	}).First(x...)
 }
 
 // DivT is the differentiable division operation.
-type DivT struct {
-	x0, x1           *Variable
+type DivT[T tensor.Float] struct {
+	x0, x1           *Variable[T]
 	x0Shape, x1Shape []int
 }
 
-func (f *DivT) Forward(x ...*Variable) []*Variable {
+func (f *DivT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.x0, f.x1 = x[0], x[1]
 	f.x0Shape, f.x1Shape = x[0].Shape(), x[1].Shape()
 
 	y := tensor.Div(x[0].Data, x[1].Data)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *DivT) Backward(gy ...*Variable) []*Variable {
+func (f *DivT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
 	gx0 := Div(gy[0], f.x1)
 	gx1 := Mul(gy[0], Div(Neg(f.x0), Mul(f.x1, f.x1))) // gy * (-x0 / x1^2)
 
 	if tensor.SliceEqual(f.x0Shape, f.x1Shape) {
-		return []*Variable{
+		return []*Variable[T]{
 			gx0,
 			gx1,
 		}
 	}
 
-	return []*Variable{
-		SumTo(f.x0Shape...)(gx0),
-		SumTo(f.x1Shape...)(gx1),
+	return []*Variable[T]{
+		SumTo[T](f.x0Shape...)(gx0),
+		SumTo[T](f.x1Shape...)(gx1),
+	}
+}
+
+func (f *DivT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Div(Sub(tx[0], Mul(y[0], tx[1])), x[1]), // (tx0 - y * tx1) / x1
+	}
+}
+
+func (f *DivT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Div(align(x, batched)...),
 	}
 }
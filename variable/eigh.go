@@ -0,0 +1,73 @@
+package variable
+
+import (
+	"github.com/itsubaki/autograd/linalg"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// Eigh returns the eigenvalues and eigenvectors of the symmetric matrix x[0].
+// The eigenvalues w are in ascending order and the eigenvectors v are columns, such that x[0] = v * diag(w) * v^T.
+// The eigenvalues must be distinct for the gradient with respect to v.
+func Eigh[T tensor.Float](x ...*Variable[T]) (w, v *Variable[T]) {
+	y := (&Function[T]{
+		Forwarder: &EighT[T]{},
+	}).Forward(x...)
+
+	return y[0], y[1]
+}
+
+// EighT is the differentiable symmetric eigendecomposition.
+type EighT[T tensor.Float] struct {
+	y []*Variable[T]
+}
+
+func (f *EighT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	w, v := linalg.Eigh(x[0].Data)
+	f.y = []*Variable[T]{
+		From(w),
+		From(v),
+	}
+
+	return f.y
+}
+
+func (f *EighT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	// gx = v * (diag(gw) + f * (v^T * gv)) * v^T
+	// f[i, j] = 1 / (w[j] - w[i]) for i != j, and 0 for i = j
+	g := orZero(gy, f.y)
+	w, v, gw, gv := f.y[0], f.y[1], g[0], g[1]
+
+	i := eye[T](w.Size(-1))
+	e := Add(Sub(Unsqueeze[T](-2)(w), Unsqueeze[T](-1)(w)), i)
+	fm := Mul(Pow[T](-1)(e), SubC(1, i))
+
+	inner := Add(diag(gw), Mul(fm, MatMul(mT(v), gv)))
+	return []*Variable[T]{
+		MatMul(MatMul(v, inner), mT(v)),
+	}
+}
+
+func (f *EighT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	// tw = diagonal(v^T * tx * v)
+	// tv = v * (f * (v^T * tx * v))
+	w, v := y[0], y[1]
+
+	i := eye[T](w.Size(-1))
+	e := Add(Sub(Unsqueeze[T](-2)(w), Unsqueeze[T](-1)(w)), i)
+	fm := Mul(Pow[T](-1)(e), SubC(1, i))
+
+	m := MatMul(MatMul(mT(v), tx[0]), v)
+	return []*Variable[T]{
+		diagonal(m),
+		MatMul(v, Mul(fm, m)),
+	}
+}
+
+func (f *EighT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	w, v := Eigh(x[0])
+	return []*Variable[T]{
+		w,
+		v,
+	}
+}
@@ -0,0 +1,39 @@
+package variable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleEigh() {
+	x := variable.New(
+		2, 1,
+		1, 2,
+	).Reshape(2, 2)
+
+	w, _ := variable.Eigh(x)
+	w.Backward()
+
+	fmt.Printf("%.4f\n", w.Data.Data)
+	fmt.Printf("%.4f\n", x.Grad.Data.Data)
+
+	// Output:
+	// [1.0000 3.0000]
+	// [1.0000 0.0000 0.0000 1.0000]
+}
+
+func TestEigh(t *testing.T) {
+	cases := []struct {
+		x *variable.Variable[float64]
+	}{
+		{spd(1, 1)},
+		{spd(3, 3)},
+		{spd(2, 4, 4)},
+	}
+
+	for _, c := range cases {
+		gradcheck(t, sym(two(variable.Eigh)), c.x)
+	}
+}
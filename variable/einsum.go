@@ -0,0 +1,129 @@
+package variable
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// Einsum returns a function that computes the Einstein summation of x described by subscripts.
+// See tensor.Einsum for the subscripts syntax.
+func Einsum[T tensor.Float](subscripts string) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &EinsumT[T]{
+			Subscripts: subscripts,
+		},
+	}).First
+}
+
+// EinsumT is the differentiable Einstein summation operation.
+type EinsumT[T tensor.Float] struct {
+	Subscripts string
+	in         []string
+	out        string
+	x          []*Variable[T]
+}
+
+func (f *EinsumT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	f.in, f.out = tensor.ParseEinsum(f.Subscripts, len(x))
+	f.x = x
+
+	list := make([]*tensor.Tensor[T], len(x))
+	for i, v := range x {
+		list[i] = v.Data
+	}
+
+	y := tensor.Einsum(f.Subscripts, list...)
+	return []*Variable[T]{
+		From(y),
+	}
+}
+
+// Backward computes the gradient of each input as an einsum of gy and the other inputs.
+// For example, "ij,jk->ik" gives gx0 = einsum("ik,jk->ij", gy, x1) and gx1 = einsum("ik,ij->jk", gy, x0).
+// Labels of an input that appear neither in the output nor in the other inputs are
+// broadcast by contracting with a ones variable.
+func (f *EinsumT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	gxs := make([]*Variable[T], len(f.x))
+	for i := range f.x {
+		sub := []string{f.out}
+		xs := []*Variable[T]{gy[0]}
+		for j := range f.x {
+			if j == i {
+				continue
+			}
+
+			sub = append(sub, f.in[j])
+			xs = append(xs, f.x[j])
+		}
+
+		// labels only in x[i]
+		present := strings.Join(sub, "")
+		var missing []byte
+		var shape []int
+		for k := range len(f.in[i]) {
+			l := f.in[i][k]
+			if strings.IndexByte(present, l) >= 0 || strings.IndexByte(string(missing), l) >= 0 {
+				continue
+			}
+
+			missing = append(missing, l)
+			shape = append(shape, f.x[i].Size(k))
+		}
+
+		if len(missing) > 0 {
+			sub = append(sub, string(missing))
+			xs = append(xs, Ones[T](shape...))
+		}
+
+		gxs[i] = Einsum[T](strings.Join(sub, ",") + "->" + f.in[i])(xs...)
+	}
+
+	return gxs
+}
+
+// JVP computes the tangent as the sum of the einsums with each input replaced by its tangent.
+func (f *EinsumT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	var ty *Variable[T]
+	for i := range x {
+		xs := slices.Clone(x)
+		xs[i] = tx[i]
+		ty = add(ty, Einsum[T](f.Subscripts)(xs...))
+	}
+
+	return []*Variable[T]{
+		ty,
+	}
+}
+
+// Batch adds a label for the examples to the subscripts of the batched inputs and the output.
+func (f *EinsumT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	in, out := tensor.ParseEinsum(f.Subscripts, len(x))
+
+	// unused label
+	var label string
+	for _, c := range "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ" {
+		if !strings.ContainsRune(f.Subscripts, c) {
+			label = string(c)
+			break
+		}
+	}
+
+	if label == "" {
+		panic(fmt.Sprintf("no unused label for the batch axis in %q", f.Subscripts))
+	}
+
+	sub := make([]string, len(in))
+	for i := range in {
+		sub[i] = in[i]
+		if batched[i] {
+			sub[i] = label + in[i]
+		}
+	}
+
+	return []*Variable[T]{
+		Einsum[T](strings.Join(sub, ",") + "->" + label + out)(x...),
+	}
+}
@@ -0,0 +1,148 @@
+package variable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleEinsum() {
+	x := variable.New(
+		1, 2, 3,
+		4, 5, 6,
+	).Reshape(2, 3)
+
+	w := variable.New(
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+	).Reshape(3, 4)
+
+	y := variable.Einsum[float64]("ij,jk->ik")(x, w)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+	fmt.Println(w.Grad)
+
+	// Output:
+	// variable[2 4]([38 44 50 56 83 98 113 128])
+	// variable[2 3]([10 26 42 10 26 42])
+	// variable[3 4]([5 5 5 5 7 7 7 7 9 9 9 9])
+}
+
+func ExampleEinsum_sum() {
+	x := variable.New(
+		1, 2, 3,
+		4, 5, 6,
+	).Reshape(2, 3)
+
+	y := variable.Einsum[float64]("ij->i")(x)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+
+	// Output:
+	// variable[2]([6 15])
+	// variable[2 3]([1 1 1 1 1 1])
+}
+
+func ExampleEinsum_trace() {
+	x := variable.New(
+		1, 2,
+		3, 4,
+	).Reshape(2, 2)
+
+	y := variable.Einsum[float64]("ii->")(x)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+
+	// Output:
+	// variable(5)
+	// variable[2 2]([1 0 0 1])
+}
+
+func ExampleEinsum_double() {
+	x := variable.New(1, 2, 3)
+
+	// sum(x * x)
+	y := variable.Einsum[float64]("i,i->")(x, x)
+	y.Backward(variable.Opts{CreateGraph: true})
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+
+	gx := x.Grad
+	x.Cleargrad()
+	variable.Sum[float64]()(gx).Backward()
+	fmt.Println(x.Grad)
+
+	// Output:
+	// variable(14)
+	// variable[3]([2 4 6])
+	// variable[3]([2 2 2])
+}
+
+func TestEinsum(t *testing.T) {
+	cases := []struct {
+		subscripts string
+		shapes     [][]int
+		f          func(x ...*variable.Variable[float64]) *variable.Variable[float64]
+	}{
+		{
+			subscripts: "bij,bjk->bik",
+			shapes:     [][]int{{2, 3, 4}, {2, 4, 5}},
+			f:          variable.MatMul[float64],
+		},
+		{
+			subscripts: "ij->ji",
+			shapes:     [][]int{{2, 3}},
+			f:          variable.Transpose[float64](1, 0),
+		},
+		{
+			subscripts: "ij,ij->ij",
+			shapes:     [][]int{{2, 3}, {2, 3}},
+			f:          variable.Mul[float64],
+		},
+		{
+			subscripts: "ij,j->ij",
+			shapes:     [][]int{{2, 3}, {3}},
+			f:          variable.Mul[float64],
+		},
+		{
+			subscripts: "ijk->ik",
+			shapes:     [][]int{{2, 3, 4}},
+			f:          variable.Sum[float64](1),
+		},
+	}
+
+	for _, c := range cases {
+		x0 := make([]*variable.Variable[float64], len(c.shapes))
+		x1 := make([]*variable.Variable[float64], len(c.shapes))
+		for i, s := range c.shapes {
+			x0[i] = variable.Randn(s)
+			x1[i] = variable.From(tensor.Clone(x0[i].Data))
+		}
+
+		y0 := variable.Einsum[float64](c.subscripts)(x0...)
+		y1 := c.f(x1...)
+		if !tensor.IsCloseAll(y0.Data, y1.Data) {
+			t.Errorf("%v: got=%v, want=%v", c.subscripts, y0, y1)
+		}
+
+		gy := variable.Randn(y0.Shape())
+		y0.Grad, y1.Grad = gy, gy
+		y0.Backward()
+		y1.Backward()
+
+		for i := range x0 {
+			if !tensor.IsCloseAll(x0[i].Grad.Data, x1[i].Grad.Data) {
+				t.Errorf("%v: got=%v, want=%v", c.subscripts, x0[i].Grad, x1[i].Grad)
+			}
+		}
+	}
+}
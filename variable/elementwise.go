@@ -0,0 +1,14 @@
+package variable
+
+import "github.com/itsubaki/autograd/tensor"
+
+// Elementwise is the interface implemented by differentiable operations that apply a scalar function to each element of x[0].
+type Elementwise[T tensor.Float] interface {
+	// Scalar returns the function applied to each element and its derivative.
+	Scalar() (f, df func(x T) T)
+}
+
+// scalar returns f applied to an element of type T.
+func scalar[T tensor.Float](f func(x float64) float64) func(x T) T {
+	return func(x T) T { return T(f(float64(x))) }
+}
@@ -0,0 +1,53 @@
+package variable_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleElementwise() {
+	var e variable.Elementwise[float64] = &variable.PowT[float64]{P: 3}
+	f, df := e.Scalar()
+	fmt.Println(f(2), df(2))
+
+	// Output:
+	// 8 12
+}
+
+func TestElementwise(t *testing.T) {
+	s := rand.NewPCG(1, 2)
+
+	cases := []struct {
+		f func(x ...*variable.Variable[float64]) *variable.Variable[float64]
+	}{
+		{variable.Sin[float64]},
+		{variable.Cos[float64]},
+		{variable.Tanh[float64]},
+		{variable.Exp[float64]},
+		{variable.Log[float64]},
+		{variable.Neg[float64]},
+		{variable.Square[float64]},
+		{variable.Pow(2.5)},
+		{variable.Clip(0.2, 0.7)},
+	}
+
+	for _, c := range cases {
+		x := variable.Rand([]int{2, 3}, s)
+		y := c.f(x)
+		y.Backward()
+
+		f, df := y.Creator.Forwarder.(variable.Elementwise[float64]).Scalar()
+		name := fmt.Sprintf("%T", y.Creator.Forwarder)
+		if !tensor.IsCloseAll(tensor.F(x.Data, f), y.Data, 1e-12, 1e-12) {
+			t.Errorf("%s: f=%v, want=%v", name, tensor.F(x.Data, f).Data, y.Data.Data)
+		}
+
+		if !tensor.IsCloseAll(tensor.F(x.Data, df), x.Grad.Data, 1e-12, 1e-12) {
+			t.Errorf("%s: df=%v, want=%v", name, tensor.F(x.Data, df).Data, x.Grad.Data.Data)
+		}
+	}
+}
@@ -1,28 +1,48 @@
 package variable
 
-import "github.com/itsubaki/autograd/tensor"
+import (
+	"math"
+
+	"github.com/itsubaki/autograd/tensor"
+)
 
 // Exp applies the exponential function.
-func Exp(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &ExpT{},
+func Exp[T tensor.Float](x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &ExpT[T]{},
 	}).First(x...)
 }
 
 // ExpT is the differentiable exponential operation.
-type ExpT struct {
-	y *Variable
+type ExpT[T tensor.Float] struct {
+	y *Variable[T]
 }
 
-func (f *ExpT) Forward(x ...*Variable) []*Variable {
+func (f *ExpT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.y = From(tensor.Exp(x[0].Data))
-	return []*Variable{
+	return []*Variable[T]{
 		f.y,
 	}
 }
 
-func (f *ExpT) Backward(gy ...*Variable) []*Variable {
-	return []*Variable{
+func (f *ExpT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
 		Mul(gy[0], f.y), // gy * y
 	}
 }
+
+func (f *ExpT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Mul(tx[0], y[0]), // tx * y
+	}
+}
+
+func (f *ExpT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Exp(x[0]),
+	}
+}
+
+func (f *ExpT[T]) Scalar() (func(x T) T, func(x T) T) {
+	return scalar[T](math.Exp), scalar[T](math.Exp)
+}
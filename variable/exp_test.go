@@ -8,7 +8,7 @@ import (
 
 func ExampleExpT() {
 	x := variable.New(1, 2, 3, 4, 5)
-	f := variable.ExpT{}
+	f := variable.ExpT[float64]{}
 
 	fmt.Println(x)
 	fmt.Println(f.Forward(x))
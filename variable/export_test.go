@@ -1,7 +1,7 @@
 package variable
 
 var (
-	AddGrad = add
-	Zip     = zip
+	AddGrad = add[float64]
+	Zip     = zip[float64]
 	InvPerm = invperm
 )
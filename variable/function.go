@@ -1,29 +1,70 @@
 package variable
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/itsubaki/autograd/tensor"
+)
 
 // Forwarder is the interface implemented by differentiable operations.
-type Forwarder interface {
-	Forward(x ...*Variable) []*Variable
-	Backward(gy ...*Variable) []*Variable
+type Forwarder[T tensor.Float] interface {
+	Forward(x ...*Variable[T]) []*Variable[T]
+	Backward(gy ...*Variable[T]) []*Variable[T]
 }
 
 // Function represents a differentiable operation in the computation graph.
-type Function struct {
-	Input, Output []*Variable
+type Function[T tensor.Float] struct {
+	Input, Output []*Variable[T]
 	Generation    int
-	Forwarder
+	Forwarder[T]
+	stack []byte
 }
 
 // First applies the function and returns the first output.
-func (f *Function) First(x ...*Variable) *Variable {
+func (f *Function[T]) First(x ...*Variable[T]) *Variable[T] {
 	return f.Forward(x...)[0]
 }
 
 // Forward applies the function.
-func (f *Function) Forward(x ...*Variable) []*Variable {
+// If any input is batched, the function is applied to each example.
+func (f *Function[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	if batched(x...) {
+		return f.vmap(x)
+	}
+
+	return f.forward(x...)
+}
+
+// forward applies the function to the inputs as they are.
+func (f *Function[T]) forward(x ...*Variable[T]) []*Variable[T] {
 	y := f.Forwarder.Forward(x...)
-	if !Config.EnableBackprop {
+	if c, ok := f.Forwarder.(capturer[T]); ok {
+		// the variables captured by Forward are inputs as well
+		x = append(x[:len(x):len(x)], c.captured()...)
+	}
+
+	s := session(x...)
+	if s.detectAnomaly() {
+		f.checkForward(x, y)
+	}
+
+	if dual(x...) {
+		f.jvp(x, y)
+	}
+
+	for i := range y {
+		y[i].session = s
+	}
+
+	if !s.enableBackprop() {
+		return y
+	}
+
+	if !requiresGrad(x...) {
+		for i := range y {
+			y[i].nograd = true
+		}
+
 		return y
 	}
 
@@ -35,17 +76,17 @@ func (f *Function) Forward(x ...*Variable) []*Variable {
 }
 
 // String returns a string representation of the function.
-func (f Function) String() string {
+func (f Function[T]) String() string {
 	return fmt.Sprintf("%T%v", f.Forwarder, f.Input)
 }
 
-func (f *Function) setCreator(y []*Variable) {
+func (f *Function[T]) setCreator(y []*Variable[T]) {
 	for i := range y {
 		y[i].SetCreator(f)
 	}
 }
 
-func maxgen(x ...*Variable) int {
+func maxgen[T tensor.Float](x ...*Variable[T]) int {
 	var max int
 	for _, v := range x {
 		if max < v.Generation {
@@ -7,8 +7,8 @@ import (
 )
 
 func ExampleFunction() {
-	f := &variable.Function{
-		Forwarder: &variable.SinT{},
+	f := &variable.Function[float64]{
+		Forwarder: &variable.SinT[float64]{},
 	}
 
 	y := f.Forward(variable.New(1.0))
@@ -16,6 +16,6 @@ func ExampleFunction() {
 	fmt.Println(y)
 
 	// Output:
-	// *variable.SinT[variable(1)]
+	// *variable.SinT[float64][variable(1)]
 	// [variable(0.8414709848078965)]
 }
@@ -0,0 +1,54 @@
+package variable
+
+import "github.com/itsubaki/autograd/tensor"
+
+// Gather returns a function that selects elements of x[0] by the index tensor along the given axis.
+// index must have the same number of dimensions as x[0], and the output has the shape of index.
+func Gather[T tensor.Float](axis int, index *tensor.Tensor[int]) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &GatherT[T]{
+			Axis:  axis,
+			Index: index,
+		},
+	}).First
+}
+
+// GatherT is the differentiable gather operation.
+type GatherT[T tensor.Float] struct {
+	Axis   int
+	Index  *tensor.Tensor[int]
+	xShape []int
+}
+
+func (f *GatherT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	f.xShape = x[0].Shape()
+
+	y := tensor.Gather(x[0].Data, f.Axis, f.Index)
+	return []*Variable[T]{
+		From(y),
+	}
+}
+
+func (f *GatherT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		ScatterAdd[T](f.Axis, f.Index)(Zeros[T](f.xShape...), gy[0]),
+	}
+}
+
+func (f *GatherT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Gather[T](f.Axis, f.Index)(tx[0]),
+	}
+}
+
+func (f *GatherT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	index := expandIndex(x[0].Size(0), f.Index)
+	return []*Variable[T]{
+		Gather[T](shiftAxis(f.Axis, f.Index.NumDims()), index)(x[0]),
+	}
+}
+
+// expandIndex returns the index shared by every example broadcast along a new axis 0 of the given size.
+func expandIndex(size int, index *tensor.Tensor[int]) *tensor.Tensor[int] {
+	return tensor.BroadcastTo(tensor.Unsqueeze(index, 0), append([]int{size}, index.Shape...)...)
+}
@@ -0,0 +1,63 @@
+package variable_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleGather() {
+	// (N, C)
+	x := variable.New(
+		1, 2, 3,
+		4, 5, 6,
+	).Reshape(2, 3)
+
+	label := tensor.New([]int{2, 1}, []int{2, 0})
+	y := variable.Gather[float64](1, label)(x)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+
+	// Output:
+	// variable[2 1]([3 4])
+	// variable[2 3]([0 0 1 1 0 0])
+}
+
+func ExampleGather_duplicate() {
+	x := variable.New(1, 2, 3)
+
+	index := tensor.New([]int{4}, []int{0, 2, 2, 2})
+	y := variable.Gather[float64](0, index)(x)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+
+	// Output:
+	// variable[4]([1 3 3 3])
+	// variable[3]([1 0 3])
+}
+
+func ExampleGather_double() {
+	x := variable.New(1, 2, 3)
+
+	index := tensor.New([]int{2}, []int{2, 2})
+	y := variable.Gather[float64](0, index)(x)
+	y = variable.Mul(y, y)
+	y.Backward(variable.Opts{CreateGraph: true})
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+
+	gx := x.Grad
+	x.Cleargrad()
+	gx.Backward()
+	fmt.Println(x.Grad)
+
+	// Output:
+	// variable[2]([9 9])
+	// variable[3]([0 0 12])
+	// variable[3]([0 0 4])
+}
@@ -3,9 +3,9 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // GetItem returns a function that selects items from x[0] along the given axis.
-func GetItem(axis int, indices []int) func(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &GetItemT{
+func GetItem[T tensor.Float](axis int, indices []int) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &GetItemT[T]{
 			Axis:    axis,
 			Indices: indices,
 		},
@@ -13,23 +13,35 @@ func GetItem(axis int, indices []int) func(x ...*Variable) *Variable {
 }
 
 // GetItemT is the differentiable indexing operation.
-type GetItemT struct {
+type GetItemT[T tensor.Float] struct {
 	Axis    int
 	Indices []int
 	xShape  []int
 }
 
-func (f *GetItemT) Forward(x ...*Variable) []*Variable {
+func (f *GetItemT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.xShape = x[0].Shape()
 
 	y := tensor.Take(x[0].Data, f.Axis, f.Indices)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *GetItemT) Backward(gy ...*Variable) []*Variable {
-	return []*Variable{
-		GetItemGrad(f.Axis, f.Indices, f.xShape)(gy...),
+func (f *GetItemT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		GetItemGrad[T](f.Axis, f.Indices, f.xShape)(gy...),
+	}
+}
+
+func (f *GetItemT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		GetItem[T](f.Axis, f.Indices)(tx[0]),
+	}
+}
+
+func (f *GetItemT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		GetItem[T](shiftAxis(f.Axis, rank(x[0], true)), f.Indices)(x[0]),
 	}
 }
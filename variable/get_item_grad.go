@@ -3,9 +3,9 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // GetItemGrad returns a function that scatters gradients back to the original shape.
-func GetItemGrad(axis int, indices, shape []int) func(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &GetItemGradT{
+func GetItemGrad[T tensor.Float](axis int, indices, shape []int) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &GetItemGradT[T]{
 			Axis:    axis,
 			Indices: indices,
 			Shape:   shape,
@@ -14,23 +14,36 @@ func GetItemGrad(axis int, indices, shape []int) func(x ...*Variable) *Variable
 }
 
 // GetItemGradT is the differentiable gradient operation for GetItem.
-type GetItemGradT struct {
+type GetItemGradT[T tensor.Float] struct {
 	Axis    int
 	Indices []int
 	Shape   []int
 }
 
-func (f *GetItemGradT) Forward(gy ...*Variable) []*Variable {
-	z := tensor.Zeros[float64](f.Shape...)
+func (f *GetItemGradT[T]) Forward(gy ...*Variable[T]) []*Variable[T] {
+	z := tensor.Zeros[T](f.Shape...)
 	gx := tensor.ScatterAdd(z, gy[0].Data, f.Axis, f.Indices)
 
-	return []*Variable{
+	return []*Variable[T]{
 		From(gx),
 	}
 }
 
-func (f *GetItemGradT) Backward(ggx ...*Variable) []*Variable {
-	return []*Variable{
-		GetItem(f.Axis, f.Indices)(ggx...),
+func (f *GetItemGradT[T]) Backward(ggx ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		GetItem[T](f.Axis, f.Indices)(ggx...),
+	}
+}
+
+func (f *GetItemGradT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		GetItemGrad[T](f.Axis, f.Indices, f.Shape)(tx[0]),
+	}
+}
+
+func (f *GetItemGradT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	shape := append([]int{x[0].Size(0)}, f.Shape...)
+	return []*Variable[T]{
+		GetItemGrad[T](shiftAxis(f.Axis, len(f.Shape)), f.Indices, shape)(x[0]),
 	}
 }
@@ -0,0 +1,49 @@
+package variable
+
+import "github.com/itsubaki/autograd/tensor"
+
+// RequiresGrad reports whether gradients are computed for v.
+// It is true unless SetRequiresGrad(false) is called, or v is the output of a function whose inputs do not require gradients.
+func (v *Variable[T]) RequiresGrad() bool {
+	return !v.nograd
+}
+
+// SetRequiresGrad sets whether gradients are computed for v, and returns v.
+// Functions whose inputs do not require gradients do not create a graph, and their outputs do not require gradients.
+// Backward does not set the gradient of a variable that does not require gradients, nor backpropagate through it.
+func (v *Variable[T]) SetRequiresGrad(requires bool) *Variable[T] {
+	v.nograd = !requires
+	return v
+}
+
+// RegisterHook registers a hook that is called during Backward with the gradient with respect to v.
+// The hook is called once for each Backward, with the sum of the gradients from the functions of v,
+// and before the gradient is backpropagated to the creator of v.
+// If Backward is called on v, the hook is called with the seed gradient.
+// The gradient returned by the hook is accumulated instead, or the gradient is unchanged if the hook returns nil.
+// Hooks are called in the order they are registered.
+func (v *Variable[T]) RegisterHook(hook func(grad *Variable[T]) *Variable[T]) {
+	v.hooks = append(v.hooks, hook)
+}
+
+// hook returns the gradient gx with respect to v transformed by the hooks of v.
+func (v *Variable[T]) hook(gx *Variable[T]) *Variable[T] {
+	for _, h := range v.hooks {
+		if g := h(gx); g != nil {
+			gx = g
+		}
+	}
+
+	return gx
+}
+
+// requiresGrad reports whether any of the variables requires gradients.
+func requiresGrad[T tensor.Float](x ...*Variable[T]) bool {
+	for _, v := range x {
+		if v != nil && !v.nograd {
+			return true
+		}
+	}
+
+	return false
+}
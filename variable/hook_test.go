@@ -0,0 +1,142 @@
+package variable_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleVariable_SetRequiresGrad() {
+	x := variable.New(1, 2)
+	w := variable.New(3, 4).SetRequiresGrad(false)
+	b := variable.New(5, 6).SetRequiresGrad(false)
+
+	y := variable.Add(variable.Mul(x, w), b)
+	y.Backward()
+
+	fmt.Println(x.Grad, w.Grad, b.Grad)
+	fmt.Println(w.RequiresGrad(), y.RequiresGrad())
+
+	// Output:
+	// variable[2]([3 4]) <nil> <nil>
+	// false true
+}
+
+func ExampleVariable_SetRequiresGrad_graph() {
+	w := variable.New(3, 4).SetRequiresGrad(false)
+	y := variable.Sin(w)
+
+	fmt.Println(y.RequiresGrad(), y.Creator)
+
+	// Output:
+	// false <nil>
+}
+
+func ExampleVariable_RegisterHook() {
+	x := variable.New(1, 2, 3)
+
+	// mask the gradient
+	x.RegisterHook(func(grad *variable.Variable[float64]) *variable.Variable[float64] {
+		return variable.Mul(grad, variable.New(1, 0, 1))
+	})
+
+	// log the gradient
+	x.RegisterHook(func(grad *variable.Variable[float64]) *variable.Variable[float64] {
+		fmt.Println("hook:", grad)
+		return nil
+	})
+
+	y := variable.Mul(x, x)
+	y.Backward()
+	fmt.Println(x.Grad)
+
+	// Output:
+	// hook: variable[3]([2 0 6])
+	// variable[3]([2 0 6])
+}
+
+func TestRequiresGrad(t *testing.T) {
+	// h depends on x directly and through the frozen variable z
+	x := variable.New(2)
+	z := variable.Exp(x).SetRequiresGrad(false)
+	h := variable.Mul(x, z)
+	h.Backward()
+
+	want := math.Exp(2)
+	if !tensor.IsCloseAll(x.Grad.Data, tensor.New([]int{1}, []float64{want}), 1e-10, 1e-10) {
+		t.Errorf("x.Grad=%v, want=%v", x.Grad, want)
+	}
+
+	if z.Grad != nil {
+		t.Errorf("z.Grad=%v", z.Grad)
+	}
+}
+
+func TestRegisterHook(t *testing.T) {
+	// the hook is called once with the sum of the gradients with respect to x
+	x := variable.New(3)
+
+	var count int
+	x.RegisterHook(func(grad *variable.Variable[float64]) *variable.Variable[float64] {
+		count++
+		return variable.MulC(2, grad)
+	})
+
+	y := variable.Add(variable.Mul(x, x), x)
+	y.Backward()
+
+	if count != 1 {
+		t.Errorf("count=%d, want=1", count)
+	}
+
+	// 2 * (x + x + 1)
+	if x.Grad.At() != 14 {
+		t.Errorf("x.Grad=%v, want=14", x.Grad)
+	}
+}
+
+func TestRegisterHook_root(t *testing.T) {
+	// the hook of y is called with the seed gradient
+	x := variable.New(1, 2)
+	y := variable.MulC(3, x)
+
+	var count int
+	y.RegisterHook(func(grad *variable.Variable[float64]) *variable.Variable[float64] {
+		count++
+		return variable.Mul(grad, variable.New(0, 1))
+	})
+
+	y.Backward()
+
+	if count != 1 {
+		t.Errorf("count=%d, want=1", count)
+	}
+
+	if !tensor.IsCloseAll(x.Grad.Data, tensor.New([]int{2}, []float64{0, 3}), 1e-10, 1e-10) {
+		t.Errorf("x.Grad=%v", x.Grad)
+	}
+}
+
+func TestRegisterHook_creator(t *testing.T) {
+	// the hook of h changes the gradient backpropagated to x
+	x := variable.New(1, 2)
+	h := variable.Exp(x)
+	h.RegisterHook(func(grad *variable.Variable[float64]) *variable.Variable[float64] {
+		return variable.Mul(grad, variable.New(0, 1))
+	})
+
+	y := variable.Sum[float64]()(variable.Add(h, h))
+	y.Backward(variable.Opts{RetainGrad: true})
+
+	want := []float64{0, 2 * math.Exp(2)}
+	if !tensor.IsCloseAll(x.Grad.Data, tensor.New([]int{2}, want), 1e-10, 1e-10) {
+		t.Errorf("x.Grad=%v, want=%v", x.Grad, want)
+	}
+
+	if !tensor.IsCloseAll(h.Grad.Data, tensor.New([]int{2}, []float64{0, 2}), 1e-10, 1e-10) {
+		t.Errorf("h.Grad=%v", h.Grad)
+	}
+}
@@ -0,0 +1,45 @@
+package variable
+
+import (
+	"github.com/itsubaki/autograd/linalg"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// Inv returns a variable representing the inverse of x[0].
+func Inv[T tensor.Float](x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &InvT[T]{},
+	}).First(x...)
+}
+
+// InvT is the differentiable matrix inverse operation.
+type InvT[T tensor.Float] struct {
+	y *Variable[T]
+}
+
+func (f *InvT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	f.y = From(linalg.Inv(x[0].Data))
+	return []*Variable[T]{
+		f.y,
+	}
+}
+
+func (f *InvT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	yT := mT(f.y)
+	return []*Variable[T]{
+		Neg(MatMul(MatMul(yT, gy[0]), yT)), // -y^T * gy * y^T
+	}
+}
+
+func (f *InvT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Neg(MatMul(MatMul(y[0], tx[0]), y[0])), // -y * tx * y
+	}
+}
+
+func (f *InvT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Inv(x[0]),
+	}
+}
@@ -0,0 +1,39 @@
+package variable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleInv() {
+	x := variable.New(
+		4, 7,
+		2, 6,
+	).Reshape(2, 2)
+
+	y := variable.Inv(x)
+	y.Backward()
+
+	fmt.Printf("%.4f\n", y.Data.Data)
+	fmt.Printf("%.4f\n", x.Grad.Data.Data)
+
+	// Output:
+	// [0.6000 -0.7000 -0.2000 0.4000]
+	// [0.0400 -0.0800 -0.0300 0.0600]
+}
+
+func TestInv(t *testing.T) {
+	cases := []struct {
+		x *variable.Variable[float64]
+	}{
+		{spd(3, 3)},
+		{variable.Rand([]int{2, 3, 3}, rand.Const())},
+	}
+
+	for _, c := range cases {
+		gradcheck(t, first(variable.Inv), c.x)
+	}
+}
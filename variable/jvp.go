@@ -0,0 +1,88 @@
+package variable
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// JVPer is the interface implemented by differentiable operations that support forward-mode differentiation.
+type JVPer[T tensor.Float] interface {
+	// JVP returns the tangents of the outputs y given the inputs x and their tangents tx.
+	// x, y and tx are bound to a session without backprop, and must not be modified.
+	JVP(x, y, tx []*Variable[T]) []*Variable[T]
+}
+
+// jvp is the session of the variables while computing tangents.
+// The functions applied to compute tangents, such as f of Checkpoint, recompute the outputs of Forward.
+var jvp = &Session{EnableBackprop: false, Train: true, Recompute: true}
+
+// Dual returns a new variable that shares the data of x and carries the tangent t for forward-mode differentiation.
+// The outputs of a function of dual variables carry the Jacobian-vector products of the tangents, available from Tangent.
+// t must have the same shape as x.
+func Dual[T tensor.Float](x, t *Variable[T]) *Variable[T] {
+	if !tensor.SliceEqual(x.Shape(), t.Shape()) {
+		panic(fmt.Sprintf("tangent shape %v does not match %v", t.Shape(), x.Shape()))
+	}
+
+	return &Variable[T]{
+		Name:    x.Name,
+		Data:    x.Data,
+		session: x.session,
+		tangent: From(t.Data),
+	}
+}
+
+// Tangent returns the tangent carried by v, or nil if v does not depend on a dual variable.
+// The tangent is not part of the graph.
+func (v *Variable[T]) Tangent() *Variable[T] {
+	return v.tangent
+}
+
+// jvp sets the tangents of the outputs y of the function given its inputs x.
+func (f *Function[T]) jvp(x, y []*Variable[T]) {
+	j, ok := f.Forwarder.(JVPer[T])
+	if !ok {
+		panic(fmt.Sprintf("%T does not support forward-mode differentiation", f.Forwarder))
+	}
+
+	xs, tx := make([]*Variable[T], len(x)), make([]*Variable[T], len(x))
+	for i, v := range x {
+		if v == nil {
+			continue
+		}
+
+		xs[i] = v.Bind(jvp)
+		if v.tangent == nil {
+			tx[i] = ZeroLike(v).Bind(jvp)
+			continue
+		}
+
+		tx[i] = v.tangent.Bind(jvp)
+	}
+
+	ys := make([]*Variable[T], len(y))
+	for i, v := range y {
+		ys[i] = v.Bind(jvp)
+	}
+
+	ty := j.JVP(xs, ys, tx)
+	for i := range min(len(y), len(ty)) {
+		if ty[i] == nil {
+			continue
+		}
+
+		y[i].tangent = From(ty[i].Data)
+	}
+}
+
+// dual reports whether any of the variables carries a tangent.
+func dual[T tensor.Float](x ...*Variable[T]) bool {
+	for _, v := range x {
+		if v != nil && v.tangent != nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,212 @@
+package variable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/autograd/numerical"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleDual() {
+	x := variable.Dual(variable.New(1, 2, 3), variable.New(1, 0, 0))
+	y := variable.Sum[float64]()(variable.Square(x))
+
+	fmt.Println(y)
+	fmt.Println(y.Tangent())
+
+	// Output:
+	// variable(14)
+	// variable(2)
+}
+
+func ExampleDual_matmul() {
+	a := variable.New(1, 2, 3, 4).Reshape(2, 2)
+	x := variable.Dual(variable.New(1, 1).Reshape(2, 1), variable.New(1, 0).Reshape(2, 1))
+	y := variable.MatMul(a, x)
+
+	fmt.Println(y)
+	fmt.Println(y.Tangent())
+
+	// Output:
+	// variable[2 1]([3 7])
+	// variable[2 1]([1 3])
+}
+
+func ExampleVariable_Tangent() {
+	x := variable.New(1, 2)
+	y := variable.Exp(x)
+
+	fmt.Println(y.Tangent())
+
+	// Output:
+	// <nil>
+}
+
+func TestDual_backward(t *testing.T) {
+	x := variable.Dual(variable.New(1.0), variable.New(1.0))
+	y := variable.Sin(x)
+	y.Backward()
+
+	// the graph is created as usual
+	if !tensor.IsCloseAll(x.Grad.Data, y.Tangent().Data) {
+		t.Errorf("got=%v, want=%v", x.Grad, y.Tangent())
+	}
+}
+
+func TestDual_shape(t *testing.T) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			return
+		}
+
+		t.Fail()
+	}()
+
+	variable.Dual(variable.New(1, 2), variable.New(1))
+}
+
+func TestJVP(t *testing.T) {
+	x := variable.Rand([]int{2, 3}, rand.Const(1))
+	w := variable.Rand([]int{3, 2}, rand.Const(2))
+	b := variable.Rand([]int{1, 3}, rand.Const(3))
+	p := variable.AddC(0.5, variable.Rand([]int{2, 3}, rand.Const(4)))
+	c := variable.Rand([]int{3, 3}, rand.Const(5))
+
+	cases := []struct {
+		f func(x ...*variable.Variable[float64]) []*variable.Variable[float64]
+		x []*variable.Variable[float64]
+	}{
+		{first(variable.Add), []*variable.Variable[float64]{x, b}},
+		{first(variable.Sub), []*variable.Variable[float64]{x, b}},
+		{first(variable.Mul), []*variable.Variable[float64]{x, b}},
+		{first(variable.Div), []*variable.Variable[float64]{x, p}},
+		{first(variable.Neg), []*variable.Variable[float64]{x}},
+		{first(variable.Exp), []*variable.Variable[float64]{x}},
+		{first(variable.Log), []*variable.Variable[float64]{p}},
+		{first(variable.Sin), []*variable.Variable[float64]{x}},
+		{first(variable.Cos), []*variable.Variable[float64]{x}},
+		{first(variable.Tanh), []*variable.Variable[float64]{x}},
+		{first(variable.Pow(3.0)), []*variable.Variable[float64]{x}},
+		{first(variable.Square), []*variable.Variable[float64]{x}},
+		{first(variable.BroadcastTo[float64](2, 3)), []*variable.Variable[float64]{b}},
+		{first(variable.Sum[float64]()), []*variable.Variable[float64]{x}},
+		{first(variable.Sum[float64](1)), []*variable.Variable[float64]{x}},
+		{first(variable.SumTo[float64](1, 3)), []*variable.Variable[float64]{x}},
+		{first(variable.Mean[float64]()), []*variable.Variable[float64]{x}},
+		{first(variable.Mean[float64](0)), []*variable.Variable[float64]{x}},
+		{first(variable.Variance[float64]()), []*variable.Variable[float64]{x}},
+		{first(variable.Variance[float64](1)), []*variable.Variable[float64]{x}},
+		{first(variable.Max[float64]()), []*variable.Variable[float64]{x}},
+		{first(variable.Max[float64](1)), []*variable.Variable[float64]{x}},
+		{first(variable.Min[float64]()), []*variable.Variable[float64]{x}},
+		{first(variable.Min[float64](0)), []*variable.Variable[float64]{x}},
+		{first(variable.Clip(0.2, 0.8)), []*variable.Variable[float64]{x}},
+		{first(variable.Reshape[float64](3, 2)), []*variable.Variable[float64]{x}},
+		{first(variable.Transpose[float64](1, 0)), []*variable.Variable[float64]{x}},
+		{first(variable.Squeeze[float64](0)), []*variable.Variable[float64]{b}},
+		{first(variable.Unsqueeze[float64](1)), []*variable.Variable[float64]{x}},
+		{first(variable.Concat[float64](0)), []*variable.Variable[float64]{x, b}},
+		{variable.Split[float64]([]int{1, 2}, 1), []*variable.Variable[float64]{x}},
+		{first(variable.MatMul), []*variable.Variable[float64]{x, w}},
+		{first(variable.Einsum[float64]("ij,jk->ik")), []*variable.Variable[float64]{x, w}},
+		{first(variable.Einsum[float64]("ij,jk,ik->i")), []*variable.Variable[float64]{x, w, variable.Rand([]int{2, 2}, rand.Const(6))}},
+		{first(variable.GetItem[float64](1, []int{0, 2, 2})), []*variable.Variable[float64]{x}},
+		{first(variable.GetItemGrad[float64](0, []int{0, 0}, []int{3, 3})), []*variable.Variable[float64]{x}},
+		{first(variable.Slice[float64](tensor.All(), tensor.R(0, 3, 2))), []*variable.Variable[float64]{x}},
+		{first(variable.SliceGrad[float64]([]tensor.Range{tensor.All(), tensor.R(1, 4)}, []int{2, 5})), []*variable.Variable[float64]{x}},
+		{first(variable.Gather[float64](1, tensor.New([]int{2, 2}, []int{0, 2, 1, 1}))), []*variable.Variable[float64]{x}},
+		{first(variable.ScatterAdd[float64](1, tensor.New([]int{2, 2}, []int{0, 2, 1, 1}))), []*variable.Variable[float64]{x, variable.Rand([]int{2, 2}, rand.Const(7))}},
+		{first(variable.Inv), []*variable.Variable[float64]{spd(3, 3)}},
+		{first(variable.Det), []*variable.Variable[float64]{c}},
+		{first(variable.LogDet), []*variable.Variable[float64]{spd(2, 3, 3)}},
+		{first(variable.Solve), []*variable.Variable[float64]{spd(3, 3), w}},
+		{first(variable.Solve), []*variable.Variable[float64]{spd(2, 3, 3), w}},
+		{sym(first(variable.Cholesky)), []*variable.Variable[float64]{spd(3, 3)}},
+		{two(variable.QR), []*variable.Variable[float64]{c}},
+		{two(variable.QR), []*variable.Variable[float64]{w}},
+		{two(variable.QR), []*variable.Variable[float64]{x}},
+		{sym(two(variable.Eigh)), []*variable.Variable[float64]{spd(3, 3)}},
+		{three(variable.SVD), []*variable.Variable[float64]{c}},
+		{three(variable.SVD), []*variable.Variable[float64]{x}},
+		{three(variable.SVD), []*variable.Variable[float64]{w}},
+		{three(variable.LU), []*variable.Variable[float64]{c}},
+	}
+
+	for _, c := range cases {
+		jvpcheck(t, c.f, c.x...)
+	}
+}
+
+// jvpcheck compares the tangents of the outputs of f with numerical.Diff, which perturbs every element by the same step,
+// and with the reverse mode through <u, J * v> = <J^T * u, v> for random tangents v and weights u.
+func jvpcheck(t *testing.T, f func(x ...*variable.Variable[float64]) []*variable.Variable[float64], x ...*variable.Variable[float64]) {
+	t.Helper()
+
+	// numerical.Diff
+	ones := make([]*variable.Variable[float64], len(x))
+	for i := range x {
+		ones[i] = variable.Dual(x[i], variable.OneLike(x[i]))
+	}
+
+	y := f(ones...)
+	for i := range y {
+		yi := func(x ...*variable.Variable[float64]) *variable.Variable[float64] { return f(x...)[i] }
+		want := numerical.Diff(yi, x)
+		if !tensor.IsCloseAll(y[i].Tangent().Data, want.Data, 1e-6, 1e-5) {
+			t.Errorf("y[%d]: got=%v, want=%v", i, y[i].Tangent(), want)
+		}
+	}
+
+	// reverse mode
+	v, duals := make([]*variable.Variable[float64], len(x)), make([]*variable.Variable[float64], len(x))
+	for i := range x {
+		v[i] = variable.Randn(x[i].Shape(), rand.Const(uint64(10+i)))
+		duals[i] = variable.Dual(x[i], v[i])
+	}
+
+	var got, want float64
+	var loss *variable.Variable[float64]
+	y = f(duals...)
+	for i := range y {
+		u := variable.Randn(y[i].Shape(), rand.Const(uint64(20+i)))
+		got += tensor.Sum(tensor.Mul(u.Data, y[i].Tangent().Data)).At()
+
+		uy := variable.Sum[float64]()(variable.Mul(u, y[i]))
+		if loss == nil {
+			loss = uy
+			continue
+		}
+
+		loss = variable.Add(loss, uy)
+	}
+
+	loss.Backward()
+	for i := range x {
+		want += tensor.Sum(tensor.Mul(duals[i].Grad.Data, v[i].Data)).At()
+	}
+
+	if !tensor.IsCloseAll(tensor.Scalar(got), tensor.Scalar(want), 1e-6, 1e-6) {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+}
+
+type identity struct{}
+
+func (identity) Forward(x ...*variable.Variable[float64]) []*variable.Variable[float64]   { return x }
+func (identity) Backward(gy ...*variable.Variable[float64]) []*variable.Variable[float64] { return gy }
+
+func TestDual_unsupported(t *testing.T) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			return
+		}
+
+		t.Fail()
+	}()
+
+	x := variable.Dual(variable.New(1), variable.New(1))
+	(&variable.Function[float64]{Forwarder: identity{}}).First(x)
+}
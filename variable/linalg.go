@@ -0,0 +1,56 @@
+package variable
+
+import "github.com/itsubaki/autograd/tensor"
+
+// mT returns x with the last two axes swapped.
+func mT[T tensor.Float](x *Variable[T]) *Variable[T] {
+	return TransposeMatMul[T](x.NumDims())(x)
+}
+
+// eye returns an (n x n) identity matrix.
+func eye[T tensor.Float](n int) *Variable[T] {
+	return From(tensor.Eye[T](n))
+}
+
+// tril returns x with the elements above the k-th diagonal of the last two axes zeroed.
+func tril[T tensor.Float](x *Variable[T], k int) *Variable[T] {
+	rows, cols := x.Size(-2), x.Size(-1)
+	mask := tensor.Tril(tensor.Ones[T](rows, cols), k)
+	return Mul(x, From(mask))
+}
+
+// triu returns x with the elements below the k-th diagonal of the last two axes zeroed.
+func triu[T tensor.Float](x *Variable[T], k int) *Variable[T] {
+	return Sub(x, tril(x, k-1))
+}
+
+// diag returns the matrices with the last axis of x on their diagonal.
+func diag[T tensor.Float](x *Variable[T]) *Variable[T] {
+	return Mul(Unsqueeze[T](-2)(x), eye[T](x.Size(-1)))
+}
+
+// orZero returns gy with nil gradients replaced by zeros like y.
+func orZero[T tensor.Float](gy, y []*Variable[T]) []*Variable[T] {
+	out := make([]*Variable[T], len(y))
+	for i := range y {
+		if i < len(gy) && gy[i] != nil {
+			out[i] = gy[i]
+			continue
+		}
+
+		out[i] = ZeroLike(y[i])
+	}
+
+	return out
+}
+
+// diagonal returns the diagonals of the matrices in x.
+func diagonal[T tensor.Float](x *Variable[T]) *Variable[T] {
+	return Sum[T](x.NumDims() - 1)(Mul(x, eye[T](x.Size(-1))))
+}
+
+// trace returns the traces of the matrices in x.
+func trace[T tensor.Float](x *Variable[T]) *Variable[T] {
+	d := diagonal(x)
+	return Sum[T](d.NumDims() - 1)(d)
+}
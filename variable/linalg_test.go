@@ -0,0 +1,85 @@
+package variable_test
+
+import (
+	"testing"
+
+	"github.com/itsubaki/autograd/numerical"
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+// gradcheck compares the gradients of a random weighted sum of the outputs of f with the numerical gradients.
+func gradcheck(t *testing.T, f func(x ...*variable.Variable[float64]) []*variable.Variable[float64], x ...*variable.Variable[float64]) {
+	t.Helper()
+
+	y := f(x...)
+	w := make([]*variable.Variable[float64], len(y))
+	for i := range y {
+		w[i] = variable.Randn(y[i].Shape(), rand.Const(uint64(i)))
+	}
+
+	loss := func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+		y := f(x...)
+
+		sum := variable.Sum[float64]()(variable.Mul(y[0], w[0]))
+		for i := 1; i < len(y); i++ {
+			sum = variable.Add(sum, variable.Sum[float64]()(variable.Mul(y[i], w[i])))
+		}
+
+		return sum
+	}
+
+	loss(x...).Backward()
+	want := numerical.Grad(loss, x)
+	for i := range x {
+		if !tensor.IsCloseAll(x[i].Grad.Data, want[i].Data, 1e-6, 1e-5) {
+			t.Errorf("x[%d]: got=%v, want=%v", i, x[i].Grad.Data.Data, want[i].Data.Data)
+		}
+	}
+}
+
+// first returns f as a function with a single output.
+func first(f func(x ...*variable.Variable[float64]) *variable.Variable[float64]) func(x ...*variable.Variable[float64]) []*variable.Variable[float64] {
+	return func(x ...*variable.Variable[float64]) []*variable.Variable[float64] {
+		return []*variable.Variable[float64]{f(x...)}
+	}
+}
+
+func two(f func(x ...*variable.Variable[float64]) (*variable.Variable[float64], *variable.Variable[float64])) func(x ...*variable.Variable[float64]) []*variable.Variable[float64] {
+	return func(x ...*variable.Variable[float64]) []*variable.Variable[float64] {
+		y0, y1 := f(x...)
+		return []*variable.Variable[float64]{y0, y1}
+	}
+}
+
+func three(f func(x ...*variable.Variable[float64]) (*variable.Variable[float64], *variable.Variable[float64], *variable.Variable[float64])) func(x ...*variable.Variable[float64]) []*variable.Variable[float64] {
+	return func(x ...*variable.Variable[float64]) []*variable.Variable[float64] {
+		y0, y1, y2 := f(x...)
+		return []*variable.Variable[float64]{y0, y1, y2}
+	}
+}
+
+// sym returns f applied to the symmetric part of x[0].
+func sym(f func(x ...*variable.Variable[float64]) []*variable.Variable[float64]) func(x ...*variable.Variable[float64]) []*variable.Variable[float64] {
+	return func(x ...*variable.Variable[float64]) []*variable.Variable[float64] {
+		xt := variable.TransposeMatMul[float64](x[0].NumDims())(x[0])
+		return f(variable.MulC(0.5, variable.Add(x[0], xt)))
+	}
+}
+
+// spd returns a random symmetric positive definite matrix with the given shape.
+func spd(shape ...int) *variable.Variable[float64] {
+	a := tensor.Rand(shape, rand.Const())
+	n := shape[len(shape)-1]
+
+	axes := make([]int, len(shape))
+	for i := range axes {
+		axes[i] = i
+	}
+	axes[len(axes)-2], axes[len(axes)-1] = axes[len(axes)-1], axes[len(axes)-2]
+
+	// a * a^T + n * I
+	aat := tensor.MatMul(a, tensor.Transpose(a, axes...))
+	return variable.From(tensor.Add(aat, tensor.MulC(float64(n), tensor.Eye[float64](n))))
+}
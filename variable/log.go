@@ -1,30 +1,50 @@
 package variable
 
-import "github.com/itsubaki/autograd/tensor"
+import (
+	"math"
+
+	"github.com/itsubaki/autograd/tensor"
+)
 
 // Log applies the natural logarithm.
-func Log(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &LogT{},
+func Log[T tensor.Float](x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &LogT[T]{},
 	}).First(x...)
 }
 
 // LogT is the differentiable logarithm operation.
-type LogT struct {
-	x *Variable
+type LogT[T tensor.Float] struct {
+	x *Variable[T]
 }
 
-func (f *LogT) Forward(x ...*Variable) []*Variable {
+func (f *LogT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.x = x[0]
 
 	y := tensor.Log(x[0].Data)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *LogT) Backward(gy ...*Variable) []*Variable {
-	return []*Variable{
+func (f *LogT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
 		Div(gy[0], f.x), // gy / x
 	}
 }
+
+func (f *LogT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Div(tx[0], x[0]), // tx / x
+	}
+}
+
+func (f *LogT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Log(x[0]),
+	}
+}
+
+func (f *LogT[T]) Scalar() (func(x T) T, func(x T) T) {
+	return scalar[T](math.Log), func(x T) T { return 1.0 / x }
+}
@@ -8,7 +8,7 @@ import (
 
 func ExampleLogT() {
 	x := variable.New(1, 2, 3, 4, 5)
-	f := variable.LogT{}
+	f := variable.LogT[float64]{}
 
 	fmt.Println(x)
 	fmt.Println(f.Forward(x))
@@ -0,0 +1,49 @@
+package variable
+
+import (
+	"github.com/itsubaki/autograd/linalg"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// LogDet returns a variable representing the natural logarithm of the absolute value of the determinant of x[0].
+// The sign of the determinant is available from linalg.LogDet.
+func LogDet[T tensor.Float](x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &LogDetT[T]{},
+	}).First(x...)
+}
+
+// LogDetT is the differentiable log-determinant operation.
+type LogDetT[T tensor.Float] struct {
+	x *Variable[T]
+}
+
+func (f *LogDetT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	f.x = x[0]
+
+	_, y := linalg.LogDet(x[0].Data)
+	return []*Variable[T]{
+		From(y),
+	}
+}
+
+func (f *LogDetT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	// gy * inv(x)^T
+	g := Unsqueeze[T](-1)(Unsqueeze[T](-1)(gy[0]))
+	return []*Variable[T]{
+		Mul(g, mT(Inv(f.x))),
+	}
+}
+
+func (f *LogDetT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		trace(Solve(x[0], tx[0])), // tr(x^-1 * tx)
+	}
+}
+
+func (f *LogDetT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		LogDet(x[0]),
+	}
+}
@@ -0,0 +1,39 @@
+package variable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleLogDet() {
+	x := variable.New(
+		2, 0,
+		0, 3,
+	).Reshape(2, 2)
+
+	y := variable.LogDet(x)
+	y.Backward()
+
+	fmt.Printf("%.4f\n", y.Data.Data)
+	fmt.Printf("%.4f\n", x.Grad.Data.Data)
+
+	// Output:
+	// [1.7918]
+	// [0.5000 0.0000 0.0000 0.3333]
+}
+
+func TestLogDet(t *testing.T) {
+	cases := []struct {
+		x *variable.Variable[float64]
+	}{
+		{spd(3, 3)},
+		{variable.Rand([]int{2, 3, 3}, rand.Const())},
+	}
+
+	for _, c := range cases {
+		gradcheck(t, first(variable.LogDet), c.x)
+	}
+}
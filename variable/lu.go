@@ -0,0 +1,68 @@
+package variable
+
+import (
+	"github.com/itsubaki/autograd/linalg"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// LU returns the LU decomposition of x[0] with partial pivoting, such that x[0] = p * l * u.
+// p is the permutation matrix, l is the unit lower triangular matrix and u is the upper triangular matrix.
+// x[0] must be non-singular for the gradient.
+func LU[T tensor.Float](x ...*Variable[T]) (p, l, u *Variable[T]) {
+	y := (&Function[T]{
+		Forwarder: &LUT[T]{},
+	}).Forward(x...)
+
+	return y[0], y[1], y[2]
+}
+
+// LUT is the differentiable LU decomposition.
+type LUT[T tensor.Float] struct {
+	y []*Variable[T]
+}
+
+func (f *LUT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	p, l, u := linalg.LU(x[0].Data)
+	f.y = []*Variable[T]{
+		From(p),
+		From(l),
+		From(u),
+	}
+
+	return f.y
+}
+
+func (f *LUT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	// gx = p * l^-T * (tril(l^T * gl, -1) + triu(gu * u^T, 0)) * u^-T
+	// p is piecewise constant, so its gradient is ignored.
+	g := orZero(gy, f.y)
+	p, l, u, gl, gu := f.y[0], f.y[1], f.y[2], g[1], g[2]
+
+	inner := Add(tril(MatMul(mT(l), gl), -1), triu(MatMul(gu, mT(u)), 0))
+	return []*Variable[T]{
+		MatMul(MatMul(MatMul(p, mT(Inv(l))), inner), mT(Inv(u))),
+	}
+}
+
+func (f *LUT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	// m = l^-1 * p^T * tx * u^-1
+	// tl = l * tril(m, -1), tu = triu(m, 0) * u
+	p, l, u := y[0], y[1], y[2]
+	m := MatMul(MatMul(Inv(l), MatMul(mT(p), tx[0])), Inv(u))
+
+	return []*Variable[T]{
+		ZeroLike(p),
+		MatMul(l, tril(m, -1)),
+		MatMul(triu(m, 0), u),
+	}
+}
+
+func (f *LUT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	p, l, u := LU(x[0])
+	return []*Variable[T]{
+		p,
+		l,
+		u,
+	}
+}
@@ -0,0 +1,43 @@
+package variable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleLU() {
+	x := variable.New(
+		1, 2,
+		3, 4,
+	).Reshape(2, 2)
+
+	p, l, u := variable.LU(x)
+	u.Backward()
+
+	fmt.Printf("%.4f\n", p.Data.Data)
+	fmt.Printf("%.4f\n", l.Data.Data)
+	fmt.Printf("%.4f\n", u.Data.Data)
+	fmt.Printf("%.4f\n", x.Grad.Data.Data)
+
+	// Output:
+	// [0.0000 1.0000 1.0000 0.0000]
+	// [1.0000 0.0000 0.3333 1.0000]
+	// [3.0000 4.0000 0.0000 0.6667]
+	// [-1.3333 1.0000 1.4444 0.6667]
+}
+
+func TestLU(t *testing.T) {
+	cases := []struct {
+		x *variable.Variable[float64]
+	}{
+		{variable.Rand([]int{3, 3}, rand.Const())},
+		{variable.Rand([]int{2, 4, 4}, rand.Const())},
+	}
+
+	for _, c := range cases {
+		gradcheck(t, three(variable.LU), c.x)
+	}
+}
@@ -3,40 +3,52 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // MatMul returns a variable representing the matrix product of x[0] and x[1].
-func MatMul(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &MatMulT{},
+func MatMul[T tensor.Float](x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &MatMulT[T]{},
 	}).First(x...)
 }
 
 // MatMulT is the differentiable matrix multiplication operation.
-type MatMulT struct {
-	x, w *Variable
+type MatMulT[T tensor.Float] struct {
+	x, w *Variable[T]
 }
 
-func (f *MatMulT) Forward(x ...*Variable) []*Variable {
+func (f *MatMulT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.x, f.w = x[0], x[1]
 
 	y := tensor.MatMul(x[0].Data, x[1].Data)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *MatMulT) Backward(gy ...*Variable) []*Variable {
-	gx := MatMul(gy[0], TransposeMatMul(f.w.NumDims())(f.w)) // gy * w.T
-	gw := MatMul(TransposeMatMul(f.x.NumDims())(f.x), gy[0]) // x.T * gy
+func (f *MatMulT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	gx := MatMul(gy[0], TransposeMatMul[T](f.w.NumDims())(f.w)) // gy * w.T
+	gw := MatMul(TransposeMatMul[T](f.x.NumDims())(f.x), gy[0]) // x.T * gy
 
 	if !tensor.SliceEqual(gx.Shape(), f.x.Shape()) {
-		gx = SumTo(f.x.Shape()...)(gx)
+		gx = SumTo[T](f.x.Shape()...)(gx)
 	}
 
 	if !tensor.SliceEqual(gw.Shape(), f.w.Shape()) {
-		gw = SumTo(f.w.Shape()...)(gw)
+		gw = SumTo[T](f.w.Shape()...)(gw)
 	}
 
-	return []*Variable{
+	return []*Variable[T]{
 		gx,
 		gw,
 	}
 }
+
+func (f *MatMulT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Add(MatMul(tx[0], x[1]), MatMul(x[0], tx[1])), // tx * w + x * tw
+	}
+}
+
+func (f *MatMulT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		MatMul(align(x, batched)...),
+	}
+}
@@ -67,10 +67,10 @@ func ExampleMatMul_double() {
 
 func TestMatMul(t *testing.T) {
 	cases := []struct {
-		x, w *variable.Variable
-		y    *variable.Variable
-		gx   *variable.Variable
-		gw   *variable.Variable
+		x, w *variable.Variable[float64]
+		y    *variable.Variable[float64]
+		gx   *variable.Variable[float64]
+		gw   *variable.Variable[float64]
 	}{
 		{
 			x: variable.New(
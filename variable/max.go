@@ -3,33 +3,33 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // Max returns a function that computes the maximum of x[0] along the given axes.
-func Max(axes ...int) func(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &MaxT{
+func Max[T tensor.Float](axes ...int) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &MaxT[T]{
 			Axes: axes,
 		},
 	}).First
 }
 
 // MaxT is the differentiable max operation.
-type MaxT struct {
+type MaxT[T tensor.Float] struct {
 	Axes []int
-	x, y *Variable
+	x, y *Variable[T]
 }
 
-func (f *MaxT) Forward(x ...*Variable) []*Variable {
+func (f *MaxT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.x = x[0]
 
 	f.y = From(tensor.Max(x[0].Data, f.Axes...))
-	return []*Variable{
+	return []*Variable[T]{
 		f.y,
 	}
 }
 
-func (f *MaxT) Backward(gy ...*Variable) []*Variable {
+func (f *MaxT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
 	if len(f.Axes) == 0 {
 		mask := isClose(f.x.Data, f.y.Data)
-		return []*Variable{
+		return []*Variable[T]{
 			Mul(gy[0], From(mask)),
 		}
 	}
@@ -42,13 +42,42 @@ func (f *MaxT) Backward(gy ...*Variable) []*Variable {
 	mask := isClose(f.x.Data, y)
 
 	// broadcast
-	gy0 := Reshape(shape...)(gy[0])
-	bgy := BroadcastTo(mask.Shape...)(gy0)
-	return []*Variable{
+	gy0 := Reshape[T](shape...)(gy[0])
+	bgy := BroadcastTo[T](mask.Shape...)(gy0)
+	return []*Variable[T]{
 		Mul(bgy, From(mask)),
 	}
 }
 
-func isClose(a, b *tensor.Tensor[float64]) *tensor.Tensor[float64] {
-	return tensor.Float64(tensor.IsClose(a, b))
+func (f *MaxT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		extremumJVP(f.Axes, x[0], y[0], tx[0]),
+	}
+}
+
+func (f *MaxT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	axes := shiftAxes(f.Axes, rank(x[0], true))
+	if len(axes) == 0 {
+		// the examples are scalars
+		return (&MaxT[T]{}).Batch([]*Variable[T]{Unsqueeze[T](1)(x[0])}, batched)
+	}
+
+	return []*Variable[T]{
+		Max[T](axes...)(x[0]),
+	}
+}
+
+func isClose[T tensor.Float](a, b *tensor.Tensor[T]) *tensor.Tensor[T] {
+	return tensor.F(tensor.IsClose(a, b), func(v int) T { return T(v) })
+}
+
+// extremumJVP returns the tangent of y = max(x, axes) or min(x, axes), which is the sum of tx where x equals y.
+func extremumJVP[T tensor.Float](axes []int, x, y, tx *Variable[T]) *Variable[T] {
+	yd := y.Data
+	if len(axes) > 0 {
+		yd = tensor.Reshape(yd, tensor.KeepDims(x.Shape(), axes)...)
+	}
+
+	mask := isClose(x.Data, yd)
+	return Reshape[T](y.Shape()...)(Sum[T](axes...)(Mul(tx, From(mask))))
 }
@@ -12,7 +12,7 @@ func ExampleMax() {
 		4, 10, 6,
 	).Reshape(2, 3)
 
-	y := variable.Max()(x)
+	y := variable.Max[float64]()(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -29,7 +29,7 @@ func ExampleMax_axis01() {
 		4, 10, 6,
 	).Reshape(2, 3)
 
-	y := variable.Max(0, 1)(x)
+	y := variable.Max[float64](0, 1)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -46,7 +46,7 @@ func ExampleMax_axis0() {
 		4, 10, 6,
 	).Reshape(2, 3)
 
-	y := variable.Max(0)(x)
+	y := variable.Max[float64](0)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -63,7 +63,7 @@ func ExampleMax_axis1() {
 		4, 10, 6,
 	).Reshape(2, 3)
 
-	y := variable.Max(1)(x)
+	y := variable.Max[float64](1)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -80,7 +80,7 @@ func ExampleMax_axis21n() {
 		4, 10, 6,
 	).Reshape(2, 3)
 
-	y := variable.Max(-2, -1)(x)
+	y := variable.Max[float64](-2, -1)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -97,7 +97,7 @@ func ExampleMax_double() {
 		4, 10, 6,
 	).Reshape(2, 3)
 
-	y := variable.Max()(x)
+	y := variable.Max[float64]()(x)
 	y.Backward(variable.Opts{CreateGraph: true})
 	fmt.Println(y)
 	fmt.Println(x.Grad)
@@ -3,34 +3,34 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // Mean returns a function that computes the mean of x[0] along the given axes.
-func Mean(axes ...int) func(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &MeanT{
+func Mean[T tensor.Float](axes ...int) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &MeanT[T]{
 			Axes: axes,
 		},
 	}).First
 }
 
 // MeanT is the differentiable mean operation.
-type MeanT struct {
+type MeanT[T tensor.Float] struct {
 	Axes []int
-	x    *Variable
+	x    *Variable[T]
 }
 
-func (f *MeanT) Forward(x ...*Variable) []*Variable {
+func (f *MeanT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.x = x[0]
 
-	return []*Variable{
+	return []*Variable[T]{
 		From(tensor.Mean(x[0].Data, f.Axes...)),
 	}
 }
 
-func (f *MeanT) Backward(gy ...*Variable) []*Variable {
+func (f *MeanT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
 	if len(f.Axes) == 0 {
 		size := f.x.Data.Size()
-		bgy := BroadcastTo(f.x.Shape()...)(gy[0])
-		return []*Variable{
-			MulC(1/float64(size), bgy),
+		bgy := BroadcastTo[T](f.x.Shape()...)(gy[0])
+		return []*Variable[T]{
+			MulC(1/T(size), bgy),
 		}
 	}
 
@@ -43,9 +43,27 @@ func (f *MeanT) Backward(gy ...*Variable) []*Variable {
 
 	// gx = 1/N * gy
 	reshape := tensor.KeepDims(shape, f.Axes)
-	gy0 := Reshape(reshape...)(gy[0])
-	bgy := BroadcastTo(shape...)(gy0)
-	return []*Variable{
-		MulC(1/float64(size), bgy),
+	gy0 := Reshape[T](reshape...)(gy[0])
+	bgy := BroadcastTo[T](shape...)(gy0)
+	return []*Variable[T]{
+		MulC(1/T(size), bgy),
+	}
+}
+
+func (f *MeanT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Mean[T](f.Axes...)(tx[0]),
+	}
+}
+
+func (f *MeanT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	axes := shiftAxes(f.Axes, rank(x[0], true))
+	if len(axes) == 0 {
+		// the examples are scalars
+		return (&MeanT[T]{}).Batch([]*Variable[T]{Unsqueeze[T](1)(x[0])}, batched)
+	}
+
+	return []*Variable[T]{
+		Mean[T](axes...)(x[0]),
 	}
 }
@@ -12,11 +12,11 @@ func ExampleMean() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Mean()(x)
+	y := variable.Mean[float64]()(x)
 	y.Backward()
 
 	fmt.Println(y)
-	fmt.Println(variable.Clip(0, 0.1666)(x.Grad))
+	fmt.Println(variable.Clip(0.0, 0.1666)(x.Grad))
 
 	// Output:
 	// variable(3.5)
@@ -29,11 +29,11 @@ func ExampleMean_axis01() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Mean(0, 1)(x)
+	y := variable.Mean[float64](0, 1)(x)
 	y.Backward()
 
 	fmt.Println(y)
-	fmt.Println(variable.Clip(0, 0.1666)(x.Grad))
+	fmt.Println(variable.Clip(0.0, 0.1666)(x.Grad))
 
 	// Output:
 	// variable(3.5)
@@ -46,7 +46,7 @@ func ExampleMean_axis0() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Mean(0)(x)
+	y := variable.Mean[float64](0)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -63,11 +63,11 @@ func ExampleMean_axis1() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Mean(1)(x)
+	y := variable.Mean[float64](1)(x)
 	y.Backward()
 
 	fmt.Println(y)
-	fmt.Println(variable.Clip(0, 0.3333)(x.Grad))
+	fmt.Println(variable.Clip(0.0, 0.3333)(x.Grad))
 
 	// Output:
 	// variable[2]([2 5])
@@ -80,7 +80,7 @@ func ExampleMean_double() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Mean(0, 1)(x)
+	y := variable.Mean[float64](0, 1)(x)
 	y.Backward(variable.Opts{CreateGraph: true})
 	fmt.Println(y)
 
@@ -3,33 +3,33 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // Min returns a function that computes the minimum of x[0] along the given axes.
-func Min(axes ...int) func(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &MinT{
+func Min[T tensor.Float](axes ...int) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &MinT[T]{
 			Axes: axes,
 		},
 	}).First
 }
 
 // MinT is the differentiable min operation.
-type MinT struct {
+type MinT[T tensor.Float] struct {
 	Axes []int
-	x, y *Variable
+	x, y *Variable[T]
 }
 
-func (f *MinT) Forward(x ...*Variable) []*Variable {
+func (f *MinT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.x = x[0]
 
 	f.y = From(tensor.Min(x[0].Data, f.Axes...))
-	return []*Variable{
+	return []*Variable[T]{
 		f.y,
 	}
 }
 
-func (f *MinT) Backward(gy ...*Variable) []*Variable {
+func (f *MinT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
 	if len(f.Axes) == 0 {
 		mask := isClose(f.x.Data, f.y.Data)
-		return []*Variable{
+		return []*Variable[T]{
 			Mul(gy[0], From(mask)),
 		}
 	}
@@ -42,9 +42,27 @@ func (f *MinT) Backward(gy ...*Variable) []*Variable {
 	mask := isClose(f.x.Data, y)
 
 	// broadcast
-	gy0 := Reshape(shape...)(gy[0])
-	bgy := BroadcastTo(mask.Shape...)(gy0)
-	return []*Variable{
+	gy0 := Reshape[T](shape...)(gy[0])
+	bgy := BroadcastTo[T](mask.Shape...)(gy0)
+	return []*Variable[T]{
 		Mul(bgy, From(mask)),
 	}
 }
+
+func (f *MinT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		extremumJVP(f.Axes, x[0], y[0], tx[0]),
+	}
+}
+
+func (f *MinT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	axes := shiftAxes(f.Axes, rank(x[0], true))
+	if len(axes) == 0 {
+		// the examples are scalars
+		return (&MinT[T]{}).Batch([]*Variable[T]{Unsqueeze[T](1)(x[0])}, batched)
+	}
+
+	return []*Variable[T]{
+		Min[T](axes...)(x[0]),
+	}
+}
@@ -12,7 +12,7 @@ func ExampleMin() {
 		4, -5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Min()(x)
+	y := variable.Min[float64]()(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -29,7 +29,7 @@ func ExampleMin_axis01() {
 		4, -5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Min(0, 1)(x)
+	y := variable.Min[float64](0, 1)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -46,7 +46,7 @@ func ExampleMin_axis0() {
 		4, -5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Min(0)(x)
+	y := variable.Min[float64](0)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -63,7 +63,7 @@ func ExampleMin_axis1() {
 		4, -5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Min(1)(x)
+	y := variable.Min[float64](1)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -80,7 +80,7 @@ func ExampleMin_axis21n() {
 		4, -5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Min(-2, -1)(x)
+	y := variable.Min[float64](-2, -1)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -97,7 +97,7 @@ func ExampleMin_double() {
 		4, -5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Min()(x)
+	y := variable.Min[float64]()(x)
 	y.Backward(variable.Opts{CreateGraph: true})
 	fmt.Println(y)
 	fmt.Println(x.Grad)
@@ -3,44 +3,57 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // MulC returns a variable representing c * x[0].
-func MulC(c float64, x ...*Variable) *Variable {
-	return (&Function{Forwarder: &MulT{}}).First(New(c), x[0])
+// c is a constant that does not require gradients.
+func MulC[T tensor.Float](c T, x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{Forwarder: &MulT[T]{}}).First(constant(c), x[0])
 }
 
 // Mul returns a variable representing x[0] * x[1].
-func Mul(x ...*Variable) *Variable {
-	return (&Function{Forwarder: &MulT{}}).First(x...)
+func Mul[T tensor.Float](x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{Forwarder: &MulT[T]{}}).First(x...)
 }
 
 // MulT is the differentiable multiplication operation.
-type MulT struct {
-	x0, x1           *Variable
+type MulT[T tensor.Float] struct {
+	x0, x1           *Variable[T]
 	x0Shape, x1Shape []int
 }
 
-func (f *MulT) Forward(x ...*Variable) []*Variable {
+func (f *MulT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.x0, f.x1 = x[0], x[1]
 	f.x0Shape, f.x1Shape = x[0].Shape(), x[1].Shape()
 
 	y := tensor.Mul(x[0].Data, x[1].Data)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *MulT) Backward(gy ...*Variable) []*Variable {
+func (f *MulT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
 	gx0 := Mul(gy[0], f.x1) // gy * x1
 	gx1 := Mul(gy[0], f.x0) // gy * x0
 
 	if tensor.SliceEqual(f.x0Shape, f.x1Shape) {
-		return []*Variable{
+		return []*Variable[T]{
 			gx0,
 			gx1,
 		}
 	}
 
-	return []*Variable{
-		SumTo(f.x0Shape...)(gx0),
-		SumTo(f.x1Shape...)(gx1),
+	return []*Variable[T]{
+		SumTo[T](f.x0Shape...)(gx0),
+		SumTo[T](f.x1Shape...)(gx1),
+	}
+}
+
+func (f *MulT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Add(Mul(tx[0], x[1]), Mul(x[0], tx[1])), // tx0 * x1 + x0 * tx1
+	}
+}
+
+func (f *MulT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Mul(align(x, batched)...),
 	}
 }
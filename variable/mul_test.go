@@ -9,7 +9,7 @@ import (
 func ExampleMulT() {
 	a := variable.New(3.0)
 	b := variable.New(2.0)
-	f := variable.MulT{}
+	f := variable.MulT[float64]{}
 
 	fmt.Println(a)
 	fmt.Println(b)
@@ -3,24 +3,40 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // Neg returns a variable representing -x[0].
-func Neg(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &NegT{},
+func Neg[T tensor.Float](x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &NegT[T]{},
 	}).First(x...)
 }
 
 // NegT is the differentiable negation operation.
-type NegT struct{}
+type NegT[T tensor.Float] struct{}
 
-func (f *NegT) Forward(x ...*Variable) []*Variable {
+func (f *NegT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	y := tensor.MulC(-1.0, x[0].Data)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *NegT) Backward(gy ...*Variable) []*Variable {
-	return []*Variable{
+func (f *NegT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
 		Neg(gy[0]),
 	}
 }
+
+func (f *NegT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Neg(tx[0]),
+	}
+}
+
+func (f *NegT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Neg(x[0]),
+	}
+}
+
+func (f *NegT[T]) Scalar() (func(x T) T, func(x T) T) {
+	return func(x T) T { return -1.0 * x }, func(x T) T { return -1.0 }
+}
@@ -1,33 +1,53 @@
 package variable
 
-import "github.com/itsubaki/autograd/tensor"
+import (
+	"math"
+
+	"github.com/itsubaki/autograd/tensor"
+)
 
 // Pow returns a function that raises x[0] to the given power.
-func Pow(p float64) func(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &PowT{
+func Pow[T tensor.Float](p T) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &PowT[T]{
 			P: p,
 		},
 	}).First
 }
 
 // PowT is the differentiable power operation.
-type PowT struct {
-	P float64
-	x *Variable
+type PowT[T tensor.Float] struct {
+	P T
+	x *Variable[T]
 }
 
-func (f *PowT) Forward(x ...*Variable) []*Variable {
+func (f *PowT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.x = x[0]
 
 	y := tensor.Pow(f.P, x[0].Data)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *PowT) Backward(gy ...*Variable) []*Variable {
-	return []*Variable{
-		Mul(gy[0], MulC(f.P, Pow(f.P-1)(f.x))), // gy * c * x^(c-1)
+func (f *PowT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Mul(gy[0], MulC(f.P, Pow[T](f.P-1)(f.x))), // gy * c * x^(c-1)
 	}
 }
+
+func (f *PowT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Mul(tx[0], MulC(f.P, Pow[T](f.P-1)(x[0]))), // tx * c * x^(c-1)
+	}
+}
+
+func (f *PowT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Pow[T](f.P)(x[0]),
+	}
+}
+
+func (f *PowT[T]) Scalar() (func(x T) T, func(x T) T) {
+	return func(x T) T { return T(math.Pow(float64(x), float64(f.P))) }, func(x T) T { return f.P * T(math.Pow(float64(x), float64(f.P-1))) }
+}
@@ -0,0 +1,112 @@
+package variable
+
+import (
+	"github.com/itsubaki/autograd/linalg"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// QR returns the reduced QR decomposition of x[0], such that x[0] = q * r.
+// The leading min(m, n) columns of x[0] must be linearly independent for the gradient.
+func QR[T tensor.Float](x ...*Variable[T]) (q, r *Variable[T]) {
+	y := (&Function[T]{
+		Forwarder: &QRT[T]{},
+	}).Forward(x...)
+
+	return y[0], y[1]
+}
+
+// QRT is the differentiable QR decomposition.
+type QRT[T tensor.Float] struct {
+	x *Variable[T]
+	y []*Variable[T]
+}
+
+func (f *QRT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	f.x = x[0]
+
+	q, r := linalg.QR(x[0].Data)
+	f.y = []*Variable[T]{
+		From(q),
+		From(r),
+	}
+
+	return f.y
+}
+
+func (f *QRT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	g := orZero(gy, f.y)
+	q, r, gq, gr := f.y[0], f.y[1], g[0], g[1]
+
+	m, n := f.x.Size(-2), f.x.Size(-1)
+	if m >= n {
+		return []*Variable[T]{
+			qrGrad(q, r, gq, gr),
+		}
+	}
+
+	// x = [x0, x1] = q * [r0, r1], where r0 is square
+	axis, size := f.x.NumDims()-1, []int{m, n - m}
+	x1 := Split[T](size, axis)(f.x)[1]
+	rs, grs := Split[T](size, axis)(r), Split[T](size, axis)(gr)
+
+	gx0 := qrGrad(q, rs[0], Add(gq, MatMul(x1, mT(grs[1]))), grs[0])
+	gx1 := MatMul(q, grs[1])
+
+	return []*Variable[T]{
+		Concat[T](axis)(gx0, gx1),
+	}
+}
+
+func (f *QRT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	q, r := y[0], y[1]
+
+	m, n := x[0].Size(-2), x[0].Size(-1)
+	if m >= n {
+		tq, tr := qrJVP(q, r, tx[0])
+		return []*Variable[T]{
+			tq,
+			tr,
+		}
+	}
+
+	// x = [x0, x1] = q * [r0, r1], where r0 is square
+	axis, size := x[0].NumDims()-1, []int{m, n - m}
+	txs, rs := Split[T](size, axis)(tx[0]), Split[T](size, axis)(r)
+
+	tq, tr0 := qrJVP(q, rs[0], txs[0])
+	tr1 := MatMul(mT(q), Sub(txs[1], MatMul(tq, rs[1]))) // q^T * (tx1 - tq * r1)
+
+	return []*Variable[T]{
+		tq,
+		Concat[T](axis)(tr0, tr1),
+	}
+}
+
+func (f *QRT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	q, r := QR(x[0])
+	return []*Variable[T]{
+		q,
+		r,
+	}
+}
+
+// qrGrad returns the gradient of x = q * r for m >= n.
+// gx = (gq + q * copyltu(m)) * r^-T, where m = r * gr^T - gq^T * q.
+func qrGrad[T tensor.Float](q, r, gq, gr *Variable[T]) *Variable[T] {
+	m := Sub(MatMul(r, mT(gr)), MatMul(mT(gq), q))
+	copyltu := Add(tril(m, 0), mT(tril(m, -1)))
+	return MatMul(Add(gq, MatMul(q, copyltu)), mT(Inv(r)))
+}
+
+// qrJVP returns the tangents of q and r of x = q * r for m >= n.
+// m = q^T * tx * r^-1, omega = tril(m, -1) - tril(m, -1)^T,
+// tr = (m - omega) * r and tq = tx * r^-1 - q * (m - omega).
+func qrJVP[T tensor.Float](q, r, tx *Variable[T]) (*Variable[T], *Variable[T]) {
+	rinv := Inv(r)
+	m := MatMul(MatMul(mT(q), tx), rinv)
+	l := tril(m, -1)
+	upper := Sub(m, Sub(l, mT(l)))
+
+	return Sub(MatMul(tx, rinv), MatMul(q, upper)), MatMul(upper, r)
+}
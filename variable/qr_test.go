@@ -0,0 +1,43 @@
+package variable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleQR() {
+	x := variable.New(
+		3, 1,
+		4, 2,
+	).Reshape(2, 2)
+
+	q, r := variable.QR(x)
+	r.Backward()
+
+	fmt.Printf("%.4f\n", q.Data.Data)
+	fmt.Printf("%.4f\n", r.Data.Data)
+	fmt.Printf("%.4f\n", x.Grad.Data.Data)
+
+	// Output:
+	// [0.6000 -0.8000 0.8000 0.6000]
+	// [5.0000 2.2000 0.0000 0.4000]
+	// [0.8880 -0.2000 0.5840 1.4000]
+}
+
+func TestQR(t *testing.T) {
+	cases := []struct {
+		x *variable.Variable[float64]
+	}{
+		{variable.Rand([]int{3, 3}, rand.Const())},
+		{variable.Rand([]int{4, 2}, rand.Const())},
+		{variable.Rand([]int{2, 4}, rand.Const())},
+		{variable.Rand([]int{2, 3, 2}, rand.Const())},
+	}
+
+	for _, c := range cases {
+		gradcheck(t, two(variable.QR), c.x)
+	}
+}
@@ -0,0 +1,74 @@
+package variable
+
+import (
+	"container/heap"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// Queue is a priority queue of the functions to backpropagate.
+// Pop returns the function of the largest generation, so that a function is popped after the functions that use its outputs.
+// Functions of the same generation are popped in the reverse order they are pushed.
+// The zero value is an empty queue.
+type Queue[T tensor.Float] struct {
+	h    funcs[T]
+	seen map[*Function[T]]bool
+	n    int
+}
+
+// Push adds f to the queue if it has not been pushed before.
+func (q *Queue[T]) Push(f *Function[T]) {
+	if q.seen == nil {
+		q.seen = make(map[*Function[T]]bool)
+	}
+
+	if q.seen[f] {
+		return
+	}
+
+	q.seen[f] = true
+	heap.Push(&q.h, entry[T]{f: f, seq: q.n})
+	q.n++
+}
+
+// Pop removes and returns the function of the largest generation.
+func (q *Queue[T]) Pop() *Function[T] {
+	return heap.Pop(&q.h).(entry[T]).f
+}
+
+// Len returns the number of functions in the queue.
+func (q *Queue[T]) Len() int {
+	return len(q.h)
+}
+
+// entry is a function in the queue and the order it is pushed.
+type entry[T tensor.Float] struct {
+	f   *Function[T]
+	seq int
+}
+
+// funcs is a max-heap of functions ordered by generation and then by the order they are pushed.
+type funcs[T tensor.Float] []entry[T]
+
+func (h funcs[T]) Len() int { return len(h) }
+
+func (h funcs[T]) Less(i, j int) bool {
+	if h[i].f.Generation != h[j].f.Generation {
+		return h[i].f.Generation > h[j].f.Generation
+	}
+
+	return h[i].seq > h[j].seq
+}
+
+func (h funcs[T]) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *funcs[T]) Push(x any) { *h = append(*h, x.(entry[T])) }
+
+func (h *funcs[T]) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = entry[T]{}
+	*h = old[:n-1]
+	return x
+}
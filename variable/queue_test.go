@@ -0,0 +1,158 @@
+package variable_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"testing"
+
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleQueue() {
+	x := variable.New(1)
+	y := variable.Sin(x)
+	z := variable.Exp(y)
+	w := variable.Add(z, y)
+
+	var q variable.Queue[float64]
+	q.Push(z.Creator)
+	q.Push(w.Creator)
+	q.Push(y.Creator)
+	q.Push(w.Creator)
+
+	for q.Len() > 0 {
+		f := q.Pop()
+		fmt.Printf("%T %d\n", f.Forwarder, f.Generation)
+	}
+
+	// Output:
+	// *variable.AddT[float64] 2
+	// *variable.ExpT[float64] 1
+	// *variable.SinT[float64] 0
+}
+
+func TestQueue(t *testing.T) {
+	x := variable.New(1)
+	f := make([]*variable.Function[float64], 6)
+	for i := range f {
+		f[i] = variable.Sin(x).Creator
+	}
+
+	// the same generation
+	var q variable.Queue[float64]
+	for _, i := range []int{2, 0, 5, 1, 0, 4, 3} {
+		q.Push(f[i])
+	}
+
+	if q.Len() != len(f) {
+		t.Errorf("len=%d, want=%d", q.Len(), len(f))
+	}
+
+	// in the reverse order they are pushed
+	for _, i := range []int{3, 4, 1, 5, 0, 2} {
+		if got := q.Pop(); got != f[i] {
+			t.Errorf("got=%v, want=f[%d]", got, i)
+		}
+	}
+
+	if q.Len() != 0 {
+		t.Errorf("len=%d", q.Len())
+	}
+}
+
+func BenchmarkBackward_rnn(b *testing.B) {
+	for _, n := range []int{1000, 10000} {
+		b.Run(fmt.Sprintf("steps=%d", n), func(b *testing.B) {
+			for range b.N {
+				b.StopTimer()
+				loss, _ := rnn(n)
+				b.StartTimer()
+
+				loss.Backward()
+			}
+		})
+	}
+}
+
+func BenchmarkQueue_rnn(b *testing.B) {
+	for _, n := range []int{1000, 10000} {
+		_, fs := rnn(n)
+
+		b.Run(fmt.Sprintf("heap/steps=%d", n), func(b *testing.B) {
+			for range b.N {
+				var q variable.Queue[float64]
+				schedule(fs, q.Push, q.Pop, q.Len)
+			}
+		})
+
+		b.Run(fmt.Sprintf("sort/steps=%d", n), func(b *testing.B) {
+			for range b.N {
+				var q sorted
+				schedule(fs, q.push, q.pop, q.len)
+			}
+		})
+	}
+}
+
+// rnn returns the loss of a recurrent network unrolled for n steps, and the function of the loss.
+// The inputs of the steps are projected before the loop, so their functions are pending until the end of Backward.
+func rnn(n int) (*variable.Variable[float64], *variable.Function[float64]) {
+	s := rand.NewPCG(1, 2)
+	wx, wh := variable.Randn([]int{2, 4}, s), variable.Randn([]int{4, 4}, s)
+
+	xs := make([]*variable.Variable[float64], n)
+	for i := range xs {
+		xs[i] = variable.MatMul(variable.Randn([]int{1, 2}, s), wx)
+	}
+
+	h := variable.Zeros[float64](1, 4)
+	for _, x := range xs {
+		h = variable.Tanh(variable.Add(variable.MatMul(h, wh), x))
+	}
+
+	loss := variable.Sum[float64]()(h)
+	return loss, loss.Creator
+}
+
+// schedule pops the functions in the graph of f in the order of the queue.
+func schedule(f *variable.Function[float64], push func(f *variable.Function[float64]), pop func() *variable.Function[float64], size func() int) {
+	push(f)
+	for size() > 0 {
+		for _, x := range pop().Input {
+			if x.Creator != nil {
+				push(x.Creator)
+			}
+		}
+	}
+}
+
+// sorted is a queue that sorts the pending functions every time a function is pushed.
+type sorted struct {
+	fs   []*variable.Function[float64]
+	seen map[*variable.Function[float64]]bool
+}
+
+func (q *sorted) push(f *variable.Function[float64]) {
+	if q.seen == nil {
+		q.seen = make(map[*variable.Function[float64]]bool)
+	}
+
+	if q.seen[f] {
+		return
+	}
+
+	q.seen[f] = true
+	q.fs = append(q.fs, f)
+	sort.Slice(q.fs, func(i, j int) bool { return q.fs[i].Generation < q.fs[j].Generation })
+}
+
+func (q *sorted) pop() *variable.Function[float64] {
+	f := q.fs[len(q.fs)-1]
+	q.fs = q.fs[:len(q.fs)-1]
+	return f
+}
+
+func (q *sorted) len() int {
+	return len(q.fs)
+}
@@ -3,31 +3,43 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // Reshape returns a function that reshapes x[0].
-func Reshape(shape ...int) func(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &ReshapeT{
+func Reshape[T tensor.Float](shape ...int) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &ReshapeT[T]{
 			Shape: shape,
 		},
 	}).First
 }
 
 // ReshapeT is the differentiable reshape operation.
-type ReshapeT struct {
+type ReshapeT[T tensor.Float] struct {
 	Shape  []int
 	xShape []int
 }
 
-func (f *ReshapeT) Forward(x ...*Variable) []*Variable {
+func (f *ReshapeT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.xShape = x[0].Shape()
 
 	y := tensor.Reshape(x[0].Data, f.Shape...)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *ReshapeT) Backward(gy ...*Variable) []*Variable {
-	return []*Variable{
-		Reshape(f.xShape...)(gy[0]),
+func (f *ReshapeT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Reshape[T](f.xShape...)(gy[0]),
+	}
+}
+
+func (f *ReshapeT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Reshape[T](f.Shape...)(tx[0]),
+	}
+}
+
+func (f *ReshapeT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Reshape[T](append([]int{x[0].Size(0)}, f.Shape...)...)(x[0]),
 	}
 }
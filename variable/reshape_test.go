@@ -13,7 +13,7 @@ func ExampleReshape() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Reshape(1, 6)(x)
+	y := variable.Reshape[float64](1, 6)(x)
 	y.Backward(variable.Opts{RetainGrad: true})
 
 	fmt.Println(x)
@@ -35,7 +35,7 @@ func ExampleReshape_double() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Reshape(1, 6)(x)
+	y := variable.Reshape[float64](1, 6)(x)
 	y.Backward(variable.Opts{CreateGraph: true, RetainGrad: true})
 	fmt.Println(x)
 	fmt.Println(y)
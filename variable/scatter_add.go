@@ -0,0 +1,62 @@
+package variable
+
+import "github.com/itsubaki/autograd/tensor"
+
+// ScatterAdd returns a function that adds x[1] to x[0] at the positions given by the index tensor along the given axis.
+// It is the adjoint of Gather. index must have the same number of dimensions as x[0] and x[1].
+func ScatterAdd[T tensor.Float](axis int, index *tensor.Tensor[int]) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &ScatterAddT[T]{
+			Axis:  axis,
+			Index: index,
+		},
+	}).First
+}
+
+// ScatterAddT is the differentiable scatter-add operation.
+type ScatterAddT[T tensor.Float] struct {
+	Axis   int
+	Index  *tensor.Tensor[int]
+	wShape []int
+}
+
+func (f *ScatterAddT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	f.wShape = x[1].Shape()
+
+	y := tensor.ScatterAddIndex(x[0].Data, x[1].Data, f.Axis, f.Index)
+	return []*Variable[T]{
+		From(y),
+	}
+}
+
+func (f *ScatterAddT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	gw := Gather[T](f.Axis, f.Index)(gy[0])
+	if !tensor.SliceEqual(gw.Shape(), f.wShape) {
+		// elements of x[1] outside of index do not contribute
+		ranges := make([]tensor.Range, len(f.wShape))
+		for i, s := range f.Index.Shape {
+			ranges[i] = tensor.R(0, s)
+		}
+
+		gw = SliceGrad[T](ranges, f.wShape)(gw)
+	}
+
+	return []*Variable[T]{
+		gy[0],
+		gw,
+	}
+}
+
+func (f *ScatterAddT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		ScatterAdd[T](f.Axis, f.Index)(tx[0], tx[1]),
+	}
+}
+
+func (f *ScatterAddT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	size := batchSize(x, batched)
+	index := expandIndex(size, f.Index)
+	return []*Variable[T]{
+		ScatterAdd[T](shiftAxis(f.Axis, f.Index.NumDims()), index)(expand(size, x, batched)...),
+	}
+}
@@ -0,0 +1,55 @@
+package variable_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleScatterAdd() {
+	// embedding (V, D)
+	x := variable.Zeros[float64](3, 2)
+
+	// sparse update (N, D)
+	w := variable.New(
+		1, 2,
+		3, 4,
+	).Reshape(2, 2)
+
+	index := tensor.New([]int{2, 2}, []int{
+		2, 2,
+		0, 0,
+	})
+
+	y := variable.ScatterAdd[float64](0, index)(x, w)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+	fmt.Println(w.Grad)
+
+	// Output:
+	// variable[3 2]([3 4 0 0 1 2])
+	// variable[3 2]([1 1 1 1 1 1])
+	// variable[2 2]([1 1 1 1])
+}
+
+func ExampleScatterAdd_partial() {
+	x := variable.Zeros[float64](3)
+	w := variable.New(1, 2, 3)
+
+	// w[2] is not scattered
+	index := tensor.New([]int{2}, []int{1, 1})
+
+	y := variable.ScatterAdd[float64](0, index)(x, w)
+	z := variable.Mul(y, variable.New(1, 10, 100))
+	z.Backward()
+
+	fmt.Println(y)
+	fmt.Println(w.Grad)
+
+	// Output:
+	// variable[3]([0 3 0])
+	// variable[3]([10 10 0])
+}
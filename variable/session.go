@@ -0,0 +1,95 @@
+package variable
+
+import "github.com/itsubaki/autograd/tensor"
+
+// Session holds the backprop and training flags of a computation.
+// A variable bound to a session by Bind carries it, and the outputs of a function carry the session of its inputs.
+// Unlike Nograd and TestMode, which change the process-wide Config, a session only affects the computations
+// that depend on its variables, so concurrent goroutines with different sessions do not interfere.
+// Config is consulted only by the computations of which no input is bound to a session.
+//
+// Recompute is set while functions are applied again to inputs they were already applied to,
+// such as by Checkpoint during Backward, so that they do not repeat their side effects,
+// for example the update of the running statistics of BatchNorm.
+//
+// DetectAnomaly enables anomaly detection like the function DetectAnomaly, but only for the computations of the session.
+// While a function is backpropagated, its gradients are bound to the session without DetectAnomaly,
+// which tells the functions applied in Backward from those in the forward.
+type Session struct {
+	EnableBackprop bool
+	Train          bool
+	Recompute      bool
+	DetectAnomaly  bool
+}
+
+// backprop is the session of the gradients while backpropagating without creating a graph.
+var backprop = &Session{EnableBackprop: false, Train: true}
+
+// Bind returns a new variable that shares the data of v and is bound to the session.
+func (v *Variable[T]) Bind(s *Session) *Variable[T] {
+	return &Variable[T]{
+		Name:    v.Name,
+		Data:    v.Data,
+		session: s,
+	}
+}
+
+// SessionOf returns the flags in effect for a function of the given inputs.
+// Backprop is enabled, training mode is on and anomaly detection is enabled only if they are for every input,
+// and it is a recomputation if any input is.
+func SessionOf[T tensor.Float](x ...*Variable[T]) Session {
+	if s := session(x...); s != nil {
+		return *s
+	}
+
+	return Session{
+		EnableBackprop: Config.EnableBackprop,
+		Train:          Config.Train,
+		DetectAnomaly:  Config.DetectAnomaly,
+	}
+}
+
+// enableBackprop reports whether backprop is enabled in the session, or in Config if the session is nil.
+func (s *Session) enableBackprop() bool {
+	if s == nil {
+		return Config.EnableBackprop
+	}
+
+	return s.EnableBackprop
+}
+
+// detectAnomaly reports whether anomaly detection is enabled in the session, or in Config if the session is nil.
+func (s *Session) detectAnomaly() bool {
+	if s == nil {
+		return Config.DetectAnomaly
+	}
+
+	return s.DetectAnomaly
+}
+
+// session returns the session of the outputs of a function of the given inputs.
+// The unbound inputs, such as parameters, take the session of the bound ones and do not consult Config.
+// It returns nil if no input is bound to a session.
+func session[T tensor.Float](x ...*Variable[T]) *Session {
+	var s *Session
+	for _, v := range x {
+		if v == nil || v.session == nil {
+			continue
+		}
+
+		if s == nil || s == v.session {
+			s = v.session
+			continue
+		}
+
+		// different sessions
+		s = &Session{
+			EnableBackprop: s.EnableBackprop && v.session.EnableBackprop,
+			Train:          s.Train && v.session.Train,
+			Recompute:      s.Recompute || v.session.Recompute,
+			DetectAnomaly:  s.DetectAnomaly && v.session.DetectAnomaly,
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,184 @@
+package variable_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleSession() {
+	s := &variable.Session{EnableBackprop: false, Train: false}
+
+	w := variable.New(2)
+	x := variable.New(3).Bind(s)
+	y := variable.Mul(x, w)
+	y.Backward()
+
+	fmt.Println(y, y.Creator == nil)
+	fmt.Println(w.Grad)
+	fmt.Println(variable.SessionOf(y))
+	fmt.Println(variable.Config.EnableBackprop)
+
+	// Output:
+	// variable(6) true
+	// <nil>
+	// {false false false false}
+	// true
+}
+
+func ExampleVariable_Bind() {
+	x := variable.New(1, 2)
+	s := &variable.Session{EnableBackprop: true, Train: false}
+
+	y := x.Bind(s)
+	fmt.Println(y.Data == x.Data)
+	fmt.Println(variable.SessionOf(x))
+	fmt.Println(variable.SessionOf(y))
+
+	// Output:
+	// true
+	// {true true false false}
+	// {true false false false}
+}
+
+func ExampleSessionOf() {
+	s0 := &variable.Session{EnableBackprop: true, Train: false}
+	s1 := &variable.Session{EnableBackprop: false, Train: true}
+
+	x0 := variable.New(1).Bind(s0)
+	x1 := variable.New(2).Bind(s1)
+	y := variable.Add(x0, x1)
+
+	fmt.Println(variable.SessionOf(x0, x1))
+	fmt.Println(variable.SessionOf(y))
+
+	// Output:
+	// {false false false false}
+	// {false false false false}
+}
+
+func TestSession_backward(t *testing.T) {
+	x := variable.New(3)
+	y := variable.Square(x)
+	y.Backward()
+
+	// the gradient is not bound to the session used while backpropagating
+	if got := variable.SessionOf(x.Grad); !got.EnableBackprop || !got.Train {
+		t.Errorf("got=%v", got)
+	}
+
+	z := variable.Mul(x.Grad, x)
+	if z.Creator == nil {
+		t.Errorf("graph is not created")
+	}
+}
+
+func TestSession_concurrent(t *testing.T) {
+	w := variable.New(1, 2, 3)
+	infer := &variable.Session{EnableBackprop: false, Train: false}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// training
+	errs := make(chan error, 2)
+	go func() {
+		defer wg.Done()
+
+		for range 1000 {
+			x := variable.New(1, 1, 1)
+			y := variable.Sum[float64]()(variable.Mul(x, variable.Square(x)))
+			y.Backward()
+
+			if x.Grad == nil || !tensor.SliceEqual(x.Grad.Shape(), []int{3}) {
+				errs <- fmt.Errorf("x.Grad=%v", x.Grad)
+				return
+			}
+		}
+	}()
+
+	// serving
+	go func() {
+		defer wg.Done()
+
+		for range 1000 {
+			x := variable.New(1, 1, 1).Bind(infer)
+			y := variable.Sum[float64]()(variable.Mul(x, w))
+			if y.Creator != nil {
+				errs <- fmt.Errorf("graph is created in inference")
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestSession_nograd(t *testing.T) {
+	w := variable.New(1, 2, 3)
+	train := &variable.Session{EnableBackprop: true, Train: true}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// Nograd and TestMode in another goroutine
+	done := make(chan struct{})
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				nograd, test := variable.Nograd(), variable.TestMode()
+				test.End()
+				nograd.End()
+			}
+		}
+	}()
+
+	// training with the session
+	errs := make(chan error, 1)
+	go func() {
+		defer wg.Done()
+		defer close(done)
+
+		for range 1000 {
+			w.Cleargrad()
+
+			x := variable.New(1, 1, 1).Bind(train)
+			y := variable.Sum[float64]()(variable.Mul(x, w))
+			if got := variable.SessionOf(y); !got.EnableBackprop || !got.Train {
+				errs <- fmt.Errorf("session=%v", got)
+				return
+			}
+
+			if y.Creator == nil {
+				errs <- fmt.Errorf("graph is not created in training")
+				return
+			}
+
+			y.Backward()
+			if w.Grad == nil {
+				errs <- fmt.Errorf("w.Grad=%v", w.Grad)
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
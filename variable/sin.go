@@ -1,30 +1,50 @@
 package variable
 
-import "github.com/itsubaki/autograd/tensor"
+import (
+	"math"
+
+	"github.com/itsubaki/autograd/tensor"
+)
 
 // Sin applies the sine function.
-func Sin(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &SinT{},
+func Sin[T tensor.Float](x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &SinT[T]{},
 	}).First(x...)
 }
 
 // SinT is the differentiable sine operation.
-type SinT struct {
-	x *Variable
+type SinT[T tensor.Float] struct {
+	x *Variable[T]
 }
 
-func (f *SinT) Forward(x ...*Variable) []*Variable {
+func (f *SinT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.x = x[0]
 
 	y := tensor.Sin(x[0].Data)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *SinT) Backward(gy ...*Variable) []*Variable {
-	return []*Variable{
+func (f *SinT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
 		Mul(Cos(f.x), gy[0]), // cos(x) * gy
 	}
 }
+
+func (f *SinT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Mul(Cos(x[0]), tx[0]), // cos(x) * tx
+	}
+}
+
+func (f *SinT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Sin(x[0]),
+	}
+}
+
+func (f *SinT[T]) Scalar() (func(x T) T, func(x T) T) {
+	return scalar[T](math.Sin), scalar[T](math.Cos)
+}
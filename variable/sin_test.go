@@ -25,7 +25,7 @@ func ExampleSin() {
 
 func ExampleSinT() {
 	x := variable.New(math.Pi / 4)
-	f := variable.SinT{}
+	f := variable.SinT[float64]{}
 
 	fmt.Println(x)
 	fmt.Println(f.Forward(x))
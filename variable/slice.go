@@ -0,0 +1,44 @@
+package variable
+
+import "github.com/itsubaki/autograd/tensor"
+
+// Slice returns a function that slices x[0] by the given ranges, one per leading axis.
+// The output is a view of x[0], even if a step is negative.
+func Slice[T tensor.Float](ranges ...tensor.Range) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &SliceT[T]{
+			Ranges: ranges,
+		},
+	}).First
+}
+
+// SliceT is the differentiable slicing operation.
+type SliceT[T tensor.Float] struct {
+	Ranges []tensor.Range
+	xShape []int
+}
+
+func (f *SliceT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	f.xShape = x[0].Shape()
+	return []*Variable[T]{
+		From(tensor.Slice(x[0].Data, f.Ranges...)),
+	}
+}
+
+func (f *SliceT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		SliceGrad[T](f.Ranges, f.xShape)(gy...),
+	}
+}
+
+func (f *SliceT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Slice[T](f.Ranges...)(tx[0]),
+	}
+}
+
+func (f *SliceT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Slice[T](append([]tensor.Range{tensor.All()}, f.Ranges...)...)(x[0]),
+	}
+}
@@ -0,0 +1,48 @@
+package variable
+
+import "github.com/itsubaki/autograd/tensor"
+
+// SliceGrad returns a function that scatters gradients into a zero tensor with the given shape.
+func SliceGrad[T tensor.Float](ranges []tensor.Range, shape []int) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &SliceGradT[T]{
+			Ranges: ranges,
+			Shape:  shape,
+		},
+	}).First
+}
+
+// SliceGradT is the differentiable gradient operation for Slice.
+type SliceGradT[T tensor.Float] struct {
+	Ranges []tensor.Range
+	Shape  []int
+}
+
+func (f *SliceGradT[T]) Forward(gy ...*Variable[T]) []*Variable[T] {
+	z := tensor.Zeros[T](f.Shape...)
+	gx := tensor.SliceAdd(z, gy[0].Data, f.Ranges...)
+
+	return []*Variable[T]{
+		From(gx),
+	}
+}
+
+func (f *SliceGradT[T]) Backward(ggx ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Slice[T](f.Ranges...)(ggx...),
+	}
+}
+
+func (f *SliceGradT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		SliceGrad[T](f.Ranges, f.Shape)(tx[0]),
+	}
+}
+
+func (f *SliceGradT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	ranges := append([]tensor.Range{tensor.All()}, f.Ranges...)
+	shape := append([]int{x[0].Size(0)}, f.Shape...)
+	return []*Variable[T]{
+		SliceGrad[T](ranges, shape)(x[0]),
+	}
+}
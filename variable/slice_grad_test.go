@@ -0,0 +1,25 @@
+package variable_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleSliceGrad() {
+	gy := variable.New(
+		1, 2,
+		3, 4,
+	).Reshape(2, 2)
+
+	y := variable.SliceGrad[float64]([]tensor.Range{tensor.All(), tensor.From(2, -2)}, []int{2, 3})(gy)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(gy.Grad)
+
+	// Output:
+	// variable[2 3]([2 0 1 4 0 3])
+	// variable[2 2]([1 1 1 1])
+}
@@ -0,0 +1,82 @@
+package variable_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleSlice() {
+	x := variable.New(
+		1, 2, 3,
+		4, 5, 6,
+	).Reshape(2, 3)
+
+	y := variable.Slice[float64](tensor.All(), tensor.R(0, 3, 2))(x)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+
+	// Output:
+	// variable[2 2]([1 3 4 6])
+	// variable[2 3]([1 0 1 1 0 1])
+}
+
+func ExampleSlice_last() {
+	// (N, T, H)
+	x := variable.New(
+		1, 2,
+		3, 4,
+		5, 6,
+
+		7, 8,
+		9, 10,
+		11, 12,
+	).Reshape(2, 3, 2)
+
+	y := variable.Slice[float64](tensor.All(), tensor.Index(-1))(x)
+	y.Backward()
+
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+
+	// Output:
+	// variable[2 2]([5 6 11 12])
+	// variable[2 3 2]([0 0 0 0 1 1 0 0 0 0 1 1])
+}
+
+func ExampleSlice_reverse() {
+	x := variable.New(1, 2, 3)
+
+	y := variable.Slice[float64](tensor.Reverse())(x)
+	z := variable.Mul(y, variable.New(1, 10, 100))
+	z.Backward()
+
+	fmt.Println(y, y.Stride())
+	fmt.Println(x.Grad)
+
+	// Output:
+	// variable[3]([3 2 1]) [-1]
+	// variable[3]([100 10 1])
+}
+
+func ExampleSlice_double() {
+	x := variable.New(1, 2, 3, 4)
+
+	y := variable.Slice[float64](tensor.R(1, 3))(x)
+	y.Backward(variable.Opts{CreateGraph: true})
+	fmt.Println(y)
+	fmt.Println(x.Grad)
+
+	gx := x.Grad
+	x.Cleargrad()
+	gx.Backward()
+	fmt.Println(x.Grad)
+
+	// Output:
+	// variable[2]([2 3])
+	// variable[4]([0 1 1 0])
+	// <nil>
+}
@@ -0,0 +1,60 @@
+package variable
+
+import (
+	"github.com/itsubaki/autograd/linalg"
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// Solve returns a variable representing the solution of x[0] * y = x[1].
+// x[0] has shape (..., n, n) and x[1] has shape (..., n, k). The batch dimensions are broadcast.
+func Solve[T tensor.Float](x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &SolveT[T]{},
+	}).First(x...)
+}
+
+// SolveT is the differentiable linear solve operation.
+type SolveT[T tensor.Float] struct {
+	a, y           *Variable[T]
+	aShape, bShape []int
+}
+
+func (f *SolveT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	f.a = x[0]
+	f.aShape, f.bShape = x[0].Shape(), x[1].Shape()
+
+	f.y = From(linalg.Solve(x[0].Data, x[1].Data))
+	return []*Variable[T]{
+		f.y,
+	}
+}
+
+func (f *SolveT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	gb := Solve(mT(f.a), gy[0])    // a^-T * gy
+	ga := Neg(MatMul(gb, mT(f.y))) // -gb * y^T
+
+	if !tensor.SliceEqual(ga.Shape(), f.aShape) {
+		ga = SumTo[T](f.aShape...)(ga)
+	}
+
+	if !tensor.SliceEqual(gb.Shape(), f.bShape) {
+		gb = SumTo[T](f.bShape...)(gb)
+	}
+
+	return []*Variable[T]{
+		ga,
+		gb,
+	}
+}
+
+func (f *SolveT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Solve(x[0], Sub(tx[1], MatMul(tx[0], y[0]))), // a^-1 * (tb - ta * y)
+	}
+}
+
+func (f *SolveT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Solve(align(x, batched)...),
+	}
+}
@@ -0,0 +1,46 @@
+package variable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleSolve() {
+	a := variable.New(
+		3, 1,
+		1, 2,
+	).Reshape(2, 2)
+	b := variable.New(
+		9,
+		8,
+	).Reshape(2, 1)
+
+	y := variable.Solve(a, b)
+	y.Backward()
+
+	fmt.Printf("%.4f\n", y.Data.Data)
+	fmt.Printf("%.4f\n", a.Grad.Data.Data)
+	fmt.Printf("%.4f\n", b.Grad.Data.Data)
+
+	// Output:
+	// [2.0000 3.0000]
+	// [-0.4000 -0.6000 -0.8000 -1.2000]
+	// [0.2000 0.4000]
+}
+
+func TestSolve(t *testing.T) {
+	cases := []struct {
+		a, b *variable.Variable[float64]
+	}{
+		{spd(3, 3), variable.Rand([]int{3, 2}, rand.Const())},
+		{spd(2, 3, 3), variable.Rand([]int{3, 2}, rand.Const())},
+		{spd(3, 3), variable.Rand([]int{2, 3, 1}, rand.Const())},
+	}
+
+	for _, c := range cases {
+		gradcheck(t, first(variable.Solve), c.a, c.b)
+	}
+}
@@ -3,9 +3,9 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // Split returns a function that splits x[0] along the given axis.
-func Split(size []int, axis int) func(x ...*Variable) []*Variable {
-	return (&Function{
-		Forwarder: &SplitT{
+func Split[T tensor.Float](size []int, axis int) func(x ...*Variable[T]) []*Variable[T] {
+	return (&Function[T]{
+		Forwarder: &SplitT[T]{
 			Size: size,
 			Axis: axis,
 		},
@@ -13,17 +13,17 @@ func Split(size []int, axis int) func(x ...*Variable) []*Variable {
 }
 
 // SplitT is the differentiable split operation.
-type SplitT struct {
+type SplitT[T tensor.Float] struct {
 	Size []int
 	Axis int
-	y    []*Variable
+	y    []*Variable[T]
 }
 
-func (f *SplitT) Forward(x ...*Variable) []*Variable {
+func (f *SplitT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	// split
 	y := tensor.Split(x[0].Data, f.Size, f.Axis)
 
-	f.y = make([]*Variable, len(y))
+	f.y = make([]*Variable[T], len(y))
 	for i, v := range y {
 		f.y[i] = From(v)
 	}
@@ -31,8 +31,8 @@ func (f *SplitT) Forward(x ...*Variable) []*Variable {
 	return f.y
 }
 
-func (f *SplitT) Backward(gy ...*Variable) []*Variable {
-	list := make([]*Variable, len(f.Size))
+func (f *SplitT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	list := make([]*Variable[T], len(f.Size))
 	for i := range f.Size {
 		if gy[i] == nil {
 			list[i] = ZeroLike(f.y[i])
@@ -42,7 +42,15 @@ func (f *SplitT) Backward(gy ...*Variable) []*Variable {
 		list[i] = gy[i]
 	}
 
-	return []*Variable{
-		Concat(f.Axis)(list...),
+	return []*Variable[T]{
+		Concat[T](f.Axis)(list...),
 	}
 }
+
+func (f *SplitT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return Split[T](f.Size, f.Axis)(tx[0])
+}
+
+func (f *SplitT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return Split[T](f.Size, shiftAxis(f.Axis, rank(x[0], true)))(x[0])
+}
@@ -13,7 +13,7 @@ func ExampleSplit() {
 		7, 8, 9,
 	).Reshape(3, 3)
 
-	y := variable.Split([]int{1, 2}, 0)(x)
+	y := variable.Split[float64]([]int{1, 2}, 0)(x)
 	fmt.Println(y[0])
 	fmt.Println(y[1])
 
@@ -33,7 +33,7 @@ func ExampleSplit_y1() {
 		7, 8, 9,
 	).Reshape(3, 3)
 
-	y := variable.Split([]int{1, 2}, 0)(x)
+	y := variable.Split[float64]([]int{1, 2}, 0)(x)
 	fmt.Println(y[0])
 	fmt.Println(y[1])
 
@@ -53,7 +53,7 @@ func ExampleSplit_y01() {
 		7, 8, 9,
 	).Reshape(3, 3)
 
-	y := variable.Split([]int{1, 2}, 0)(x)
+	y := variable.Split[float64]([]int{1, 2}, 0)(x)
 	fmt.Println(y[0])
 	fmt.Println(y[1])
 
@@ -74,7 +74,7 @@ func ExampleSplit_axis1() {
 		7, 8, 9,
 	).Reshape(3, 3)
 
-	y := variable.Split([]int{1, 2}, 1)(x)
+	y := variable.Split[float64]([]int{1, 2}, 1)(x)
 	fmt.Println(y[0])
 	fmt.Println(y[1])
 
@@ -94,7 +94,7 @@ func ExampleSplit_double() {
 		7, 8, 9,
 	).Reshape(3, 3)
 
-	y := variable.Split([]int{1, 2}, 0)(x)
+	y := variable.Split[float64]([]int{1, 2}, 0)(x)
 	fmt.Println(y[0])
 	fmt.Println(y[1])
 
@@ -1,10 +1,12 @@
 package variable
 
+import "github.com/itsubaki/autograd/tensor"
+
 // Square returns a variable representing x[0]^2.
-func Square(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &SquareT{
-			PowT{
+func Square[T tensor.Float](x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &SquareT[T]{
+			PowT[T]{
 				P: 2.0,
 			},
 		},
@@ -12,6 +14,6 @@ func Square(x ...*Variable) *Variable {
 }
 
 // SquareT is the differentiable square operation.
-type SquareT struct {
-	PowT
+type SquareT[T tensor.Float] struct {
+	PowT[T]
 }
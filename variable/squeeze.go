@@ -2,26 +2,38 @@ package variable
 
 import "github.com/itsubaki/autograd/tensor"
 
-func Squeeze(axis int) func(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &SqueezeT{
+func Squeeze[T tensor.Float](axis int) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &SqueezeT[T]{
 			Axis: axis,
 		},
 	}).First
 }
 
-type SqueezeT struct {
+type SqueezeT[T tensor.Float] struct {
 	Axis int
 }
 
-func (f *SqueezeT) Forward(x ...*Variable) []*Variable {
-	return []*Variable{
+func (f *SqueezeT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
 		From(tensor.Squeeze(x[0].Data, f.Axis)),
 	}
 }
 
-func (f *SqueezeT) Backward(gy ...*Variable) []*Variable {
-	return []*Variable{
-		Unsqueeze(f.Axis)(gy...),
+func (f *SqueezeT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Unsqueeze[T](f.Axis)(gy...),
+	}
+}
+
+func (f *SqueezeT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Squeeze[T](f.Axis)(tx[0]),
+	}
+}
+
+func (f *SqueezeT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Squeeze[T](shiftAxis(f.Axis, rank(x[0], true)))(x[0]),
 	}
 }
@@ -13,7 +13,7 @@ func ExampleSqueeze() {
 		7.0, 8.0, 9.0,
 	).Reshape(3, 1, 3)
 
-	y := variable.Squeeze(1)(x)
+	y := variable.Squeeze[float64](1)(x)
 	y.Backward()
 
 	fmt.Println(x)
@@ -33,7 +33,7 @@ func ExampleSqueeze_double() {
 		7.0, 8.0, 9.0,
 	).Reshape(3, 1, 3)
 
-	y := variable.Squeeze(1)(x)
+	y := variable.Squeeze[float64](1)(x)
 	y.Backward(variable.Opts{CreateGraph: true})
 
 	gx := x.Grad
@@ -3,47 +3,60 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // SubC returns a variable representing c - x[0].
-func SubC(c float64, x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &SubT{},
-	}).First(New(c), x[0])
+// c is a constant that does not require gradients.
+func SubC[T tensor.Float](c T, x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &SubT[T]{},
+	}).First(constant(c), x[0])
 }
 
 // Sub returns a variable representing x[0] - x[1].
-func Sub(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &SubT{},
+func Sub[T tensor.Float](x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &SubT[T]{},
 	}).First(x...)
 }
 
 // SubT is the differentiable subtraction operation.
-type SubT struct {
+type SubT[T tensor.Float] struct {
 	x0Shape []int
 	x1Shape []int
 }
 
-func (f *SubT) Forward(x ...*Variable) []*Variable {
+func (f *SubT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.x0Shape, f.x1Shape = x[0].Shape(), x[1].Shape()
 
 	y := tensor.Sub(x[0].Data, x[1].Data)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *SubT) Backward(gy ...*Variable) []*Variable {
+func (f *SubT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
 	gx0 := gy[0]
 	gx1 := Neg(gy[0]) // -1.0 * gy
 
 	if tensor.SliceEqual(f.x0Shape, f.x1Shape) {
-		return []*Variable{
+		return []*Variable[T]{
 			gx0,
 			gx1,
 		}
 	}
 
-	return []*Variable{
-		SumTo(f.x0Shape...)(gx0),
-		SumTo(f.x1Shape...)(gx1),
+	return []*Variable[T]{
+		SumTo[T](f.x0Shape...)(gx0),
+		SumTo[T](f.x1Shape...)(gx1),
+	}
+}
+
+func (f *SubT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Sub(tx[0], tx[1]),
+	}
+}
+
+func (f *SubT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Sub(align(x, batched)...),
 	}
 }
@@ -3,33 +3,33 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // Sum returns a function that sums x[0] along the given axes.
-func Sum(axes ...int) func(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &SumT{
+func Sum[T tensor.Float](axes ...int) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &SumT[T]{
 			Axes: axes,
 		},
 	}).First
 }
 
 // SumT is the differentiable sum operation.
-type SumT struct {
+type SumT[T tensor.Float] struct {
 	Axes   []int
 	xShape []int
 }
 
-func (f *SumT) Forward(x ...*Variable) []*Variable {
+func (f *SumT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.xShape = x[0].Shape()
 
 	y := tensor.Sum(x[0].Data, f.Axes...)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *SumT) Backward(gy ...*Variable) []*Variable {
+func (f *SumT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
 	if len(f.Axes) == 0 || len(f.Axes) == len(f.xShape) {
-		return []*Variable{
-			BroadcastTo(f.xShape...)(gy[0]),
+		return []*Variable[T]{
+			BroadcastTo[T](f.xShape...)(gy[0]),
 		}
 	}
 
@@ -37,9 +37,27 @@ func (f *SumT) Backward(gy ...*Variable) []*Variable {
 	shape := tensor.KeepDims(f.xShape, f.Axes)
 
 	// broadcast
-	gy0 := Reshape(shape...)(gy[0])
-	bgy := BroadcastTo(f.xShape...)(gy0)
-	return []*Variable{
+	gy0 := Reshape[T](shape...)(gy[0])
+	bgy := BroadcastTo[T](f.xShape...)(gy0)
+	return []*Variable[T]{
 		bgy,
 	}
 }
+
+func (f *SumT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Sum[T](f.Axes...)(tx[0]),
+	}
+}
+
+func (f *SumT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	axes := shiftAxes(f.Axes, rank(x[0], true))
+	if len(axes) == 0 {
+		// the examples are scalars
+		return (&SumT[T]{}).Batch([]*Variable[T]{Unsqueeze[T](1)(x[0])}, batched)
+	}
+
+	return []*Variable[T]{
+		Sum[T](axes...)(x[0]),
+	}
+}
@@ -9,7 +9,7 @@ import (
 func ExampleSum() {
 	// p292
 	x := variable.New(1, 2, 3, 4, 5, 6)
-	y := variable.Sum()(x)
+	y := variable.Sum[float64]()(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -26,7 +26,7 @@ func ExampleSum_axis01() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Sum(0, 1)(x)
+	y := variable.Sum[float64](0, 1)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -43,7 +43,7 @@ func ExampleSum_axis0() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Sum(0)(x)
+	y := variable.Sum[float64](0)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -60,7 +60,7 @@ func ExampleSum_axis1() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Sum(1)(x)
+	y := variable.Sum[float64](1)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -77,7 +77,7 @@ func ExampleSum_axis21n() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Sum(-2, -1)(x)
+	y := variable.Sum[float64](-2, -1)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -91,7 +91,7 @@ func ExampleSum_axis21n() {
 func ExampleSum_double() {
 	x := variable.New(1, 2, 3, 4, 5, 6)
 
-	y := variable.Sum()(x)
+	y := variable.Sum[float64]()(x)
 	y.Backward(variable.Opts{CreateGraph: true})
 	fmt.Println(y)
 	fmt.Println(x.Grad)
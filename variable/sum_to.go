@@ -3,31 +3,50 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // SumTo returns a function that reduces x[0] to the given shape.
-func SumTo(shape ...int) func(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &SumToT{
+func SumTo[T tensor.Float](shape ...int) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &SumToT[T]{
 			Shape: shape,
 		},
 	}).First
 }
 
 // SumToT is the differentiable SumTo operation.
-type SumToT struct {
+type SumToT[T tensor.Float] struct {
 	Shape  []int
 	xShape []int
 }
 
-func (f *SumToT) Forward(x ...*Variable) []*Variable {
+func (f *SumToT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.xShape = x[0].Shape()
 
 	y := tensor.SumTo(x[0].Data, f.Shape...)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *SumToT) Backward(gy ...*Variable) []*Variable {
-	return []*Variable{
-		BroadcastTo(f.xShape...)(gy[0]),
+func (f *SumToT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		BroadcastTo[T](f.xShape...)(gy[0]),
+	}
+}
+
+func (f *SumToT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		SumTo[T](f.Shape...)(tx[0]),
+	}
+}
+
+func (f *SumToT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	// sum the leading axes of the examples that are not in the shape
+	lead := rank(x[0], true) - len(f.Shape)
+	v := x[0]
+	if lead > 0 {
+		v = Sum[T](shiftAxes(nil, lead)...)(v)
+	}
+
+	return []*Variable[T]{
+		SumTo[T](append([]int{v.Size(0)}, f.Shape...)...)(v),
 	}
 }
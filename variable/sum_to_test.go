@@ -13,7 +13,7 @@ func ExampleSumTo() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.SumTo(1, 3)(x)
+	y := variable.SumTo[float64](1, 3)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -31,7 +31,7 @@ func ExampleSumTo_axes21() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.SumTo(2, 1)(x)
+	y := variable.SumTo[float64](2, 1)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -48,7 +48,7 @@ func ExampleSumTo_axes11() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.SumTo(1, 1)(x)
+	y := variable.SumTo[float64](1, 1)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -65,7 +65,7 @@ func ExampleSumTo_double() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.SumTo(1, 3)(x)
+	y := variable.SumTo[float64](1, 3)(x)
 	y.Backward(variable.Opts{CreateGraph: true})
 	fmt.Println(y)
 	fmt.Println(x.Grad)
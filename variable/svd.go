@@ -0,0 +1,127 @@
+package variable
+
+import (
+	"github.com/itsubaki/autograd/linalg"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// SVD returns the reduced singular value decomposition of x[0], such that x[0] = u * diag(s) * vt.
+// The singular values s are in descending order.
+// The singular values must be distinct and non-zero for the gradient with respect to u and vt.
+func SVD[T tensor.Float](x ...*Variable[T]) (u, s, vt *Variable[T]) {
+	y := (&Function[T]{
+		Forwarder: &SVDT[T]{},
+	}).Forward(x...)
+
+	return y[0], y[1], y[2]
+}
+
+// SVDT is the differentiable singular value decomposition.
+type SVDT[T tensor.Float] struct {
+	y []*Variable[T]
+}
+
+func (f *SVDT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	u, s, vt := linalg.SVD(x[0].Data)
+	f.y = []*Variable[T]{
+		From(u),
+		From(s),
+		From(vt),
+	}
+
+	return f.y
+}
+
+func (f *SVDT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	// gx = u * (j * s + diag(gs) + s * k) * v^T
+	//    + (I - u * u^T) * gu * s^-1 * v^T
+	//    + u * s^-1 * gv^T * (I - v * v^T)
+	//
+	// j = f * (u^T * gu - gu^T * u)
+	// k = f * (v^T * gv - gv^T * v)
+	// f[i, j] = 1 / (s[j]^2 - s[i]^2) for i != j, and 0 for i = j
+	g := orZero(gy, f.y)
+	u, s, vt, gu, gs, gvt := f.y[0], f.y[1], f.y[2], g[0], g[1], g[2]
+	gv := mT(gvt)
+
+	i := eye[T](s.Size(-1))
+	s2 := Square(s)
+	e := Add(Sub(Unsqueeze[T](-2)(s2), Unsqueeze[T](-1)(s2)), i)
+	fm := Mul(Pow[T](-1)(e), SubC(1, i))
+
+	utgu := MatMul(mT(u), gu)
+	vtgv := MatMul(vt, gv)
+	j := Mul(fm, Sub(utgu, mT(utgu)))
+	k := Mul(fm, Sub(vtgv, mT(vtgv)))
+
+	// x * diag(s) scales the columns, diag(s) * x scales the rows
+	srow, scol := Unsqueeze[T](-2)(s), Unsqueeze[T](-1)(s)
+	inner := Add(Add(Mul(j, srow), diag(gs)), Mul(scol, k))
+	gx := MatMul(MatMul(u, inner), vt)
+
+	m, n := u.Size(-2), vt.Size(-1)
+	sinv := Pow[T](-1)(srow)
+	if m > u.Size(-1) {
+		// (gu - u * u^T * gu) * s^-1 * v^T
+		pu := Sub(gu, MatMul(u, utgu))
+		gx = Add(gx, MatMul(Mul(pu, sinv), vt))
+	}
+
+	if n > vt.Size(-2) {
+		// u * s^-1 * (gv^T - gv^T * v * v^T)
+		pv := Sub(gvt, MatMul(mT(vtgv), vt))
+		gx = Add(gx, MatMul(u, Mul(mT(sinv), pv)))
+	}
+
+	return []*Variable[T]{
+		gx,
+	}
+}
+
+func (f *SVDT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	// p = u^T * tx * v
+	// ts = diagonal(p)
+	// tu = u * (f * (p * s + s * p^T)) + (I - u * u^T) * tx * v * s^-1
+	// tv = v * (f * (s * p + p^T * s)) + (I - v * v^T) * tx^T * u * s^-1
+	u, s, vt := y[0], y[1], y[2]
+	v := mT(vt)
+
+	i := eye[T](s.Size(-1))
+	s2 := Square(s)
+	e := Add(Sub(Unsqueeze[T](-2)(s2), Unsqueeze[T](-1)(s2)), i)
+	fm := Mul(Pow[T](-1)(e), SubC(1, i))
+
+	// x * diag(s) scales the columns, diag(s) * x scales the rows
+	srow, scol := Unsqueeze[T](-2)(s), Unsqueeze[T](-1)(s)
+	p := MatMul(MatMul(mT(u), tx[0]), v)
+	tu := MatMul(u, Mul(fm, Add(Mul(p, srow), Mul(scol, mT(p)))))
+	tv := MatMul(v, Mul(fm, Add(Mul(scol, p), Mul(mT(p), srow))))
+
+	m, n := u.Size(-2), vt.Size(-1)
+	sinv := Pow[T](-1)(srow)
+	if m > u.Size(-1) {
+		txv := MatMul(tx[0], v)
+		tu = Add(tu, Mul(Sub(txv, MatMul(u, MatMul(mT(u), txv))), sinv))
+	}
+
+	if n > vt.Size(-2) {
+		txu := MatMul(mT(tx[0]), u)
+		tv = Add(tv, Mul(Sub(txu, MatMul(v, MatMul(vt, txu))), sinv))
+	}
+
+	return []*Variable[T]{
+		tu,
+		diagonal(p),
+		mT(tv),
+	}
+}
+
+func (f *SVDT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	u, s, vt := SVD(x[0])
+	return []*Variable[T]{
+		u,
+		s,
+		vt,
+	}
+}
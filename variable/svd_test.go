@@ -0,0 +1,41 @@
+package variable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleSVD() {
+	x := variable.New(
+		3, 0,
+		0, 2,
+	).Reshape(2, 2)
+
+	_, s, _ := variable.SVD(x)
+	s.Backward()
+
+	fmt.Printf("%.4f\n", s.Data.Data)
+	fmt.Printf("%.4f\n", x.Grad.Data.Data)
+
+	// Output:
+	// [3.0000 2.0000]
+	// [1.0000 0.0000 0.0000 1.0000]
+}
+
+func TestSVD(t *testing.T) {
+	cases := []struct {
+		x *variable.Variable[float64]
+	}{
+		{variable.Rand([]int{3, 3}, rand.Const())},
+		{variable.Rand([]int{4, 2}, rand.Const())},
+		{variable.Rand([]int{2, 4}, rand.Const())},
+		{variable.Rand([]int{2, 3, 2}, rand.Const())},
+	}
+
+	for _, c := range cases {
+		gradcheck(t, three(variable.SVD), c.x)
+	}
+}
@@ -1,28 +1,51 @@
 package variable
 
-import "github.com/itsubaki/autograd/tensor"
+import (
+	"math"
+
+	"github.com/itsubaki/autograd/tensor"
+)
 
 // Tanh applies the hyperbolic tangent function.
-func Tanh(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &TanhT{},
+func Tanh[T tensor.Float](x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &TanhT[T]{},
 	}).First(x...)
 }
 
 // TanhT is the differentiable hyperbolic tangent operation.
-type TanhT struct {
-	y *Variable
+type TanhT[T tensor.Float] struct {
+	y *Variable[T]
 }
 
-func (f *TanhT) Forward(x ...*Variable) []*Variable {
+func (f *TanhT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.y = From(tensor.Tanh(x[0].Data))
-	return []*Variable{
+	return []*Variable[T]{
 		f.y,
 	}
 }
 
-func (f *TanhT) Backward(gy ...*Variable) []*Variable {
-	return []*Variable{
+func (f *TanhT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
 		Mul(gy[0], SubC(1.0, Mul(f.y, f.y))), // gy * (1-y^2)
 	}
 }
+
+func (f *TanhT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Mul(tx[0], SubC(1.0, Mul(y[0], y[0]))), // tx * (1-y^2)
+	}
+}
+
+func (f *TanhT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Tanh(x[0]),
+	}
+}
+
+func (f *TanhT[T]) Scalar() (func(x T) T, func(x T) T) {
+	return scalar[T](math.Tanh), func(x T) T {
+		y := T(math.Tanh(float64(x)))
+		return 1.0 - y*y
+	}
+}
@@ -8,7 +8,7 @@ import "github.com/itsubaki/autograd/tensor"
 // The permutation of axes is automatically constructed based on ndim.
 // For example, if ndim = 4, the axes will be [0, 1, 3, 2], so the last
 // two dimensions are reversed while the others remain in order.
-func TransposeMatMul(ndim int) func(x ...*Variable) *Variable {
+func TransposeMatMul[T tensor.Float](ndim int) func(x ...*Variable[T]) *Variable[T] {
 	axes := make([]int, ndim)
 	for i := range ndim - 2 {
 		axes[i] = i
@@ -19,42 +19,77 @@ func TransposeMatMul(ndim int) func(x ...*Variable) *Variable {
 	axes[ndim-1] = ndim - 2
 
 	// transpose
-	return Transpose(axes...)
+	return Transpose[T](axes...)
 }
 
 // Transpose returns a function that transposes x[0] using the given axes.
-func Transpose(axes ...int) func(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &TransposeT{
+func Transpose[T tensor.Float](axes ...int) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &TransposeT[T]{
 			Axes: axes,
 		},
 	}).First
 }
 
 // TransposeT is the differentiable transpose operation.
-type TransposeT struct {
+type TransposeT[T tensor.Float] struct {
 	Axes []int
 	ndim int
 }
 
-func (f *TransposeT) Forward(x ...*Variable) []*Variable {
+func (f *TransposeT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.ndim = x[0].NumDims()
 
 	y := tensor.Transpose(x[0].Data, f.Axes...)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *TransposeT) Backward(gy ...*Variable) []*Variable {
-	return []*Variable{
-		Transpose(invperm(f.ndim, f.Axes...)...)(gy[0]),
+func (f *TransposeT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Transpose[T](invperm(f.ndim, f.Axes...)...)(gy[0]),
+	}
+}
+
+func (f *TransposeT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Transpose[T](f.Axes...)(tx[0]),
+	}
+}
+
+func (f *TransposeT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	ndim := rank(x[0], true)
+
+	axes := []int{0}
+	if len(f.Axes) == 0 {
+		// reverse the axes of the examples
+		for i := range ndim {
+			axes = append(axes, ndim-i)
+		}
+	}
+
+	for _, a := range f.Axes {
+		axes = append(axes, shiftAxis(a, ndim))
+	}
+
+	return []*Variable[T]{
+		Transpose[T](axes...)(x[0]),
 	}
 }
 
 // invperm returns the inverse permutation of the given axes for a tensor with ndim dimensions.
 func invperm(ndim int, axes ...int) []int {
 	out := make([]int, ndim)
+	if len(axes) == 0 {
+		// the reverse of the axes is its own inverse
+		for i := range ndim {
+			out[i] = ndim - 1 - i
+		}
+
+		return out
+	}
+
 	for i, a := range axes {
 		if a < 0 {
 			a += ndim
@@ -15,7 +15,7 @@ func ExampleTranspose() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Transpose(-1, -2)(x)
+	y := variable.Transpose[float64](-1, -2)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -33,7 +33,7 @@ func ExampleTranspose_double() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Transpose(-1, -2)(x)
+	y := variable.Transpose[float64](-1, -2)(x)
 	y.Backward(variable.Opts{CreateGraph: true})
 	fmt.Println(y)
 	fmt.Println(x.Grad)
@@ -51,10 +51,10 @@ func ExampleTranspose_double() {
 
 func TestTranspose(t *testing.T) {
 	cases := []struct {
-		x    *variable.Variable
+		x    *variable.Variable[float64]
 		axes []int
-		y    *variable.Variable
-		gx   *variable.Variable
+		y    *variable.Variable[float64]
+		gx   *variable.Variable[float64]
 	}{
 		{
 			x: variable.New(
@@ -157,7 +157,7 @@ func TestTranspose(t *testing.T) {
 	}
 
 	for _, c := range cases {
-		y := variable.Transpose(c.axes...)(c.x)
+		y := variable.Transpose[float64](c.axes...)(c.x)
 		if !tensor.IsCloseAll(y.Data, c.y.Data) {
 			t.Errorf("got=%v, want=%v", y.Data, c.y.Data)
 		}
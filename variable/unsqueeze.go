@@ -2,26 +2,38 @@ package variable
 
 import "github.com/itsubaki/autograd/tensor"
 
-func Unsqueeze(axis int) func(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &UnsqueezeT{
+func Unsqueeze[T tensor.Float](axis int) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &UnsqueezeT[T]{
 			Axis: axis,
 		},
 	}).First
 }
 
-type UnsqueezeT struct {
+type UnsqueezeT[T tensor.Float] struct {
 	Axis int
 }
 
-func (f *UnsqueezeT) Forward(x ...*Variable) []*Variable {
-	return []*Variable{
+func (f *UnsqueezeT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
 		From(tensor.Unsqueeze(x[0].Data, f.Axis)),
 	}
 }
 
-func (f *UnsqueezeT) Backward(gy ...*Variable) []*Variable {
-	return []*Variable{
-		Squeeze(f.Axis)(gy...),
+func (f *UnsqueezeT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Squeeze[T](f.Axis)(gy...),
+	}
+}
+
+func (f *UnsqueezeT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		Unsqueeze[T](f.Axis)(tx[0]),
+	}
+}
+
+func (f *UnsqueezeT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	return []*Variable[T]{
+		Unsqueeze[T](shiftAxis(f.Axis, rank(x[0], true)+1))(x[0]),
 	}
 }
@@ -13,7 +13,7 @@ func ExampleUnsqueeze() {
 		7.0, 8.0, 9.0,
 	).Reshape(3, 3)
 
-	y := variable.Unsqueeze(1)(x)
+	y := variable.Unsqueeze[float64](1)(x)
 	y.Backward()
 
 	fmt.Println(x)
@@ -3,78 +3,95 @@ package variable
 import (
 	"fmt"
 	randv2 "math/rand/v2"
-	"sort"
 
 	"github.com/itsubaki/autograd/tensor"
 )
 
 // Variable represents a value in the computation graph.
-type Variable struct {
+// The data and the gradient of a variable have the element type T, so functions, layers and optimizers
+// compute in the precision of their inputs. Use Convert to change the precision.
+type Variable[T tensor.Float] struct {
 	Name       string
-	Data       *tensor.Tensor[float64]
-	Grad       *Variable
-	Creator    *Function
+	Data       *tensor.Tensor[T]
+	Grad       *Variable[T]
+	Creator    *Function[T]
 	Generation int
+	session    *Session
+	tangent    *Variable[T]
+	batched    bool
+	nograd     bool
+	hooks      []func(grad *Variable[T]) *Variable[T]
 }
 
 // New returns a new variable from the given values.
-func New(v ...float64) *Variable {
-	return &Variable{Data: tensor.New([]int{len(v)}, v)}
+func New(v ...float64) *Variable[float64] {
+	return &Variable[float64]{Data: tensor.New([]int{len(v)}, v)}
 }
 
 // From returns a new variable backed by the given tensor.
-func From(v *tensor.Tensor[float64]) *Variable {
-	return &Variable{Data: v}
+func From[T tensor.Float](v *tensor.Tensor[T]) *Variable[T] {
+	return &Variable[T]{Data: v}
+}
+
+// Convert returns a new variable backed by a copy of the data of v with elements cast to T.
+// The returned variable does not have a creator, so the precision is converted at the boundary of the graph.
+func Convert[T, U tensor.Float](v *Variable[U]) *Variable[T] {
+	return &Variable[T]{Name: v.Name, Data: tensor.Convert[T](v.Data)}
+}
+
+// constant returns a new variable with the single value c that does not require gradients.
+func constant[T tensor.Float](c T) *Variable[T] {
+	return From(tensor.Full([]int{1}, c)).SetRequiresGrad(false)
 }
 
 // ZeroLike returns a new zero-filled variable with the same shape as v.
-func ZeroLike(v *Variable) *Variable {
-	return &Variable{Data: tensor.ZeroLike(v.Data)}
+func ZeroLike[T tensor.Float](v *Variable[T]) *Variable[T] {
+	return &Variable[T]{Data: tensor.ZeroLike(v.Data)}
 }
 
 // OneLike returns a new one-filled variable with the same shape as v.
-func OneLike(v *Variable) *Variable {
-	return &Variable{Data: tensor.OneLike(v.Data)}
+func OneLike[T tensor.Float](v *Variable[T]) *Variable[T] {
+	return &Variable[T]{Data: tensor.OneLike(v.Data)}
 }
 
 // Zeros returns a new zero-filled variable with the given shape.
-func Zeros(shape ...int) *Variable {
-	return &Variable{Data: tensor.Zeros[float64](shape...)}
+func Zeros[T tensor.Float](shape ...int) *Variable[T] {
+	return &Variable[T]{Data: tensor.Zeros[T](shape...)}
 }
 
 // Ones returns a new one-filled variable with the given shape.
-func Ones(shape ...int) *Variable {
-	return &Variable{Data: tensor.Ones[float64](shape...)}
+func Ones[T tensor.Float](shape ...int) *Variable[T] {
+	return &Variable[T]{Data: tensor.Ones[T](shape...)}
 }
 
 // Rand returns a new variable with pseudo-random values in [0.0, 1.0).
-func Rand(shape []int, s ...randv2.Source) *Variable {
-	return &Variable{Data: tensor.Rand(shape, s...)}
+func Rand(shape []int, s ...randv2.Source) *Variable[float64] {
+	return &Variable[float64]{Data: tensor.Rand(shape, s...)}
 }
 
 // Randn returns a new variable with values drawn from the standard normal distribution.
-func Randn(shape []int, s ...randv2.Source) *Variable {
-	return &Variable{Data: tensor.Randn(shape, s...)}
+func Randn(shape []int, s ...randv2.Source) *Variable[float64] {
+	return &Variable[float64]{Data: tensor.Randn(shape, s...)}
 }
 
 // Normal returns a new variable with values drawn from the normal distribution with the given mean and standard deviation.
-func Normal(shape []int, mean, stddev float64, s ...randv2.Source) *Variable {
-	return &Variable{Data: tensor.Normal(shape, mean, stddev, s...)}
+func Normal(shape []int, mean, stddev float64, s ...randv2.Source) *Variable[float64] {
+	return &Variable[float64]{Data: tensor.Normal(shape, mean, stddev, s...)}
 }
 
 // At returns the value at the given indices.
 // If no indices are given, it returns the first element.
-func (v *Variable) At(indices ...int) float64 {
+func (v *Variable[T]) At(indices ...int) T {
 	return v.Data.At(indices...)
 }
 
 // NumDims returns the number of dimensions of the variable.
-func (v *Variable) NumDims() int {
+func (v *Variable[T]) NumDims() int {
 	return v.Data.NumDims()
 }
 
 // Size returns the number of elements in the variable.
-func (v *Variable) Size(axis ...int) int {
+func (v *Variable[T]) Size(axis ...int) int {
 	if len(axis) == 0 {
 		return v.Data.Size()
 	}
@@ -88,48 +105,48 @@ func (v *Variable) Size(axis ...int) int {
 }
 
 // Shape returns the shape of the variable.
-func (v *Variable) Shape() []int {
+func (v *Variable[T]) Shape() []int {
 	shape := make([]int, len(v.Data.Shape))
 	copy(shape, v.Data.Shape)
 	return shape
 }
 
 // Stride returns the stride of the variable.
-func (v *Variable) Stride() []int {
+func (v *Variable[T]) Stride() []int {
 	stride := make([]int, len(v.Data.Stride))
 	copy(stride, v.Data.Stride)
 	return stride
 }
 
 // Reshape changes the shape of the variable.
-func (v *Variable) Reshape(shape ...int) *Variable {
+func (v *Variable[T]) Reshape(shape ...int) *Variable[T] {
 	v.Data = tensor.Reshape(v.Data, shape...)
 	return v
 }
 
 // Cleargrad clears the gradient of the variable.
-func (v *Variable) Cleargrad() {
+func (v *Variable[T]) Cleargrad() {
 	v.Grad = nil
 }
 
 // SetCreator sets the function that created the variable.
-func (v *Variable) SetCreator(f *Function) {
+func (v *Variable[T]) SetCreator(f *Function[T]) {
 	v.Creator = f
 	v.Generation = f.Generation + 1
 }
 
 // Unchain detaches the variable from its creator.
-func (v *Variable) Unchain() {
+func (v *Variable[T]) Unchain() {
 	v.Creator = nil
 }
 
 // UnchainBackward detaches the variable and its ancestors from the graph.
-func (v *Variable) UnchainBackward() {
+func (v *Variable[T]) UnchainBackward() {
 	if v.Creator == nil {
 		return
 	}
 
-	fs := append(make([]*Function, 0), v.Creator)
+	fs := append(make([]*Function[T], 0), v.Creator)
 	for len(fs) > 0 {
 		// pop
 		f := fs[len(fs)-1]
@@ -148,53 +165,119 @@ func (v *Variable) UnchainBackward() {
 }
 
 // Backward performs backpropagation starting from the variable.
-func (v *Variable) Backward(opts ...Opts) {
+// The seed gradient is v.Grad, or ones if it is nil, and it is passed to the hooks of v first.
+func (v *Variable[T]) Backward(opts ...Opts) {
 	if v.Grad == nil {
 		v.Grad = OneLike(v)
 	}
 
+	// hooks of the root
+	if len(v.hooks) > 0 {
+		gy := v.Grad
+		if NoCreateGraph(opts...) {
+			gy = gy.Bind(backprop)
+		}
+
+		v.Grad = v.hook(gy)
+		if v.Grad.session == backprop {
+			v.Grad.session = nil
+		}
+	}
+
 	if v.Creator == nil {
 		return
 	}
 
-	seen := make(map[*Function]bool)
-	fs := addFunc(make([]*Function, 0), v.Creator, seen)
-	for len(fs) > 0 {
+	// anomaly detection in the session of v
+	detect := SessionOf(v).DetectAnomaly
+
+	// the gradients of the variables with hooks are summed before calling the hooks
+	hooked, pending := make([]*Variable[T], 0), make(map[*Variable[T]]*Variable[T])
+	flush := func(x *Variable[T]) {
+		gx, ok := pending[x]
+		if !ok {
+			return
+		}
+
+		delete(pending, x)
+		x.Grad = add(x.Grad, x.hook(gx))
+		if x.Grad.session == backprop {
+			x.Grad.session = nil
+		}
+	}
+
+	var q Queue[T]
+	q.Push(v.Creator)
+	for q.Len() > 0 {
 		// pop
-		f := fs[len(fs)-1]
-		fs = fs[:len(fs)-1]
+		f := q.Pop()
+
+		// hooks
+		for _, y := range f.Output {
+			flush(y)
+		}
 
 		// gys
 		gys := grads(f.Output)
+		if NoCreateGraph(opts...) {
+			// bind gys to the backprop session so that the backward functions do not create a graph
+			for i := range gys {
+				if gys[i] != nil {
+					gys[i] = gys[i].Bind(backprop)
+				}
+			}
+		}
 
 		// backward
-		func() {
-			if NoCreateGraph(opts...) {
-				defer Nograd().End()
+		var gxs []*Variable[T]
+		if detect {
+			gxs = f.backward(gys...)
+		} else {
+			gxs = f.Backward(gys...)
+		}
+
+		// chain
+		xs, gxs := zip(f.Input, gxs)
+		for i, x := range xs {
+			if x.nograd {
+				continue
 			}
 
-			// backward
-			gxs := f.Backward(gys...)
+			if len(x.hooks) > 0 {
+				if _, ok := pending[x]; !ok {
+					hooked = append(hooked, x)
+				}
 
-			// chain
-			xs, gxs := zip(f.Input, gxs)
-			for i, x := range xs {
+				pending[x] = add(pending[x], gxs[i])
+			} else {
 				x.Grad = add(x.Grad, gxs[i])
+			}
 
-				if x.Creator != nil {
-					fs = addFunc(fs, x.Creator, seen)
-				}
+			if x.Creator != nil {
+				q.Push(x.Creator)
 			}
-		}()
+		}
+
+		// unbind the gradients from the backprop session
+		for _, x := range xs {
+			if x.Grad != nil && x.Grad.session == backprop {
+				x.Grad.session = nil
+			}
+		}
 
 		if NoRetainGrad(opts...) {
 			cleargrad(f.Output)
 		}
 	}
+
+	// hooks of the leaves
+	for _, x := range hooked {
+		flush(x)
+	}
 }
 
 // String returns a string representation of the variable.
-func (v *Variable) String() string {
+func (v *Variable[T]) String() string {
 	name := "variable"
 	if v.Name != "" {
 		name = v.Name
@@ -217,24 +300,13 @@ func (v *Variable) String() string {
 	return fmt.Sprintf("%s%v(%v)", name, v.Shape(), tensor.Clone(v.Data).Data)
 }
 
-func addFunc(fs []*Function, f *Function, seen map[*Function]bool) []*Function {
-	if _, ok := seen[f]; ok {
-		return fs
-	}
-
-	seen[f] = true
-	fs = append(fs, f)
-	sort.Slice(fs, func(i, j int) bool { return fs[i].Generation < fs[j].Generation })
-	return fs
-}
-
-func zip(xs, gxs []*Variable) ([]*Variable, []*Variable) {
+func zip[T tensor.Float](xs, gxs []*Variable[T]) ([]*Variable[T], []*Variable[T]) {
 	n := min(len(xs), len(gxs))
 	return xs[:n], gxs[:n]
 }
 
-func grads(y []*Variable) []*Variable {
-	gys := make([]*Variable, len(y))
+func grads[T tensor.Float](y []*Variable[T]) []*Variable[T] {
+	gys := make([]*Variable[T], len(y))
 	for i := range y {
 		gys[i] = y[i].Grad
 	}
@@ -242,7 +314,7 @@ func grads(y []*Variable) []*Variable {
 	return gys
 }
 
-func add(xgrad, gx *Variable) *Variable {
+func add[T tensor.Float](xgrad, gx *Variable[T]) *Variable[T] {
 	if xgrad == nil {
 		return gx
 	}
@@ -251,7 +323,7 @@ func add(xgrad, gx *Variable) *Variable {
 	return Add(xgrad, gx)
 }
 
-func cleargrad(output []*Variable) {
+func cleargrad[T tensor.Float](output []*Variable[T]) {
 	for _, y := range output {
 		y.Cleargrad()
 	}
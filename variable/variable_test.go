@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/itsubaki/autograd/rand"
+	"github.com/itsubaki/autograd/tensor"
 	"github.com/itsubaki/autograd/variable"
 )
 
@@ -41,14 +42,14 @@ func ExampleOneLike() {
 }
 
 func ExampleZeros() {
-	fmt.Println(variable.Zeros(2, 3))
+	fmt.Println(variable.Zeros[float64](2, 3))
 
 	// Output:
 	// variable[2 3]([0 0 0 0 0 0])
 }
 
 func ExampleOnes() {
-	fmt.Println(variable.Ones(2, 3))
+	fmt.Println(variable.Ones[float64](2, 3))
 
 	// Output:
 	// variable[2 3]([1 1 1 1 1 1])
@@ -93,6 +94,34 @@ func ExampleNormal() {
 	// [-0.3678242340302933 1.0919575041640825 -0.4438344619606553]
 }
 
+func ExampleFrom_float32() {
+	v := tensor.New([]int{2}, []float32{1.5, 2.5})
+
+	x := variable.From(v)
+	y := variable.Square(x)
+	y.Backward()
+
+	fmt.Println(x.Grad)
+	fmt.Printf("%T %v\n", x.Grad.Data.Data, x.Grad.Data.Data)
+
+	// Output:
+	// variable[2]([3 5])
+	// []float32 [3 5]
+}
+
+func ExampleConvert() {
+	x := variable.New(1.5, 2.5)
+	x.Name = "x"
+
+	y := variable.Convert[float32](x)
+	fmt.Println(y)
+	fmt.Printf("%T %v\n", y.Data.Data, y.Data.Data)
+
+	// Output:
+	// x[2]([1.5 2.5])
+	// []float32 [1.5 2.5]
+}
+
 func ExampleVariable_NumDims() {
 	x := variable.New(
 		1, 2, 3,
@@ -153,7 +182,7 @@ func ExampleVariable_Unchain() {
 	fmt.Println(y.Creator) // nil
 
 	// Output:
-	// *variable.PowT[variable(1)]
+	// *variable.PowT[float64][variable(1)]
 	// <nil>
 }
 
@@ -175,10 +204,10 @@ func ExampleVariable_UnchainBackward() {
 	fmt.Println(z.Creator) // nil
 
 	// Output:
-	// *variable.PowT[variable(1)]
-	// *variable.SinT[variable(1)]
+	// *variable.PowT[float64][variable(1)]
+	// *variable.SinT[float64][variable(1)]
 	// <nil>
-	// *variable.SinT[variable(1)]
+	// *variable.SinT[float64][variable(1)]
 	// <nil>
 	// <nil>
 }
@@ -207,11 +236,11 @@ func Example_add() {
 }
 
 func Example_zip() {
-	xs := []*variable.Variable{
+	xs := []*variable.Variable[float64]{
 		variable.New(1),
 		variable.New(2),
 	}
-	gxs := []*variable.Variable{
+	gxs := []*variable.Variable[float64]{
 		variable.New(1),
 	}
 	xs, gxs = variable.Zip(xs, gxs)
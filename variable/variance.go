@@ -3,36 +3,36 @@ package variable
 import "github.com/itsubaki/autograd/tensor"
 
 // Variance returns a function that computes the variance of x[0] along the given axes.
-func Variance(axes ...int) func(x ...*Variable) *Variable {
-	return (&Function{
-		Forwarder: &VarianceT{
+func Variance[T tensor.Float](axes ...int) func(x ...*Variable[T]) *Variable[T] {
+	return (&Function[T]{
+		Forwarder: &VarianceT[T]{
 			Axes: axes,
 		},
 	}).First
 }
 
 // VarianceT is the differentiable variance operation.
-type VarianceT struct {
+type VarianceT[T tensor.Float] struct {
 	Axes []int
-	x    *Variable
+	x    *Variable[T]
 }
 
-func (f *VarianceT) Forward(x ...*Variable) []*Variable {
+func (f *VarianceT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
 	f.x = x[0]
 
 	y := tensor.Variance(x[0].Data, f.Axes...)
-	return []*Variable{
+	return []*Variable[T]{
 		From(y),
 	}
 }
 
-func (f *VarianceT) Backward(gy ...*Variable) []*Variable {
+func (f *VarianceT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
 	if len(f.Axes) == 0 {
-		mu := Mean(f.Axes...)(f.x)      // mean(x)
-		xc := Sub(f.x, mu)              // x - mean(x)
-		size := f.x.Data.Size()         // N
-		gx := MulC(2/float64(size), xc) // 2/N * (x - mean(x))
-		return []*Variable{
+		mu := Mean[T](f.Axes...)(f.x) // mean(x)
+		xc := Sub(f.x, mu)            // x - mean(x)
+		size := f.x.Data.Size()       // N
+		gx := MulC(2/T(size), xc)     // 2/N * (x - mean(x))
+		return []*Variable[T]{
 			Mul(gy[0], gx),
 		}
 	}
@@ -46,13 +46,39 @@ func (f *VarianceT) Backward(gy ...*Variable) []*Variable {
 
 	// mu = mean(x, axes)
 	reshape := tensor.KeepDims(shape, f.Axes)
-	mu := Mean(f.Axes...)(f.x)              // mean(x, axes)
-	xc := Sub(f.x, Reshape(reshape...)(mu)) // x - mean(x, axes)
-	gx := MulC(2/float64(size), xc)         // 2/N * (x - mean(x, axes))
+	mu := Mean[T](f.Axes...)(f.x)              // mean(x, axes)
+	xc := Sub(f.x, Reshape[T](reshape...)(mu)) // x - mean(x, axes)
+	gx := MulC(2/T(size), xc)                  // 2/N * (x - mean(x, axes))
 
-	gy0 := Reshape(reshape...)(gy[0])
-	bgy := BroadcastTo(shape...)(gy0)
-	return []*Variable{
+	gy0 := Reshape[T](reshape...)(gy[0])
+	bgy := BroadcastTo[T](shape...)(gy0)
+	return []*Variable[T]{
 		Mul(bgy, gx),
 	}
 }
+
+func (f *VarianceT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	// 2 * mean((x - mean(x, axes)) * tx, axes)
+	mu := Mean[T](f.Axes...)(x[0])
+	if len(f.Axes) > 0 {
+		mu = Reshape[T](tensor.KeepDims(x[0].Shape(), f.Axes)...)(mu)
+	}
+
+	xc := Sub(x[0], mu)
+	ty := MulC(2, Mean[T](f.Axes...)(Mul(xc, tx[0])))
+	return []*Variable[T]{
+		Reshape[T](y[0].Shape()...)(ty),
+	}
+}
+
+func (f *VarianceT[T]) Batch(x []*Variable[T], batched []bool) []*Variable[T] {
+	axes := shiftAxes(f.Axes, rank(x[0], true))
+	if len(axes) == 0 {
+		// the examples are scalars
+		return (&VarianceT[T]{}).Batch([]*Variable[T]{Unsqueeze[T](1)(x[0])}, batched)
+	}
+
+	return []*Variable[T]{
+		Variance[T](axes...)(x[0]),
+	}
+}
@@ -12,7 +12,7 @@ func ExampleVariance() {
 		-1, 0, 1,
 	).Reshape(2, 3)
 
-	y := variable.Variance()(x)
+	y := variable.Variance[float64]()(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -29,7 +29,7 @@ func ExampleVariance_axis01() {
 		-1, 0, 1,
 	).Reshape(2, 3)
 
-	y := variable.Variance(0, 1)(x)
+	y := variable.Variance[float64](0, 1)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -46,7 +46,7 @@ func ExampleVariance_axis0() {
 		4, 5, 6,
 	).Reshape(2, 3)
 
-	y := variable.Variance(0)(x)
+	y := variable.Variance[float64](0)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -63,7 +63,7 @@ func ExampleVariance_axis1() {
 		-3, 0, 3,
 	).Reshape(2, 3)
 
-	y := variable.Variance(1)(x)
+	y := variable.Variance[float64](1)(x)
 	y.Backward()
 
 	fmt.Println(y)
@@ -80,7 +80,7 @@ func ExampleVariance_double() {
 		-3, 0, 3,
 	).Reshape(2, 3)
 
-	y := variable.Variance(1)(x)
+	y := variable.Variance[float64](1)(x)
 	y.Backward(variable.Opts{CreateGraph: true})
 	fmt.Println(y)
 	fmt.Println(x.Grad)
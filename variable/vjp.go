@@ -0,0 +1,63 @@
+package variable
+
+import "github.com/itsubaki/autograd/tensor"
+
+// VJP returns the vector-Jacobian products of gy and y with respect to each x.
+// Unlike Backward, the gradients are accumulated in a map instead of the Grad fields, and the hooks are not called.
+// The graph of the gradients is created if backprop is enabled for gy, so VJP can be composed for higher-order derivatives.
+// The inputs that do not require gradients are skipped, and the gradients are checked if anomaly detection is enabled for y.
+// If an x does not reach y, its gradient is zero.
+func VJP[T tensor.Float](y, gy *Variable[T], x ...*Variable[T]) []*Variable[T] {
+	grads := map[*Variable[T]]*Variable[T]{y: gy}
+	detect := SessionOf(y).DetectAnomaly
+
+	var q Queue[T]
+	if y.Creator != nil {
+		q.Push(y.Creator)
+	}
+
+	for q.Len() > 0 {
+		// pop
+		f := q.Pop()
+
+		// gys
+		gys := make([]*Variable[T], len(f.Output))
+		for i, o := range f.Output {
+			gys[i] = grads[o]
+		}
+
+		// backward
+		var gxs []*Variable[T]
+		if detect {
+			gxs = f.backward(gys...)
+		} else {
+			gxs = f.Backward(gys...)
+		}
+
+		// chain
+		xs, gxs := zip(f.Input, gxs)
+		for i, in := range xs {
+			if in.nograd {
+				continue
+			}
+
+			grads[in] = add(grads[in], gxs[i])
+			if in.Creator != nil {
+				q.Push(in.Creator)
+			}
+		}
+	}
+
+	gxs := make([]*Variable[T], len(x))
+	for i := range x {
+		gx, ok := grads[x[i]]
+		if !ok || gx == nil {
+			gxs[i] = ZeroLike(x[i])
+			continue
+		}
+
+		gxs[i] = gx
+	}
+
+	return gxs
+}
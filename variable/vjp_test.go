@@ -0,0 +1,54 @@
+package variable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleVJP() {
+	x := variable.New(1, 2, 3)
+	y := variable.Square(x)
+
+	gxs := variable.VJP(y, variable.New(1, 10, 100), x)
+	fmt.Println(gxs[0])
+	fmt.Println(x.Grad)
+
+	// Output:
+	// variable[3]([2 40 600])
+	// <nil>
+}
+
+func ExampleVJP_higher() {
+	x := variable.New(2)
+	y := variable.Pow(3.0)(x)
+
+	// d/dx 3x^2 = 6x
+	gx := variable.VJP(y, variable.OneLike(y), x)[0]
+	ggx := variable.VJP(gx, variable.OneLike(gx), x)[0]
+	fmt.Println(gx, ggx)
+
+	// Output:
+	// variable(12) variable(12)
+}
+
+func TestVJP_nograd(t *testing.T) {
+	x := variable.New(1, 2)
+	w := variable.New(3, 4).SetRequiresGrad(false)
+	y := variable.Mul(x, w)
+
+	gxs := variable.VJP(y, variable.OneLike(y), x, w, variable.New(5))
+	if gxs[0].Data.Data[0] != 3 || gxs[0].Data.Data[1] != 4 {
+		t.Errorf("gx=%v", gxs[0])
+	}
+
+	// the inputs that do not require gradients, and those that do not reach y, have zero gradients
+	for _, gx := range gxs[1:] {
+		for _, a := range gx.Data.Data {
+			if a != 0 {
+				t.Errorf("gx=%v", gx)
+			}
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package variable
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/itsubaki/autograd/tensor"
+)
+
+// Batcher is the interface implemented by differentiable operations that have a batching rule.
+type Batcher[T tensor.Float] interface {
+	// Batch returns the outputs for every example at once.
+	// x[i] has the examples along axis 0 if batched[i], and is shared by every example otherwise.
+	// The outputs must have the examples along axis 0.
+	Batch(x []*Variable[T], batched []bool) []*Variable[T]
+}
+
+// Batch returns a variable whose examples are along the given axis of x.
+// A function of batched variables is applied to each example, and its outputs are batched.
+// It uses the batching rule of the function if it implements Batcher, and loops over the examples otherwise.
+// The data of the returned variable has the examples along axis 0.
+func Batch[T tensor.Float](x *Variable[T], axis int) *Variable[T] {
+	if x.batched {
+		panic("nested batching is not supported")
+	}
+
+	return batch(moveaxis(x, axis, 0))
+}
+
+// Unbatch returns the batched variable y as a variable with the examples along the given axis.
+func Unbatch[T tensor.Float](y *Variable[T], axis int) *Variable[T] {
+	if !y.batched {
+		panic("variable is not batched")
+	}
+
+	return moveaxis(unbatch(y), 0, axis)
+}
+
+// Batched reports whether v is batched.
+func (v *Variable[T]) Batched() bool {
+	return v.batched
+}
+
+// vmap applies the function to each example of the batched inputs x.
+func (f *Function[T]) vmap(x []*Variable[T]) []*Variable[T] {
+	size := -1
+	xs, batched := make([]*Variable[T], len(x)), make([]bool, len(x))
+	for i, v := range x {
+		if v == nil || !v.batched {
+			xs[i] = v
+			continue
+		}
+
+		if size >= 0 && v.Size(0) != size {
+			panic(fmt.Sprintf("batch size %d does not match %d", v.Size(0), size))
+		}
+
+		size = v.Size(0)
+		xs[i], batched[i] = unbatch(v), true
+	}
+
+	var y []*Variable[T]
+	if b, ok := f.Forwarder.(Batcher[T]); ok {
+		y = b.Batch(xs, batched)
+	} else {
+		y = f.loop(size, xs, batched)
+	}
+
+	out := make([]*Variable[T], len(y))
+	for i := range y {
+		out[i] = batch(y[i])
+	}
+
+	return out
+}
+
+// loop applies a copy of the function to each example of x and stacks the outputs along axis 0.
+func (f *Function[T]) loop(size int, x []*Variable[T], batched []bool) []*Variable[T] {
+	var list [][]*Variable[T]
+	for i := range size {
+		xi := make([]*Variable[T], len(x))
+		for j := range x {
+			if !batched[j] {
+				xi[j] = x[j]
+				continue
+			}
+
+			xi[j] = Slice[T](tensor.Index(i))(x[j])
+		}
+
+		yi := (&Function[T]{Forwarder: clone(f.Forwarder)}).Forward(xi...)
+		if list == nil {
+			list = make([][]*Variable[T], len(yi))
+		}
+
+		for k := range yi {
+			list[k] = append(list[k], Unsqueeze[T](0)(yi[k]))
+		}
+	}
+
+	y := make([]*Variable[T], len(list))
+	for k := range list {
+		y[k] = Concat[T](0)(list[k]...)
+	}
+
+	return y
+}
+
+// clone returns a shallow copy of the forwarder.
+func clone[T tensor.Float](f Forwarder[T]) Forwarder[T] {
+	v := reflect.ValueOf(f)
+	if v.Kind() != reflect.Pointer {
+		return f
+	}
+
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+	return c.Interface().(Forwarder[T])
+}
+
+// BatchT marks its input as batched. Its gradient is unbatched.
+type BatchT[T tensor.Float] struct{}
+
+func (f *BatchT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	y := From(x[0].Data)
+	y.batched = true
+	return []*Variable[T]{
+		y,
+	}
+}
+
+func (f *BatchT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		unbatch(gy[0]),
+	}
+}
+
+func (f *BatchT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return tx
+}
+
+// UnbatchT unmarks its batched input. Its gradient is batched.
+type UnbatchT[T tensor.Float] struct{}
+
+func (f *UnbatchT[T]) Forward(x ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		From(x[0].Data),
+	}
+}
+
+func (f *UnbatchT[T]) Backward(gy ...*Variable[T]) []*Variable[T] {
+	return []*Variable[T]{
+		batch(gy[0]),
+	}
+}
+
+func (f *UnbatchT[T]) JVP(x, y, tx []*Variable[T]) []*Variable[T] {
+	return tx
+}
+
+// batch returns x marked as batched, bypassing the batching rules.
+func batch[T tensor.Float](x *Variable[T]) *Variable[T] {
+	return (&Function[T]{Forwarder: &BatchT[T]{}}).forward(x)[0]
+}
+
+// unbatch returns x unmarked, bypassing the batching rules.
+func unbatch[T tensor.Float](x *Variable[T]) *Variable[T] {
+	return (&Function[T]{Forwarder: &UnbatchT[T]{}}).forward(x)[0]
+}
+
+// moveaxis returns x with the axis src moved to dst.
+func moveaxis[T tensor.Float](x *Variable[T], src, dst int) *Variable[T] {
+	ndim := x.NumDims()
+	if src < 0 {
+		src += ndim
+	}
+
+	if dst < 0 {
+		dst += ndim
+	}
+
+	if src == dst {
+		return x
+	}
+
+	axes := make([]int, 0, ndim)
+	for i := range ndim {
+		if i != src {
+			axes = append(axes, i)
+		}
+	}
+
+	axes = append(axes[:dst], append([]int{src}, axes[dst:]...)...)
+	return Transpose[T](axes...)(x)
+}
+
+// rank returns the number of dimensions of an example of x.
+func rank[T tensor.Float](x *Variable[T], batched bool) int {
+	if batched {
+		return x.NumDims() - 1
+	}
+
+	return x.NumDims()
+}
+
+// align returns x with the batched variables reshaped to the same number of dimensions,
+// so that the examples along axis 0 broadcast against the variables shared by every example.
+func align[T tensor.Float](x []*Variable[T], batched []bool) []*Variable[T] {
+	var ndim int
+	for i := range x {
+		if x[i] == nil {
+			continue
+		}
+
+		ndim = max(ndim, rank(x[i], batched[i]))
+	}
+
+	out := make([]*Variable[T], len(x))
+	for i := range x {
+		if x[i] == nil || !batched[i] || x[i].NumDims() == ndim+1 {
+			out[i] = x[i]
+			continue
+		}
+
+		shape := x[i].Shape()
+		ones := make([]int, ndim+1-len(shape))
+		for j := range ones {
+			ones[j] = 1
+		}
+
+		out[i] = Reshape[T](append(append([]int{shape[0]}, ones...), shape[1:]...)...)(x[i])
+	}
+
+	return out
+}
+
+// expand returns x with the variables shared by every example broadcast along a new axis 0 of the given size.
+func expand[T tensor.Float](size int, x []*Variable[T], batched []bool) []*Variable[T] {
+	out := make([]*Variable[T], len(x))
+	for i := range x {
+		if batched[i] {
+			out[i] = x[i]
+			continue
+		}
+
+		out[i] = BroadcastTo[T](append([]int{size}, x[i].Shape()...)...)(Unsqueeze[T](0)(x[i]))
+	}
+
+	return out
+}
+
+// batchSize returns the size of axis 0 of the batched variables in x.
+func batchSize[T tensor.Float](x []*Variable[T], batched []bool) int {
+	for i := range x {
+		if batched[i] {
+			return x[i].Size(0)
+		}
+	}
+
+	return 0
+}
+
+// shiftAxis returns the axis of an example with ndim dimensions as an axis of the batched variable.
+func shiftAxis(axis, ndim int) int {
+	if axis < 0 {
+		axis += ndim
+	}
+
+	return axis + 1
+}
+
+// shiftAxes returns the axes of an example with ndim dimensions as axes of the batched variable.
+// If no axes are given, it returns every axis of the example.
+func shiftAxes(axes []int, ndim int) []int {
+	if len(axes) == 0 {
+		out := make([]int, ndim)
+		for i := range ndim {
+			out[i] = i + 1
+		}
+
+		return out
+	}
+
+	out := make([]int, len(axes))
+	for i, a := range axes {
+		out[i] = shiftAxis(a, ndim)
+	}
+
+	return out
+}
+
+// batched reports whether any of the variables is batched.
+func batched[T tensor.Float](x ...*Variable[T]) bool {
+	for _, v := range x {
+		if v != nil && v.batched {
+			return true
+		}
+	}
+
+	return false
+}
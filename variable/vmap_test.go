@@ -0,0 +1,189 @@
+package variable_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/itsubaki/autograd/tensor"
+	"github.com/itsubaki/autograd/variable"
+)
+
+func ExampleBatch() {
+	x := variable.New(1, 2, 3, 4, 5, 6).Reshape(2, 3)
+	w := variable.New(1, 0, -1)
+
+	// the examples are the columns of x
+	xs := variable.Batch(x, 1)
+	y := variable.Mul(xs, variable.Sum[float64]()(w))
+
+	fmt.Println(xs.Batched(), xs.Shape())
+	fmt.Println(y.Batched(), y.Shape())
+	fmt.Println(variable.Unbatch(y, 0))
+
+	// Output:
+	// true [3 2]
+	// true [3 2]
+	// variable[3 2]([0 0 0 0 0 0])
+}
+
+func ExampleUnbatch() {
+	x := variable.New(1, 2, 3, 4, 5, 6).Reshape(2, 3)
+
+	// the sum of each row
+	y := variable.Sum[float64]()(variable.Batch(x, 0))
+	fmt.Println(variable.Unbatch(y, 0))
+
+	// Output:
+	// variable[2]([6 15])
+}
+
+func ExampleBatch_backward() {
+	x := variable.New(1, 2, 3, 4, 5, 6).Reshape(2, 3)
+	w := variable.New(1, 2, 3)
+
+	y := variable.Sum[float64]()(variable.Mul(variable.Batch(x, 0), w))
+	z := variable.Sum[float64]()(variable.Unbatch(y, 0))
+	z.Backward()
+
+	fmt.Println(z)
+	fmt.Println(x.Grad)
+	fmt.Println(w.Grad)
+
+	// Output:
+	// variable(46)
+	// variable[2 3]([1 2 3 1 2 3])
+	// variable[3]([5 7 9])
+}
+
+func TestBatch(t *testing.T) {
+	s := rand.NewPCG(1, 2)
+	rand := func(shape ...int) *variable.Variable[float64] {
+		return variable.Rand(shape, s)
+	}
+
+	cases := []struct {
+		name string
+		f    func(x ...*variable.Variable[float64]) *variable.Variable[float64]
+		x    *variable.Variable[float64]
+		w    *variable.Variable[float64]
+	}{
+		{"add", variable.Add[float64], rand(4, 3), rand(2, 3)},
+		{"sub scalar", variable.Sub[float64], rand(4), rand(3)},
+		{"mul", variable.Mul[float64], rand(4, 2, 3), rand(3)},
+		{"div", variable.Div[float64], rand(4, 3), variable.AddC(1, rand(3))},
+		{"matmul", variable.MatMul[float64], rand(4, 1, 3), rand(3, 2)},
+		{"matmul rank", variable.MatMul[float64], rand(4, 2, 3), rand(5, 3, 2)},
+		{"solve", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return variable.Solve(x[1], x[0])
+		}, rand(4, 3, 1), variable.AddC(3, rand(3, 3))},
+		{"concat", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return variable.Concat[float64](0)(x...)
+		}, rand(4, 2, 3), rand(1, 3)},
+		{"broadcast_to", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return variable.Add(variable.BroadcastTo[float64](2, 3)(x[0]), x[1])
+		}, rand(4, 3), rand(2, 3)},
+		{"einsum", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return variable.Einsum[float64]("ij,j->i")(x[1], x[0])
+		}, rand(4, 3), rand(2, 3)},
+		{"gather", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return variable.Add(variable.Gather[float64](0, tensor.New([]int{2}, []int{1, 1}))(x[0]), x[1])
+		}, rand(4, 3), rand(2)},
+		{"scatter_add", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return variable.ScatterAdd[float64](0, tensor.New([]int{2}, []int{0, 2}))(x[0], x[1])
+		}, rand(4, 3), rand(2)},
+		{"gather axis", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return variable.Add(variable.Gather[float64](-1, tensor.New([]int{2, 2}, []int{2, 0, -1, 1}))(x[0]), x[1])
+		}, rand(4, 2, 3), rand(2, 2)},
+		{"scatter_add batched w", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return variable.ScatterAdd[float64](1, tensor.New([]int{2, 2}, []int{0, 2, 2, 2}))(x[1], x[0])
+		}, rand(4, 2, 3), rand(2, 3)},
+		{"scatter_add both", func(x ...*variable.Variable[float64]) *variable.Variable[float64] {
+			return variable.ScatterAdd[float64](-1, tensor.New([]int{1, 2}, []int{1, 1}))(x[0], variable.MulC(2, x[0]))
+		}, rand(4, 2, 3), rand(1)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := variable.Unbatch(c.f(variable.Batch(c.x, 0), c.w), 0)
+
+			list := make([]*variable.Variable[float64], c.x.Size(0))
+			for i := range list {
+				xi := variable.Slice[float64](tensor.Index(i))(c.x)
+				list[i] = variable.Unsqueeze[float64](0)(c.f(xi, c.w))
+			}
+
+			want := variable.Concat[float64](0)(list...)
+			if !tensor.SliceEqual(got.Shape(), want.Shape()) {
+				t.Fatalf("shape=%v, want=%v", got.Shape(), want.Shape())
+			}
+
+			if !tensor.IsCloseAll(got.Data, want.Data, 1e-8, 1e-8) {
+				t.Errorf("got=%v, want=%v", got.Data.Data, want.Data.Data)
+			}
+		})
+	}
+}
+
+func TestBatch_rule(t *testing.T) {
+	// the functions applied to the examples at once
+	for _, f := range []variable.Forwarder[float64]{&variable.GatherT[float64]{}, &variable.ScatterAddT[float64]{}} {
+		if _, ok := f.(variable.Batcher[float64]); !ok {
+			t.Errorf("%T does not implement Batcher", f)
+		}
+	}
+}
+
+func TestBatch_einsumLabels(t *testing.T) {
+	labels := "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	defer func() {
+		if r := recover(); r != fmt.Sprintf("no unused label for the batch axis in %q", labels+"->") {
+			t.Errorf("unexpected panic: %v", r)
+		}
+	}()
+
+	shape := make([]int, len(labels)+1)
+	for i := range shape {
+		shape[i] = 1
+	}
+
+	x := variable.Batch(variable.Ones[float64](shape...), 0)
+	variable.Einsum[float64](labels + "->")(x)
+	t.Fail()
+}
+
+func TestBatch_nested(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "nested batching is not supported" {
+			t.Errorf("unexpected panic: %v", r)
+		}
+	}()
+
+	x := variable.Batch(variable.New(1, 2), 0)
+	variable.Batch(x, 0)
+	t.Fail()
+}
+
+func TestBatch_size(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "batch size 3 does not match 2" {
+			t.Errorf("unexpected panic: %v", r)
+		}
+	}()
+
+	x0 := variable.Batch(variable.New(1, 2), 0)
+	x1 := variable.Batch(variable.New(1, 2, 3), 0)
+	variable.Add(x0, x1)
+	t.Fail()
+}
+
+func TestUnbatch(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "variable is not batched" {
+			t.Errorf("unexpected panic: %v", r)
+		}
+	}()
+
+	variable.Unbatch(variable.New(1, 2), 0)
+	t.Fail()
+}